@@ -0,0 +1,109 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// accessLogEntry is one JSON line in the access log
+type accessLogEntry struct {
+	Time       string  `json:"time"`
+	RequestID  string  `json:"request_id"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	Status     int     `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	ClientIP   string  `json:"client_ip"`
+}
+
+// accessLogger writes structured access logs for the serve subcommand
+type accessLogger struct {
+	trusted []*net.IPNet
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// newAccessLogger creates a logger writing JSON lines to w
+func newAccessLogger(w io.Writer, trusted []*net.IPNet) *accessLogger {
+	return &accessLogger{trusted: trusted, enc: json.NewEncoder(w)}
+}
+
+// validRequestID reports whether a client-supplied request ID is safe to
+// propagate into headers and logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// middleware tags each request with an X-Request-Id and logs it once the
+// handler returns
+func (l *accessLogger) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get("X-Request-Id")
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-Id", id)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.enc.Encode(accessLogEntry{
+			Time:       start.UTC().Format(time.RFC3339Nano),
+			RequestID:  id,
+			Method:     r.Method,
+			Path:       r.URL.RequestURI(),
+			Status:     rec.status,
+			DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			ClientIP:   clientIP(r, l.trusted),
+		})
+	})
+}
@@ -0,0 +1,107 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test each request is logged as a JSON line with a request ID
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newAccessLogger(&buf, nil).middleware(newAPIServer(statusCodes).routes())
+
+	req := httptest.NewRequest("GET", "/status/999?format=json", nil)
+	req.RemoteAddr = "192.0.2.10:5555"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid access log line: %v\n%s", err, buf.String())
+	}
+	if entry.Method != "GET" || entry.Path != "/status/999?format=json" || entry.Status != http.StatusNotFound {
+		t.Errorf("Unexpected request fields: %+v", entry)
+	}
+	if entry.ClientIP != "192.0.2.10" {
+		t.Errorf("Expected client IP 192.0.2.10, got %s", entry.ClientIP)
+	}
+	if entry.Time == "" || entry.DurationMs < 0 {
+		t.Errorf("Missing timing fields: %+v", entry)
+	}
+	if len(entry.RequestID) != 32 || rec.Header().Get("X-Request-Id") != entry.RequestID {
+		t.Errorf("Expected generated request ID in header and log, got header %q log %q",
+			rec.Header().Get("X-Request-Id"), entry.RequestID)
+	}
+}
+
+// Test client request IDs are propagated, and unsafe ones replaced
+func TestAccessLogRequestIDPropagation(t *testing.T) {
+	testCases := []struct {
+		sent      string
+		propagate bool
+	}{
+		{"abc-123", true},
+		{"trace.id:42_x", true},
+		{"bad id\n", false},
+		{strings.Repeat("a", 129), false},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		handler := newAccessLogger(&buf, nil).middleware(newAPIServer(statusCodes).routes())
+		req := httptest.NewRequest("GET", "/status/200", nil)
+		req.Header.Set("X-Request-Id", tc.sent)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		got := rec.Header().Get("X-Request-Id")
+		if (got == tc.sent) != tc.propagate {
+			t.Errorf("Request ID %q: propagate=%v, got %q", tc.sent, tc.propagate, got)
+		}
+		if !strings.Contains(buf.String(), `"request_id":"`+got+`"`) {
+			t.Errorf("Request ID %q missing from log: %s", got, buf.String())
+		}
+	}
+}
+
+// Test the middleware passes flushes through for streaming responses
+func TestAccessLogStreaming(t *testing.T) {
+	var buf bytes.Buffer
+	handler := newAccessLogger(&buf, nil).middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("chunk"))
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush failed through middleware: %v", err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/stream", nil))
+	if !rec.Flushed {
+		t.Error("Expected response to be flushed")
+	}
+}
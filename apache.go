@@ -0,0 +1,70 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// apacheErrorCodes are the error codes Apache httpd recognises; ErrorDocument
+// fails the config check for anything else
+var apacheErrorCodes = map[int]bool{
+	400: true, 401: true, 402: true, 403: true, 404: true, 405: true, 406: true, 407: true,
+	408: true, 409: true, 410: true, 411: true, 412: true, 413: true, 414: true, 415: true,
+	416: true, 417: true, 418: true, 421: true, 422: true, 423: true, 424: true, 426: true,
+	428: true, 429: true, 431: true, 451: true,
+	500: true, 501: true, 502: true, 503: true, 504: true, 505: true, 506: true, 507: true,
+	508: true, 510: true, 511: true,
+}
+
+// apacheQuote quotes a message for an Apache config directive
+func apacheQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// printApache writes an ErrorDocument directive per code, grouped by class;
+// codes ErrorDocument can't be used with are skipped with a warning
+func printApache(w, warn io.Writer, codes []StatusCode, inline bool) {
+	lastClass := 0
+	for _, sc := range codes {
+		if !apacheErrorCodes[sc.Code] {
+			fmt.Fprintf(warn, "warning: skipping %d - Apache ErrorDocument only supports recognised 4xx and 5xx codes\n", sc.Code)
+			continue
+		}
+
+		if class := sc.Code / 100; class != lastClass {
+			if lastClass != 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "# %dxx %s\n", class, sc.Type)
+			lastClass = class
+		}
+
+		if inline {
+			fmt.Fprintf(w, "ErrorDocument %d %s\n", sc.Code, apacheQuote(shortFor(sc.Code)))
+		} else {
+			fmt.Fprintf(w, "ErrorDocument %d /errors/%d.html\n", sc.Code, sc.Code)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test ErrorDocument output against the golden files for both variants
+func TestApacheGolden(t *testing.T) {
+	codes, err := processInputs("200,301,404,418,499,500,503", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, inline := range map[string]bool{"path": false, "inline": true} {
+		var out, warn bytes.Buffer
+		printApache(&out, &warn, codes, inline)
+		checkGolden(t, filepath.Join("testdata", "apache", name+".golden"), out.Bytes())
+
+		for _, skipped := range []string{"200", "301", "499"} {
+			if !strings.Contains(warn.String(), "skipping "+skipped+" ") {
+				t.Errorf("%s: expected a warning for %s, got:\n%s", name, skipped, warn.String())
+			}
+		}
+	}
+}
+
+// Test messages are quoted and escaped
+func TestApacheQuote(t *testing.T) {
+	testCases := map[string]string{
+		"Not Found":     `"Not Found"`,
+		"I'm a teapot":  `"I'm a teapot"`,
+		`say "hi"`:      `"say \"hi\""`,
+		`back\slash`:    `"back\\slash"`,
+		`trailing \`:    `"trailing \\"`,
+		`"`:             `"\""`,
+		"":              `""`,
+		"(Unused) code": `"(Unused) code"`,
+	}
+	for in, expected := range testCases {
+		if got := apacheQuote(in); got != expected {
+			t.Errorf("apacheQuote(%q): expected %s, got %s", in, expected, got)
+		}
+	}
+}
+
+// Test every recognised code is a 4xx or 5xx code in the dataset
+func TestApacheErrorCodes(t *testing.T) {
+	for code := range apacheErrorCodes {
+		if code < 400 || code > 599 {
+			t.Errorf("Unexpected ErrorDocument code %d", code)
+		}
+		if _, found := findStatusCode(code); !found {
+			t.Errorf("ErrorDocument code %d is not in the dataset", code)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// clipboardEnv names an environment variable overriding the clipboard command
+const clipboardEnv = "HTTPSTATUS_CLIPBOARD"
+
+// clipboardWaitDelay bounds how long stderr is drained after the helper exits
+const clipboardWaitDelay = 500 * time.Millisecond
+
+// clipboardCommand picks the command that writes stdin to the system clipboard
+func clipboardCommand(goos string, getenv func(string) string, lookPath func(string) (string, error)) ([]string, error) {
+	if custom := strings.Fields(getenv(clipboardEnv)); len(custom) > 0 {
+		return custom, nil
+	}
+
+	var candidates [][]string
+	switch {
+	case goos == "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case goos == "windows":
+		candidates = [][]string{{"clip.exe"}}
+	case getenv("WAYLAND_DISPLAY") != "":
+		candidates = [][]string{{"wl-copy"}}
+	case getenv("DISPLAY") != "":
+		candidates = [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	default:
+		return nil, fmt.Errorf("no clipboard available: neither WAYLAND_DISPLAY nor DISPLAY is set (set %s to a command to use instead)", clipboardEnv)
+	}
+
+	var names []string
+	for _, c := range candidates {
+		if _, err := lookPath(c[0]); err == nil {
+			return c, nil
+		}
+		names = append(names, c[0])
+	}
+	return nil, fmt.Errorf("no clipboard utility found - looked for %s (set %s to a command to use instead)", strings.Join(names, ", "), clipboardEnv)
+}
+
+// copyToClipboard places rendered text output on the system clipboard
+func copyToClipboard(data []byte) error {
+	if !utf8.Valid(data) {
+		return fmt.Errorf("cannot copy binary output to the clipboard")
+	}
+
+	args, err := clipboardCommand(runtime.GOOS, os.Getenv, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	// xclip and wl-copy fork a child that owns the clipboard and may keep
+	// inherited pipes open, so stdout is left unset and stderr is only
+	// read for a moment once the helper itself has exited
+	var stderr bytes.Buffer
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = clipboardWaitDelay
+	if err := cmd.Run(); err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+		return fmt.Errorf("copying to clipboard with %s: %v %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
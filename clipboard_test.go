@@ -0,0 +1,135 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test the clipboard command is chosen per platform and session
+func TestClipboardCommand(t *testing.T) {
+	testCases := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		available []string
+		expected  string
+		errPart   string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, "pbcopy", ""},
+		{"windows", "windows", nil, []string{"clip.exe"}, "clip.exe", ""},
+		{"wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, "wl-copy", ""},
+		{"x11 xclip", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel"}, "xclip -selection clipboard", ""},
+		{"x11 xsel", "freebsd", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, "xsel --clipboard --input", ""},
+		{"override", "linux", map[string]string{clipboardEnv: "tee /tmp/clip"}, nil, "tee /tmp/clip", ""},
+		{"x11 missing", "linux", map[string]string{"DISPLAY": ":0"}, nil, "", "looked for xclip, xsel"},
+		{"wayland missing", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, nil, "", "looked for wl-copy"},
+		{"headless", "linux", nil, nil, "", "neither WAYLAND_DISPLAY nor DISPLAY"},
+	}
+
+	for _, tc := range testCases {
+		getenv := func(key string) string { return tc.env[key] }
+		lookPath := func(name string) (string, error) {
+			for _, a := range tc.available {
+				if a == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+
+		cmd, err := clipboardCommand(tc.goos, getenv, lookPath)
+		if tc.errPart != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errPart) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.errPart, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got := strings.Join(cmd, " "); got != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+}
+
+// Test output is piped to the clipboard command and binary output is refused
+func TestCopyToClipboard(t *testing.T) {
+	if _, err := exec.LookPath("tee"); err != nil {
+		t.Skip("tee not available")
+	}
+	path := filepath.Join(t.TempDir(), "clipboard")
+	t.Setenv(clipboardEnv, "tee "+path)
+
+	if err := copyToClipboard([]byte("404 Not Found\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "404 Not Found\n" {
+		t.Errorf("Expected clipboard contents to match output, got %q", data)
+	}
+
+	if err := copyToClipboard([]byte{0x50, 0x4b, 0x03, 0xff}); err == nil || !strings.Contains(err.Error(), "binary") {
+		t.Errorf("Expected binary output to be rejected, got %v", err)
+	}
+}
+
+// Test a helper that leaves a child holding its pipes does not block the copy
+func TestCopyToClipboardForkingHelper(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "clipboard")
+	helper := filepath.Join(dir, "xclip")
+	script := "#!/bin/sh\ncat > " + path + "\nsleep 30 &\n"
+	if err := os.WriteFile(helper, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(clipboardEnv, helper)
+
+	start := time.Now()
+	if err := copyToClipboard([]byte("404 Not Found\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected copy to return once the helper exits, took %v", elapsed)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "404 Not Found\n" {
+		t.Errorf("Expected clipboard contents to match output, got %q", data)
+	}
+}
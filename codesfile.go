@@ -0,0 +1,83 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// readCodesFiles reads the lookup targets listed in --codes-file files, in
+// order; "-" reads the list from stdin
+func readCodesFiles(paths []string, stdin io.Reader) ([]string, error) {
+	var tokens []string
+	usedStdin := false
+	for _, path := range paths {
+		if path == "-" {
+			if usedStdin {
+				return nil, fmt.Errorf("invalid codes file: '-' - stdin can only be read once")
+			}
+			usedStdin = true
+			list, err := parseCodesList(stdin)
+			if err != nil {
+				return nil, &cliError{Kind: errIO, Message: fmt.Sprintf("reading codes from stdin: %v", err)}
+			}
+			tokens = append(tokens, list...)
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			var pathErr *fs.PathError
+			if errors.As(err, &pathErr) {
+				err = pathErr.Err
+			}
+			return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("cannot read codes file: '%s' - %v", path, err), Input: path}
+		}
+		list, err := parseCodesList(f)
+		f.Close()
+		if err != nil {
+			return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("cannot read codes file: '%s' - %v", path, err), Input: path}
+		}
+		tokens = append(tokens, list...)
+	}
+	return tokens, nil
+}
+
+// parseCodesList splits a list of codes on whitespace and commas,
+// ignoring everything after a # on each line
+func parseCodesList(r io.Reader) ([]string, error) {
+	var tokens []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		tokens = append(tokens, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})...)
+	}
+	return tokens, scanner.Err()
+}
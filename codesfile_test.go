@@ -0,0 +1,70 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test lists split on whitespace, newlines and commas, with # comments
+func TestParseCodesList(t *testing.T) {
+	input := "# codes we alert on\n200 404,\r\n\t418, 5 # server errors\n\n,,\n"
+	got, err := parseCodesList(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"200", "404", "418", "5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// Test files are read in order, stdin once, and a missing file names its path
+func TestReadCodesFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	os.WriteFile(a, []byte("404\n"), 0644)
+	os.WriteFile(b, []byte("# nothing\n"), 0644)
+
+	got, err := readCodesFiles([]string{a, "-", b}, strings.NewReader("200,301\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"404", "200", "301"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if _, err := readCodesFiles([]string{"-", "-"}, strings.NewReader("")); err == nil {
+		t.Error("Expected stdin to be read only once")
+	}
+
+	missing := filepath.Join(dir, "missing.txt")
+	_, err = readCodesFiles([]string{missing}, nil)
+	e, ok := err.(*cliError)
+	if !ok || e.Kind != errInvalidInput || !strings.Contains(e.Message, missing) {
+		t.Errorf("Expected an input error naming the path, got %v", err)
+	}
+}
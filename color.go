@@ -0,0 +1,277 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// colorModes lists the --color values; auto colours only a terminal
+var colorModes = []string{"auto", "always", "never"}
+
+// ANSI SGR sequences used for highlighting, after jq's palette
+const (
+	ansiReset   = "\x1b[0m"
+	ansiKey     = "\x1b[34;1m"
+	ansiString  = "\x1b[32m"
+	ansiNumber  = "\x1b[36m"
+	ansiLiteral = "\x1b[35m"
+	ansiMuted   = "\x1b[90m"
+)
+
+// classColors maps each status class, the first digit of a code, to the
+// colour of its rows in text and table output; 1xx is left plain
+var classColors = map[int]string{
+	2: "\x1b[32m",
+	3: "\x1b[36m",
+	4: "\x1b[33m",
+	5: "\x1b[31m",
+}
+
+// classColor returns the SGR sequence for code's class, or "" for none
+func classColor(code int) string {
+	return classColors[code/100]
+}
+
+// paintClass colours s by the class of code, leaving it alone for classes
+// without a colour
+func paintClass(code int, s string) string {
+	if sgr := classColor(code); sgr != "" {
+		return paint(sgr, s)
+	}
+	return s
+}
+
+// validColorMode reports whether mode is a --color value
+func validColorMode(mode string) bool {
+	for _, m := range colorModes {
+		if mode == m {
+			return true
+		}
+	}
+	return false
+}
+
+// useColor decides whether output to f is coloured. always and never are
+// explicit; auto colours a terminal unless NO_COLOR is set or TERM is dumb
+func useColor(mode string, f *os.File) bool {
+	return colorSetting(mode, f).Value == "always"
+}
+
+// colorSetting is the colour decision for output to f, as a setting whose
+// value is always or never and whose source says what decided it. An
+// empty mode is the auto default
+func colorSetting(mode string, f *os.File) setting {
+	return decideColor(mode, colorEnv{
+		noColor:  os.Getenv("NO_COLOR") != "",
+		term:     os.Getenv("TERM"),
+		terminal: term.IsTerminal(int(f.Fd())),
+		mintty:   isMintty(f),
+		enableVT: func() bool { return enableVirtualTerminal(f) },
+	})
+}
+
+// colorEnv is what the --color decision depends on besides the mode
+type colorEnv struct {
+	noColor  bool        // NO_COLOR is set
+	term     string      // the TERM variable
+	terminal bool        // the output is a terminal or console
+	mintty   bool        // the output is a mintty or MSYS pty, e.g. Git Bash
+	enableVT func() bool // turns on escape processing, reporting success
+}
+
+// decideColor decides whether to colour output. always and never are
+// explicit, though always still asks for escape processing; auto colours
+// a terminal unless NO_COLOR is set or TERM is dumb. A mintty pty looks
+// like a pipe to Windows but understands escapes itself, while a console
+// is only coloured when escape processing can be enabled
+func decideColor(mode string, env colorEnv) setting {
+	decided := func(on bool, source string) setting {
+		s := setting{Name: "color", Value: "never", Source: source}
+		if on {
+			s.Value = "always"
+		}
+		return s
+	}
+
+	switch mode {
+	case "always":
+		if !env.mintty {
+			env.enableVT()
+		}
+		return decided(true, flagSource("color"))
+	case "never":
+		return decided(false, flagSource("color"))
+	}
+	switch {
+	case env.noColor:
+		return decided(false, envSource("NO_COLOR"))
+	case env.term == "dumb":
+		return decided(false, envSource("TERM"))
+	case env.mintty:
+		return decided(true, "default (auto, mintty terminal)")
+	case !env.terminal:
+		return decided(false, "default (auto, not a terminal)")
+	case !env.enableVT():
+		return decided(false, "default (auto, console without escape processing)")
+	}
+	return decided(true, "default (auto, terminal)")
+}
+
+// isMinttyPipeName reports whether a named pipe is the pty of mintty or
+// another MSYS or Cygwin terminal, e.g. \msys-1888ae32e00d56aa-pty0-to-master
+func isMinttyPipeName(name string) bool {
+	name = strings.TrimPrefix(name, `\Device\NamedPipe`)
+	parts := strings.Split(strings.TrimPrefix(name, `\`), "-")
+	if len(parts) < 5 {
+		return false
+	}
+	return (parts[0] == "msys" || parts[0] == "cygwin") && parts[1] != "" &&
+		strings.HasPrefix(parts[2], "pty") &&
+		(parts[3] == "from" || parts[3] == "to") && parts[4] == "master"
+}
+
+// highlighted runs print and, when color is set, colours what it wrote
+// with highlight; otherwise the output is passed through untouched
+func highlighted(w io.Writer, color bool, highlight func(string) string, print func(io.Writer)) {
+	if !color {
+		print(w)
+		return
+	}
+	var buf bytes.Buffer
+	print(&buf)
+	io.WriteString(w, highlight(buf.String()))
+}
+
+// paint wraps s in an SGR sequence
+func paint(sgr, s string) string {
+	return sgr + s + ansiReset
+}
+
+// highlightJSON colours the keys, strings, numbers and literals of
+// marshaled JSON, leaving punctuation and whitespace as they are
+func highlightJSON(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end < len(s) {
+				end++
+			}
+			token := s[i:end]
+			rest := strings.TrimLeft(s[end:], " \t\r\n")
+			if strings.HasPrefix(rest, ":") {
+				b.WriteString(paint(ansiKey, token))
+			} else {
+				b.WriteString(paint(ansiString, token))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(s) && strings.IndexByte("0123456789.eE+-", s[end]) >= 0 {
+				end++
+			}
+			b.WriteString(paint(ansiNumber, s[i:end]))
+			i = end
+		case strings.HasPrefix(s[i:], "true"), strings.HasPrefix(s[i:], "null"):
+			b.WriteString(paint(ansiLiteral, s[i:i+4]))
+			i += 4
+		case strings.HasPrefix(s[i:], "false"):
+			b.WriteString(paint(ansiLiteral, s[i:i+5]))
+			i += 5
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// highlightYAML colours the keys and scalar values of marshaled YAML line
+// by line; document separators are muted and other lines are left alone
+func highlightYAML(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		body := strings.TrimRight(line, "\n")
+		newline := line[len(body):]
+		trimmed := strings.TrimLeft(body, " ")
+		indent := body[:len(body)-len(trimmed)]
+		if strings.HasPrefix(trimmed, "- ") {
+			indent += "- "
+			trimmed = trimmed[2:]
+		}
+
+		switch {
+		case trimmed == "---":
+			lines[i] = indent + paint(ansiMuted, trimmed) + newline
+		case strings.HasPrefix(trimmed, "#") || trimmed == "":
+			// comments and blank lines stay as they are
+		default:
+			key, value, found := strings.Cut(trimmed, ":")
+			if found && (value == "" || value[0] == ' ') && !strings.ContainsAny(key[:1], `"'`) {
+				lines[i] = indent + paint(ansiKey, key) + ":" + highlightYAMLScalar(value) + newline
+			} else {
+				lines[i] = indent + highlightYAMLScalar(trimmed) + newline
+			}
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// highlightYAMLScalar colours a value after a key, keeping its leading space
+func highlightYAMLScalar(value string) string {
+	v := strings.TrimLeft(value, " ")
+	lead := value[:len(value)-len(v)]
+	if v == "" {
+		return value
+	}
+	switch {
+	case v == "true" || v == "false" || v == "null" || v == "~":
+		return lead + paint(ansiLiteral, v)
+	case isYAMLNumber(v):
+		return lead + paint(ansiNumber, v)
+	case v == "|" || v == ">" || strings.HasPrefix(v, "|-") || strings.HasPrefix(v, ">-"):
+		return value
+	}
+	return lead + paint(ansiString, v)
+}
+
+// isYAMLNumber reports whether a plain scalar is an integer or float
+func isYAMLNumber(v string) bool {
+	var f float64
+	_, err := fmt.Sscanf(v, "%g", &f)
+	return err == nil && strings.Trim(v, "0123456789.-+eE") == ""
+}
@@ -0,0 +1,38 @@
+//go:build !windows
+
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import "os"
+
+// enableVirtualTerminal reports whether colour can be used; terminals
+// outside Windows understand ANSI escapes already
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+// isMintty reports whether f is a mintty pty; outside Windows a pty is
+// already a terminal
+func isMintty(f *os.File) bool {
+	return false
+}
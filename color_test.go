@@ -0,0 +1,218 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// ansiPattern matches an SGR escape sequence
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Test auto mode never colours a file or pipe, and the mode flags win
+func TestUseColor(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	t.Setenv("NO_COLOR", "")
+	if useColor("auto", f) {
+		t.Error("Expected auto to leave a file uncoloured")
+	}
+	if useColor("never", f) {
+		t.Error("Expected never to disable colour")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !useColor("always", f) {
+		t.Error("Expected always to force colour even with NO_COLOR")
+	}
+	if validColorMode("sometimes") {
+		t.Error("Expected an unknown color mode to be invalid")
+	}
+}
+
+// Test every input to the colour decision
+func TestDecideColor(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		env  colorEnv
+		vtOK bool
+		want string
+		from string
+	}{
+		{"auto terminal", "auto", colorEnv{terminal: true}, true, "always", "default (auto, terminal)"},
+		{"default terminal", "", colorEnv{terminal: true}, true, "always", "default (auto, terminal)"},
+		{"auto pipe", "auto", colorEnv{}, true, "never", "default (auto, not a terminal)"},
+		{"auto NO_COLOR", "auto", colorEnv{terminal: true, noColor: true}, true, "never", "env NO_COLOR"},
+		{"auto dumb TERM", "auto", colorEnv{terminal: true, term: "dumb"}, true, "never", "env TERM"},
+		{"auto console without VT", "auto", colorEnv{terminal: true}, false, "never", "default (auto, console without escape processing)"},
+		{"auto mintty", "auto", colorEnv{mintty: true}, false, "always", "default (auto, mintty terminal)"},
+		{"auto mintty NO_COLOR", "auto", colorEnv{mintty: true, noColor: true}, false, "never", "env NO_COLOR"},
+		{"always pipe", "always", colorEnv{noColor: true}, false, "always", "command line (--color)"},
+		{"never terminal", "never", colorEnv{terminal: true, mintty: true}, true, "never", "command line (--color)"},
+	}
+	for _, tt := range tests {
+		vtCalls := 0
+		tt.env.enableVT = func() bool {
+			vtCalls++
+			return tt.vtOK
+		}
+		if got := decideColor(tt.mode, tt.env); got.Value != tt.want || got.Source != tt.from {
+			t.Errorf("%s: expected %s from %s, got %+v", tt.name, tt.want, tt.from, got)
+		}
+		if tt.env.mintty && vtCalls > 0 {
+			t.Errorf("%s: escape processing requested for a mintty pty", tt.name)
+		}
+	}
+}
+
+// Test mintty, MSYS and Cygwin pty pipe names are recognised
+func TestIsMinttyPipeName(t *testing.T) {
+	tests := map[string]bool{
+		`\msys-1888ae32e00d56aa-pty0-to-master`:                  true,
+		`\cygwin-e022582115c10879-pty4-from-master`:              true,
+		`\Device\NamedPipe\msys-1888ae32e00d56aa-pty1-to-master`: true,
+		`\msys-1888ae32e00d56aa-pty0-to-slave`:                   false,
+		`\msys--pty0-to-master`:                                  false,
+		`\msys-1888ae32e00d56aa-cmd0-to-master`:                  false,
+		`\mojo.1234.5678`:                                        false,
+		``:                                                       false,
+	}
+	for name, want := range tests {
+		if got := isMinttyPipeName(name); got != want {
+			t.Errorf("isMinttyPipeName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// Test uncoloured output is byte-identical to the plain encoders
+func TestHighlightedPlain(t *testing.T) {
+	var codes []StatusCode
+	for _, code := range []int{404, 418} {
+		sc, _ := findStatusCode(code)
+		codes = append(codes, sc)
+	}
+	outputs := prepareOutputs(codes, false, true)
+	for _, tt := range []struct {
+		name      string
+		highlight func(string) string
+		print     func(io.Writer)
+	}{
+		{"json", highlightJSON, func(w io.Writer) { printJSON(w, outputs, true) }},
+		{"yaml", highlightYAML, func(w io.Writer) { printYAML(w, outputs, true) }},
+	} {
+		var plain, got bytes.Buffer
+		tt.print(&plain)
+		highlighted(&got, false, tt.highlight, tt.print)
+		if got.String() != plain.String() || ansiPattern.MatchString(got.String()) {
+			t.Errorf("%s: expected plain output without escapes, got:\n%q", tt.name, got.String())
+		}
+
+		got.Reset()
+		highlighted(&got, true, tt.highlight, tt.print)
+		if ansiPattern.ReplaceAllString(got.String(), "") != plain.String() {
+			t.Errorf("%s: expected highlighting to only add escapes, got:\n%q", tt.name, got.String())
+		}
+	}
+}
+
+// Test JSON keys, strings, numbers and literals get their own colours
+func TestHighlightJSON(t *testing.T) {
+	got := highlightJSON(`{"code": 404, "short": "Say \"hi\"", "long": null, "ok": true, "n": -1.5e3}`)
+	for _, span := range []string{
+		ansiKey + `"code"` + ansiReset,
+		ansiNumber + "404" + ansiReset,
+		ansiString + `"Say \"hi\""` + ansiReset,
+		ansiLiteral + "null" + ansiReset,
+		ansiLiteral + "true" + ansiReset,
+		ansiNumber + "-1.5e3" + ansiReset,
+	} {
+		if !strings.Contains(got, span) {
+			t.Errorf("Expected %q in %q", span, got)
+		}
+	}
+}
+
+// Test YAML keys and values are coloured and separators are muted
+func TestHighlightYAML(t *testing.T) {
+	got := highlightYAML("code: 404\nshort: 'Not: Found'\n\n---\n- code: 418\n  long: null\n")
+	for _, span := range []string{
+		ansiKey + "code" + ansiReset + ": " + ansiNumber + "404" + ansiReset + "\n",
+		ansiKey + "short" + ansiReset + ": " + ansiString + "'Not: Found'" + ansiReset,
+		ansiMuted + "---" + ansiReset,
+		"- " + ansiKey + "code" + ansiReset,
+		"  " + ansiKey + "long" + ansiReset + ": " + ansiLiteral + "null" + ansiReset,
+	} {
+		if !strings.Contains(got, span) {
+			t.Errorf("Expected %q in %q", span, got)
+		}
+	}
+}
+
+// Test table rows are coloured by class and strip back to the plain table
+func TestTableClassColors(t *testing.T) {
+	codes := goldenCodes(t)
+	for _, style := range []string{"compact", "plain", "ascii", "unicode"} {
+		var plain, colored bytes.Buffer
+		printTableOptions(&plain, codes, baseFields, tableOptions{style: style})
+		printTableOptions(&colored, codes, baseFields, tableOptions{style: style, width: 50, wrap: true, color: true})
+		if !strings.Contains(colored.String(), classColors[4]) || strings.Contains(plain.String(), "\x1b") {
+			t.Errorf("%s: expected only the coloured table to have colour:\n%s", style, colored.String())
+		}
+		for _, line := range strings.Split(colored.String(), "\n") {
+			if strings.Contains(line, "503") && !strings.Contains(line, classColors[5]) {
+				t.Errorf("%s: 503 row not coloured red: %q", style, line)
+			}
+			if strings.Contains(line, "CODE") && strings.Contains(line, "\x1b") {
+				t.Errorf("%s: header row coloured: %q", style, line)
+			}
+		}
+	}
+
+	var github bytes.Buffer
+	printTableOptions(&github, codes, baseFields, tableOptions{style: "github", color: true})
+	if strings.Contains(github.String(), "\x1b") {
+		t.Errorf("github table coloured:\n%s", github.String())
+	}
+}
+
+// Test text output is coloured per code and plain without colour
+func TestTextClassColors(t *testing.T) {
+	codes := goldenCodes(t)
+	var plain, colored bytes.Buffer
+	printText(&plain, codes)
+	printTextOptions(&colored, codes, textOptions{color: true})
+	if got := ansiPattern.ReplaceAllString(colored.String(), ""); got != plain.String() {
+		t.Errorf("Coloured text differs from plain text once stripped:\n%s", got)
+	}
+	if !strings.Contains(colored.String(), paint(classColors[4], "Code: 404")) {
+		t.Errorf("Expected 404 in yellow:\n%q", colored.String())
+	}
+}
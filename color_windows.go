@@ -0,0 +1,65 @@
+//go:build windows
+
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on ANSI escape processing for a Windows
+// console, reporting whether colour can be used
+func enableVirtualTerminal(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+// isMintty reports whether f is the pty of mintty or another MSYS or
+// Cygwin terminal, which Windows sees as a named pipe rather than a console
+func isMintty(f *os.File) bool {
+	// FILE_NAME_INFO: the name length in bytes, then the UTF-16 name
+	var buf [4 + 2*windows.MAX_PATH]byte
+	if err := windows.GetFileInformationByHandleEx(windows.Handle(f.Fd()), windows.FileNameInfo, &buf[0], uint32(len(buf))); err != nil {
+		return false
+	}
+	n := int(binary.LittleEndian.Uint32(buf[:4]))
+	if n > len(buf)-4 {
+		n = len(buf) - 4
+	}
+	name := make([]uint16, n/2)
+	for i := range name {
+		name[i] = binary.LittleEndian.Uint16(buf[4+2*i:])
+	}
+	return isMinttyPipeName(string(utf16.Decode(name)))
+}
@@ -0,0 +1,53 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// countOutput is the JSON form of --count
+type countOutput struct {
+	Count int `json:"count"`
+}
+
+// countable treats a lookup that found nothing as zero matches, so
+// --count reports 0 rather than failing; other errors are kept
+func countable(results []StatusCode, err error) ([]StatusCode, error) {
+	var e *cliError
+	if errors.As(err, &e) && e.Kind == errNotFound {
+		return nil, nil
+	}
+	return results, err
+}
+
+// printCount prints the number of matches, as {"count": n} for --json
+func printCount(w io.Writer, n int, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(countOutput{Count: n})
+	}
+	_, err := fmt.Fprintln(w, n)
+	return err
+}
@@ -0,0 +1,76 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Test counting the matches of prefixes and searches
+func TestCountMatches(t *testing.T) {
+	tests := []struct {
+		q    lookupQuery
+		want int
+	}{
+		{lookupQuery{codes: "4"}, 34},
+		{lookupQuery{codes: "20,30"}, 18},
+		{lookupQuery{search: "error"}, 4},
+		{lookupQuery{codes: "5", search: "teapot"}, 12},
+		{lookupQuery{classes: []string{"5"}, search: "gateway"}, 2},
+		{lookupQuery{search: "no such words"}, 0},
+		{lookupQuery{codes: "999"}, 0},
+	}
+	for _, tt := range tests {
+		results, err := countable(lookup(tt.q))
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.q, err)
+		}
+		if len(results) != tt.want {
+			t.Errorf("%+v: expected %d, got %d", tt.q, tt.want, len(results))
+		}
+	}
+
+	// Input errors are still errors
+	if _, err := countable(lookup(lookupQuery{codes: "500-400"})); err == nil {
+		t.Error("Expected an error for a reversed range")
+	}
+}
+
+// Test the plain and JSON count output
+func TestPrintCount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printCount(&buf, 12, false); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "12\n" {
+		t.Errorf("Expected 12, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := printCount(&buf, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "{\"count\":0}\n" {
+		t.Errorf("Expected {\"count\":0}, got %q", buf.String())
+	}
+}
@@ -0,0 +1,126 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// csvQuoteModes are the values accepted by --quote
+var csvQuoteModes = []string{"minimal", "all", "nonnumeric"}
+
+// csvNumeric matches fields left unquoted by the nonnumeric mode
+var csvNumeric = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// csvOptions controls how CSV output is written
+type csvOptions struct {
+	quote     string // minimal, all or nonnumeric
+	excelHint bool   // emit a leading sep= line for Excel
+	delimiter rune   // field separator, a comma when zero
+	bom       bool   // start with a UTF-8 byte order mark for Excel
+
+	// fields are the columns to write, the base four when nil
+	fields []metadataField
+}
+
+// validCSVQuote reports whether mode is a supported quoting mode
+func validCSVQuote(mode string) bool {
+	for _, m := range csvQuoteModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDelimiter reads a --delimiter value: a single character, or tab
+// or \t for tab-separated output
+func parseDelimiter(s string) (rune, error) {
+	if s == "tab" || s == `\t` {
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid delimiter: '%s' - must be a single character other than a quote or newline, e.g. ;", s), Input: s}
+	}
+	return r, nil
+}
+
+// csvRecordWriter writes CSV records; minimal quoting uses encoding/csv and
+// the other modes quote fields themselves
+type csvRecordWriter struct {
+	w         io.Writer
+	cw        *csv.Writer
+	quote     string
+	delimiter string
+}
+
+// newCSVRecordWriter creates a record writer, writing the byte order mark
+// and the Excel hint first
+func newCSVRecordWriter(w io.Writer, opts csvOptions) *csvRecordWriter {
+	delimiter := opts.delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	if opts.bom {
+		fmt.Fprint(w, "\uFEFF")
+	}
+	if opts.excelHint {
+		fmt.Fprintf(w, "sep=%c\n", delimiter)
+	}
+	if opts.quote == "" || opts.quote == "minimal" {
+		cw := csv.NewWriter(w)
+		cw.Comma = delimiter
+		return &csvRecordWriter{cw: cw}
+	}
+	return &csvRecordWriter{w: w, quote: opts.quote, delimiter: string(delimiter)}
+}
+
+// Write writes one record
+func (c *csvRecordWriter) Write(record []string) error {
+	if c.cw != nil {
+		return c.cw.Write(record)
+	}
+
+	fields := make([]string, len(record))
+	for i, field := range record {
+		if c.quote == "nonnumeric" && csvNumeric.MatchString(field) {
+			fields[i] = field
+			continue
+		}
+		fields[i] = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+	}
+	_, err := io.WriteString(c.w, strings.Join(fields, c.delimiter)+"\n")
+	return err
+}
+
+// Flush flushes buffered records
+func (c *csvRecordWriter) Flush() {
+	if c.cw != nil {
+		c.cw.Flush()
+	}
+}
@@ -0,0 +1,121 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+// Test each quoting mode against its golden file
+func TestCSVQuoteGolden(t *testing.T) {
+	results, err := processInputs("200,306,418", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := prepareOutputs(results, false, true)
+
+	testCases := map[string]csvOptions{
+		"minimal":    {quote: "minimal"},
+		"all":        {quote: "all"},
+		"nonnumeric": {quote: "nonnumeric"},
+		"excel-hint": {quote: "minimal", excelHint: true},
+	}
+	for name, opts := range testCases {
+		var buf bytes.Buffer
+		printCSVWith(&buf, codes, opts)
+		checkGolden(t, filepath.Join("testdata", "csv", name+".golden"), buf.Bytes())
+	}
+}
+
+// Test the default mode is byte-identical to encoding/csv
+func TestCSVMinimalMatchesDefault(t *testing.T) {
+	codes := prepareOutputs(statusCodes, false, true)
+	var plain, minimal bytes.Buffer
+	printCSV(&plain, codes)
+	printCSVWith(&minimal, codes, csvOptions{quote: "minimal"})
+	if plain.String() != minimal.String() {
+		t.Error("Minimal quoting differs from the default CSV output")
+	}
+}
+
+// Test quotes and empty fields are escaped in the custom modes
+func TestCSVRecordWriterEscaping(t *testing.T) {
+	testCases := []struct {
+		quote    string
+		record   []string
+		expected string
+	}{
+		{"all", []string{"404", `say "hi"`, ""}, `"404","say ""hi""",""` + "\n"},
+		{"nonnumeric", []string{"404", "-1.5", "1e3", `a,b`, ""}, `404,-1.5,"1e3","a,b",""` + "\n"},
+		{"minimal", []string{"404", `say "hi"`, "a,b"}, `404,"say ""hi""","a,b"` + "\n"},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		cw := newCSVRecordWriter(&buf, csvOptions{quote: tc.quote})
+		cw.Write(tc.record)
+		cw.Flush()
+		if buf.String() != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.quote, tc.expected, buf.String())
+		}
+	}
+}
+
+// Test other delimiters quote fields containing them
+func TestCSVDelimiter(t *testing.T) {
+	long := "Try again; later | maybe"
+	codes := []StatusCode{{Code: 503, Type: "Server Error", Short: strPtr("Service Unavailable"), Long: &long}}
+
+	tests := []struct {
+		delimiter rune
+		opts      csvOptions
+		want      string
+	}{
+		{';', csvOptions{excelHint: true}, "sep=;\nCode;Type;Short;Long\n503;Server Error;Service Unavailable;\"Try again; later | maybe\"\n"},
+		{'|', csvOptions{}, "Code|Type|Short|Long\n503|Server Error|Service Unavailable|\"Try again; later | maybe\"\n"},
+		{'|', csvOptions{quote: "nonnumeric"}, "\"Code\"|\"Type\"|\"Short\"|\"Long\"\n503|\"Server Error\"|\"Service Unavailable\"|\"Try again; later | maybe\"\n"},
+	}
+	for _, tt := range tests {
+		tt.opts.delimiter = tt.delimiter
+		var buf bytes.Buffer
+		printCSVWith(&buf, codes, tt.opts)
+		if buf.String() != tt.want {
+			t.Errorf("%q %+v: expected\n%s\ngot\n%s", tt.delimiter, tt.opts, tt.want, buf.String())
+		}
+	}
+}
+
+// Test --delimiter values are validated
+func TestParseDelimiter(t *testing.T) {
+	valid := map[string]rune{";": ';', "|": '|', "tab": '\t', `\t`: '\t', "§": '§'}
+	for in, want := range valid {
+		if got, err := parseDelimiter(in); err != nil || got != want {
+			t.Errorf("parseDelimiter(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", `"`, "\n", "\r", ";;"} {
+		if _, err := parseDelimiter(in); err == nil {
+			t.Errorf("parseDelimiter(%q): expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// curlStatusLine matches a response status line such as "HTTP/1.1 404 Not Found" or "HTTP/2 200"
+var curlStatusLine = regexp.MustCompile(`^HTTP/[0-9.]+ ([0-9]{3})\b`)
+
+// curlResponse is one response parsed from curl output
+type curlResponse struct {
+	statusLine string
+	code       int
+	header     http.Header
+}
+
+// parseCurlOutput extracts the responses from "curl -i" or "curl -v" output;
+// redirects followed with -L yield one response per hop
+func parseCurlOutput(r io.Reader) ([]curlResponse, error) {
+	var responses []curlResponse
+	inHeaders := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		// curl -v marks response lines with "< " and everything else with "> ", "* ", "{ " or "} "
+		switch {
+		case line == "<" || strings.HasPrefix(line, "< "):
+			line = strings.TrimPrefix(strings.TrimPrefix(line, "<"), " ")
+		case strings.HasPrefix(line, "> "), strings.HasPrefix(line, "* "),
+			strings.HasPrefix(line, "{ "), strings.HasPrefix(line, "} "):
+			continue
+		}
+
+		if m := curlStatusLine.FindStringSubmatch(line); m != nil {
+			code, _ := strconv.Atoi(m[1])
+			responses = append(responses, curlResponse{statusLine: line, code: code, header: http.Header{}})
+			inHeaders = true
+			continue
+		}
+		if !inHeaders {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			inHeaders = false
+			continue
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			responses[len(responses)-1].header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no HTTP status line found in input - pipe in the output of curl -i or curl -v")
+	}
+	return responses, nil
+}
+
+// printCurlAnnotations describes each response and its relevant headers
+func printCurlAnnotations(w io.Writer, responses []curlResponse) {
+	for i, resp := range responses {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if len(responses) > 1 {
+			fmt.Fprintf(w, "Response %d of %d: %s\n", i+1, len(responses), resp.statusLine)
+		} else {
+			fmt.Fprintln(w, resp.statusLine)
+		}
+
+		sc, found := findStatusCode(resp.code)
+		if !found {
+			fmt.Fprintf(w, "  %d is not a known HTTP status code\n", resp.code)
+			continue
+		}
+		fmt.Fprintf(w, "  %d %s\n", sc.Code, describe(sc))
+		if sc.Long != nil {
+			fmt.Fprintf(w, "  %s\n", *sc.Long)
+		}
+		for _, name := range responseHeaders(sc.Code) {
+			for _, value := range resp.header.Values(name) {
+				fmt.Fprintf(w, "  %s: %s\n", name, value)
+			}
+		}
+	}
+}
+
+// annotateCurl reads curl output and prints a description of each response
+func annotateCurl(r io.Reader, w io.Writer) error {
+	responses, err := parseCurlOutput(r)
+	if err != nil {
+		return err
+	}
+	printCurlAnnotations(w, responses)
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// parseCurlFixture parses a captured curl output from testdata/curl
+func parseCurlFixture(t *testing.T, name string) []curlResponse {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "curl", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	responses, err := parseCurlOutput(f)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return responses
+}
+
+// Test curl -i output with CRLF line endings
+func TestParseCurlInclude(t *testing.T) {
+	responses := parseCurlFixture(t, "include-crlf.txt")
+	if len(responses) != 1 || responses[0].code != 429 {
+		t.Fatalf("Expected one 429 response, got %+v", responses)
+	}
+	if responses[0].statusLine != "HTTP/1.1 429 Too Many Requests" {
+		t.Errorf("Expected CR stripped from status line, got %q", responses[0].statusLine)
+	}
+	if got := responses[0].header.Get("Retry-After"); got != "120" {
+		t.Errorf("Expected Retry-After 120, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	printCurlAnnotations(&buf, responses)
+	for _, want := range []string{"429 Too Many Requests (Client Error)", "  Retry-After: 120", "  X-RateLimit-Remaining: 0"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in output:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "Content-Type") {
+		t.Errorf("Unrelated headers should not be called out:\n%s", buf.String())
+	}
+}
+
+// Test curl -v output, where response lines are prefixed with "< "
+func TestParseCurlVerbose(t *testing.T) {
+	responses := parseCurlFixture(t, "verbose.txt")
+	if len(responses) != 1 || responses[0].code != 401 {
+		t.Fatalf("Expected one 401 response, got %+v", responses)
+	}
+	if len(responses[0].header) != 3 {
+		t.Errorf("Expected 3 response headers and no request headers, got %v", responses[0].header)
+	}
+
+	var buf bytes.Buffer
+	printCurlAnnotations(&buf, responses)
+	if !strings.Contains(buf.String(), `  WWW-Authenticate: Basic realm="admin"`) {
+		t.Errorf("Expected WWW-Authenticate to be called out:\n%s", buf.String())
+	}
+}
+
+// Test each hop of a followed redirect is reported, and bodies are ignored
+func TestParseCurlRedirects(t *testing.T) {
+	responses := parseCurlFixture(t, "redirects.txt")
+	codes := []int{}
+	for _, r := range responses {
+		codes = append(codes, r.code)
+	}
+	if len(codes) != 3 || codes[0] != 301 || codes[1] != 302 || codes[2] != 200 {
+		t.Fatalf("Expected 301, 302, 200, got %v", codes)
+	}
+
+	var buf bytes.Buffer
+	printCurlAnnotations(&buf, responses)
+	for _, want := range []string{
+		"Response 1 of 3: HTTP/1.1 301 Moved Permanently",
+		"  Location: https://example.com/",
+		"Response 2 of 3: HTTP/2 302",
+		"  Location: https://www.example.com/home",
+		"Response 3 of 3: HTTP/2 200",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in output:\n%s", want, buf.String())
+		}
+	}
+}
+
+// Test input without a status line is an error
+func TestParseCurlNoStatus(t *testing.T) {
+	if _, err := parseCurlOutput(strings.NewReader("<html>not curl</html>\n")); err == nil {
+		t.Error("Expected error for input without a status line")
+	}
+
+	var buf bytes.Buffer
+	if err := annotateCurl(strings.NewReader("HTTP/1.1 299 Odd\n\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "299 is not a known HTTP status code") {
+		t.Errorf("Expected unknown code note, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,322 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// customColumns are the CSV columns custom import understands; code and
+// type are required
+var customColumns = []string{"code", "type", "short", "long"}
+
+// configDir returns the httpstatus directory in the user's config directory
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppName), nil
+}
+
+// customDataPath returns the user's custom data file in the config directory
+func customDataPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "custom.yaml"), nil
+}
+
+// knownType returns the built-in spelling of a status type, ignoring case
+func knownType(t string) (string, bool) {
+	for _, sc := range builtinCodes {
+		if strings.EqualFold(sc.Type, t) {
+			return sc.Type, true
+		}
+	}
+	return t, false
+}
+
+// importRow is a CSV row that passed validation
+type importRow struct {
+	line int
+	code StatusCode
+}
+
+// importFailure is a CSV row that failed validation
+type importFailure struct {
+	line   int
+	reason string
+}
+
+// readImportCSV reads and validates the rows of a custom import CSV; a bad
+// header is an error, bad rows are returned as failures
+func readImportCSV(r io.Reader) ([]importRow, []importFailure, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("the CSV is empty - a code,type,short,long header is required")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range customColumns {
+			known = known || name == c
+		}
+		if !known {
+			return nil, nil, fmt.Errorf("unknown column: '%s' - must be one of %s", header[i], strings.Join(customColumns, ", "))
+		}
+		if _, dup := columns[name]; dup {
+			return nil, nil, fmt.Errorf("column '%s' appears twice in the header", name)
+		}
+		columns[name] = i
+	}
+	for _, required := range customColumns[:2] {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing column: '%s' - the header needs at least code and type", required)
+		}
+	}
+
+	var rows []importRow
+	var failures []importFailure
+	firstLine := make(map[int]int)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, failures, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			failures = append(failures, importFailure{parseErr.Line, parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		if len(record) != len(header) {
+			failures = append(failures, importFailure{line, fmt.Sprintf("expected %d fields, got %d", len(header), len(record))})
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		code, err := strconv.Atoi(field("code"))
+		switch {
+		case err != nil:
+			failures = append(failures, importFailure{line, fmt.Sprintf("invalid status code: '%s' - must be numeric", field("code"))})
+			continue
+		case code < 100 || code > 599:
+			failures = append(failures, importFailure{line, fmt.Sprintf("invalid status code: %d - must be 100-599", code)})
+			continue
+		case field("type") == "":
+			failures = append(failures, importFailure{line, fmt.Sprintf("code %d has no type", code)})
+			continue
+		case firstLine[code] != 0:
+			failures = append(failures, importFailure{line, fmt.Sprintf("code %d is already on line %d", code, firstLine[code])})
+			continue
+		}
+		firstLine[code] = line
+
+		sc := StatusCode{Code: code}
+		sc.Type, _ = knownType(field("type"))
+		if s := field("short"); s != "" {
+			sc.Short = strPtr(s)
+		}
+		if l := field("long"); l != "" {
+			sc.Long = strPtr(l)
+		}
+		rows = append(rows, importRow{line, sc})
+	}
+}
+
+// loadCustomFile reads a custom data file, which may not exist yet
+func loadCustomFile(path string) ([]StatusCode, error) {
+	codes, err := loadDataset(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return codes, err
+}
+
+// writeCustomFile writes custom codes as a YAML list in code order
+func writeCustomFile(path string, codes []StatusCode) error {
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	data, err := yaml.Marshal(codes)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// describe summarises a definition for the overwrite prompt
+func describe(sc StatusCode) string {
+	if sc.Short != nil {
+		return fmt.Sprintf("%s (%s)", *sc.Short, sc.Type)
+	}
+	return sc.Type
+}
+
+// runCustom implements the custom subcommand
+func runCustom(args []string, in io.Reader, w io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("custom requires an action, e.g. httpstatus custom import codes.csv")
+	}
+	switch args[0] {
+	case "import":
+		return runCustomImport(args[1:], in, w)
+	case "validate":
+		return runCustomValidate(args[1:], w)
+	default:
+		return fmt.Errorf("invalid custom action: '%s' - must be import or validate", args[0])
+	}
+}
+
+// runCustomImport imports codes from a CSV into the custom data file,
+// asking before replacing an existing custom definition
+func runCustomImport(args []string, in io.Reader, w io.Writer) error {
+	fs := newFlagSet("custom import")
+	overwrite := fs.Bool("overwrite", false, "Replace existing custom codes without asking")
+	skipExisting := fs.Bool("skip-existing", false, "Keep existing custom codes without asking")
+	file := fs.String("file", "", "Custom data file to write (default custom.yaml in the config directory)")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("custom import requires one CSV file, e.g. httpstatus custom import codes.csv")
+	}
+	if *overwrite && *skipExisting {
+		return fmt.Errorf("--overwrite and --skip-existing cannot be used together")
+	}
+	path := *file
+	if path == "" {
+		if path, err = customDataPath(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rows, failures, err := readImportCSV(f)
+	if err != nil {
+		return fmt.Errorf("invalid CSV %s: %v", positional[0], err)
+	}
+
+	existing, err := loadCustomFile(path)
+	if err != nil {
+		return err
+	}
+	index := make(map[int]int)
+	for i, sc := range existing {
+		index[sc.Code] = i
+	}
+
+	answers := bufio.NewScanner(in)
+	var imported int
+	var skipped []importRow
+	for _, row := range rows {
+		i, conflict := index[row.code.Code]
+		if !conflict {
+			index[row.code.Code] = len(existing)
+			existing = append(existing, row.code)
+			imported++
+			continue
+		}
+
+		replace := *overwrite
+		if !*overwrite && !*skipExisting {
+			fmt.Fprintf(w, "Code %d is already defined as %s. Replace with %s? [y/N] ",
+				row.code.Code, describe(existing[i]), describe(row.code))
+			if !answers.Scan() {
+				fmt.Fprintln(w)
+				return fmt.Errorf("no answer for code %d - use --overwrite or --skip-existing when not running interactively", row.code.Code)
+			}
+			answer := strings.ToLower(strings.TrimSpace(answers.Text()))
+			replace = answer == "y" || answer == "yes"
+		}
+		if replace {
+			existing[i] = row.code
+			imported++
+		} else {
+			skipped = append(skipped, row)
+		}
+	}
+
+	if imported > 0 {
+		if err := writeCustomFile(path, existing); err != nil {
+			return err
+		}
+	}
+	printImportSummary(w, path, imported, skipped, failures)
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d rows failed to import", len(failures), len(rows)+len(failures))
+	}
+	return nil
+}
+
+// printImportSummary reports the imported, skipped and failed rows
+func printImportSummary(w io.Writer, path string, imported int, skipped []importRow, failures []importFailure) {
+	fmt.Fprintf(w, "Imported: %d\n", imported)
+	fmt.Fprintf(w, "Skipped:  %d\n", len(skipped))
+	for _, row := range skipped {
+		fmt.Fprintf(w, "  line %d: code %d is already defined\n", row.line, row.code.Code)
+	}
+	fmt.Fprintf(w, "Failed:   %d\n", len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(w, "  line %d: %s\n", f.line, f.reason)
+	}
+	if imported > 0 {
+		fmt.Fprintf(w, "Wrote %s\n", path)
+	}
+}
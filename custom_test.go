@@ -0,0 +1,179 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runImport runs custom import into a custom file in a temporary directory
+func runImport(t *testing.T, custom, csvContent, answers string, flags ...string) (string, error) {
+	t.Helper()
+	csvPath := writeDataFile(t, "codes.csv", csvContent)
+	args := append([]string{"import", csvPath, "--file", custom}, flags...)
+	var out bytes.Buffer
+	err := runCustom(args, strings.NewReader(answers), &out)
+	return out.String(), err
+}
+
+// Test rows are validated and reported with their line numbers
+func TestCustomImportValidation(t *testing.T) {
+	custom := filepath.Join(t.TempDir(), "httpstatus", "custom.yaml")
+	csvContent := "short,Code,type,long\n" +
+		"Missing,404,client error,\n" +
+		"Custom,209,Success,Our custom\n" +
+		"Bad,abc,Success,\n" +
+		"Far,700,Success,\n" +
+		"NoType,210,,\n" +
+		"Again,209,Success,\n" +
+		"Short,211\n"
+	out, err := runImport(t, custom, csvContent, "")
+	if err == nil || !strings.Contains(err.Error(), "5 of 7 rows failed") {
+		t.Errorf("Expected the failures to be an error, got %v", err)
+	}
+	for _, expected := range []string{
+		"Imported: 2\n",
+		"line 4: invalid status code: 'abc' - must be numeric",
+		"line 5: invalid status code: 700 - must be 100-599",
+		"line 6: code 210 has no type",
+		"line 7: code 209 is already on line 3",
+		"line 8: expected 4 fields, got 2",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", expected, out)
+		}
+	}
+
+	codes, err := loadDataset(custom)
+	if err != nil {
+		t.Fatalf("Unexpected error loading the custom file: %v", err)
+	}
+	if len(codes) != 2 || codes[0].Code != 209 || codes[1].Type != "Client Error" || codes[1].Long != nil {
+		t.Errorf("Unexpected custom codes: %+v", codes)
+	}
+}
+
+// Test a bad header stops the import before anything is written
+func TestCustomImportHeader(t *testing.T) {
+	for _, csvContent := range []string{"", "code,short\n404,x\n", "code,type,reason\n", "code,type,code\n"} {
+		custom := filepath.Join(t.TempDir(), "custom.yaml")
+		if _, err := runImport(t, custom, csvContent, ""); err == nil {
+			t.Errorf("%q: expected an error", csvContent)
+		}
+		if codes, _ := loadCustomFile(custom); codes != nil {
+			t.Errorf("%q: expected nothing to be written", csvContent)
+		}
+	}
+}
+
+// Test conflicts with existing custom codes prompt, or follow the flags
+func TestCustomImportConflicts(t *testing.T) {
+	first := "code,type,short\n404,Client Error,Missing\n209,Success,Custom\n"
+	second := "code,type,short\n404,Client Error,Gone Missing\n209,Success,Renamed\n"
+
+	testCases := []struct {
+		flags    []string
+		answers  string
+		expected [2]string // short descriptions of 209 and 404 afterwards
+	}{
+		{[]string{"--overwrite"}, "", [2]string{"Renamed", "Gone Missing"}},
+		{[]string{"--skip-existing"}, "", [2]string{"Custom", "Missing"}},
+		{nil, "y\nn\n", [2]string{"Custom", "Gone Missing"}},
+	}
+	for _, tc := range testCases {
+		custom := filepath.Join(t.TempDir(), "custom.yaml")
+		if _, err := runImport(t, custom, first, ""); err != nil {
+			t.Fatal(err)
+		}
+		out, err := runImport(t, custom, second, tc.answers, tc.flags...)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.flags, err)
+		}
+		codes, _ := loadCustomFile(custom)
+		if *codes[0].Short != tc.expected[0] || *codes[1].Short != tc.expected[1] {
+			t.Errorf("%v: expected %v, got %s and %s", tc.flags, tc.expected, *codes[0].Short, *codes[1].Short)
+		}
+		if tc.answers != "" && !strings.Contains(out, "Code 404 is already defined as Missing (Client Error). Replace with Gone Missing (Client Error)? [y/N]") {
+			t.Errorf("Expected a prompt, got:\n%s", out)
+		}
+	}
+
+	// Without flags or answers, a conflict is an error
+	custom := filepath.Join(t.TempDir(), "custom.yaml")
+	runImport(t, custom, first, "")
+	if _, err := runImport(t, custom, second, ""); err == nil || !strings.Contains(err.Error(), "--overwrite or --skip-existing") {
+		t.Errorf("Expected an error asking for a flag, got %v", err)
+	}
+	if _, err := runImport(t, custom, second, "", "--overwrite", "--skip-existing"); err == nil {
+		t.Error("Expected --overwrite and --skip-existing to conflict")
+	}
+}
+
+// Test a custom code with only code and type, which validation allows,
+// works everywhere a code is described
+func TestCustomCodeWithoutDescriptions(t *testing.T) {
+	cfg := useTempConfig(t)
+	os.WriteFile(filepath.Join(cfg, "custom.yaml"), []byte("- code: 299\n  type: Success\n"), 0644)
+	if _, err := loadData(dataOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runQuiz([]string{"--seed", "1", "--count", "8", "--class", "2"}, strings.NewReader(strings.Repeat("a\n", 8)), &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "299") {
+		t.Errorf("Expected the quiz to skip a code with no reason phrase:\n%s", out.String())
+	}
+	if err := runQuiz([]string{"--class", "29"}, strings.NewReader(""), &out); err == nil {
+		t.Error("Expected an error when no code in the class can be asked about")
+	}
+
+	out.Reset()
+	if err := annotateCurl(strings.NewReader("HTTP/1.1 299 Fine\n\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "  299 Success\n") {
+		t.Errorf("Unexpected --from-curl output:\n%s", out.String())
+	}
+
+	var stderr bytes.Buffer
+	out.Reset()
+	if err := runEnrich([]string{"--csv-in", "-"}, strings.NewReader("status_code\n299\n"), &out, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "299,Success,,") {
+		t.Errorf("Unexpected enriched CSV:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runEnrich([]string{"--json-in", "-", "--field", "status"}, strings.NewReader(`{"status":299}`), &out, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"status_info":{"type":"Success","short":"","long":""}`) {
+		t.Errorf("Unexpected enriched JSON: %s", out.String())
+	}
+}
@@ -0,0 +1,40 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+// builtinDataset is the name that refers to the embedded status codes
+// wherever a data file is expected
+const builtinDataset = "builtin"
+
+// loadDataset reads status codes from a JSON or YAML data file in the
+// format the --json and --yaml-pretty outputs produce, applying the same
+// validation as every other data file loader
+func loadDataset(source string) ([]StatusCode, error) {
+	if source == builtinDataset {
+		return builtinCodes, nil
+	}
+	layer, err := loadLayer(source)
+	if err != nil {
+		return nil, err
+	}
+	return layer.codes, nil
+}
@@ -0,0 +1,87 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDataFile writes a data file into a temporary directory
+func writeDataFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Test the JSON and YAML layouts all load the same codes, whatever the
+// extension, as they do with --custom
+func TestLoadDataset(t *testing.T) {
+	files := map[string]string{
+		"list.json": `[{"code":404,"type":"Client Error","short":"Not Found"},{"code":599,"type":"Server Error"}]`,
+		"list.yaml": "- code: 404\n  type: Client Error\n  short: Not Found\n- code: 599\n  type: Server Error\n",
+		"docs.yml":  "code: 404\ntype: Client Error\nshort: Not Found\n---\ncode: 599\ntype: Server Error\n",
+		"codes.txt": "- code: 404\n  type: Client Error\n  short: Not Found\n- code: 599\n  type: Server Error\n",
+	}
+	for name, content := range files {
+		codes, err := loadDataset(writeDataFile(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if len(codes) != 2 || codes[0].Code != 404 || *codes[0].Short != "Not Found" || codes[1].Short != nil {
+			t.Errorf("%s: unexpected codes %+v", name, codes)
+		}
+	}
+
+	codes, err := loadDataset(builtinDataset)
+	if err != nil || len(codes) != len(statusCodes) {
+		t.Errorf("Expected the embedded dataset, got %d codes, %v", len(codes), err)
+	}
+}
+
+// Test invalid data files are rejected with the reason
+func TestLoadDatasetErrors(t *testing.T) {
+	testCases := []struct {
+		name, content, expected string
+	}{
+		{"codes.txt", "404", "first on line 1: expected a list of status codes"},
+		{"bad.json", `{"code":404}`, "first on line 1: code 404 has no type"},
+		{"range.yaml", "- code: 99\n  type: Odd\n", "code 99 is out of range"},
+		{"type.yaml", "- code: 404\n", "code 404 has no type"},
+		{"twice.yaml", "- {code: 404, type: A}\n- {code: 404, type: B}\n", "first on line 2: code 404 is already defined on line 1"},
+	}
+	for _, tc := range testCases {
+		_, err := loadDataset(writeDataFile(t, tc.name, tc.content))
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+
+	if _, err := loadDataset(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
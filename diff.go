@@ -0,0 +1,206 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// diffFields are the dataset fields compared by diff; framework and
+// registration are derived, not stored
+var diffFields = baseFields[1:]
+
+// fieldChange is one field that differs between two definitions of a code
+type fieldChange struct {
+	Field string  `json:"field"`
+	From  *string `json:"from,omitempty"`
+	To    *string `json:"to,omitempty"`
+}
+
+// codeChange lists the field changes for a code present in both datasets
+type codeChange struct {
+	Code    int           `json:"code"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// datasetDiff is the difference between two datasets, each list in code order
+type datasetDiff struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Added   []StatusCode `json:"added"`
+	Removed []StatusCode `json:"removed"`
+	Changed []codeChange `json:"changed"`
+}
+
+// empty reports whether the datasets are the same
+func (d datasetDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// diffDatasets compares two datasets code by code
+func diffDatasets(from, to []StatusCode) datasetDiff {
+	d := datasetDiff{Added: []StatusCode{}, Removed: []StatusCode{}, Changed: []codeChange{}}
+
+	old := make(map[int]StatusCode)
+	for _, sc := range from {
+		old[sc.Code] = sc
+	}
+	current := make(map[int]bool)
+	for _, sc := range to {
+		current[sc.Code] = true
+		prev, found := old[sc.Code]
+		if !found {
+			d.Added = append(d.Added, sc)
+			continue
+		}
+
+		var changes []fieldChange
+		for _, f := range diffFields {
+			before, hadBefore := f.value(prev)
+			after, hasAfter := f.value(sc)
+			if before == after && hadBefore == hasAfter {
+				continue
+			}
+			change := fieldChange{Field: f.name}
+			if hadBefore {
+				change.From = strPtr(before)
+			}
+			if hasAfter {
+				change.To = strPtr(after)
+			}
+			changes = append(changes, change)
+		}
+		if len(changes) > 0 {
+			d.Changed = append(d.Changed, codeChange{Code: sc.Code, Changes: changes})
+		}
+	}
+	for _, sc := range from {
+		if !current[sc.Code] {
+			d.Removed = append(d.Removed, sc)
+		}
+	}
+
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].Code < d.Added[j].Code })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].Code < d.Removed[j].Code })
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].Code < d.Changed[j].Code })
+	return d
+}
+
+// diffHunk is one code's section of the text report
+type diffHunk struct {
+	code  int
+	kind  string
+	lines []string
+}
+
+// fieldLines renders the fields of a code as diff lines with a marker
+func fieldLines(marker string, sc StatusCode) []string {
+	var lines []string
+	for _, f := range diffFields {
+		if value, ok := f.value(sc); ok {
+			lines = append(lines, fmt.Sprintf("%s%s: %s", marker, f.name, value))
+		}
+	}
+	return lines
+}
+
+// printDiff writes a unified-style report with one hunk per code, in code
+// order, followed by a summary; identical datasets print nothing
+func printDiff(w io.Writer, d datasetDiff) {
+	if d.empty() {
+		return
+	}
+
+	var hunks []diffHunk
+	for _, sc := range d.Added {
+		hunks = append(hunks, diffHunk{sc.Code, "added", fieldLines("+", sc)})
+	}
+	for _, sc := range d.Removed {
+		hunks = append(hunks, diffHunk{sc.Code, "removed", fieldLines("-", sc)})
+	}
+	for _, cc := range d.Changed {
+		var lines []string
+		for _, c := range cc.Changes {
+			if c.From != nil {
+				lines = append(lines, fmt.Sprintf("-%s: %s", c.Field, *c.From))
+			}
+			if c.To != nil {
+				lines = append(lines, fmt.Sprintf("+%s: %s", c.Field, *c.To))
+			}
+		}
+		hunks = append(hunks, diffHunk{cc.Code, "changed", lines})
+	}
+	sort.SliceStable(hunks, func(i, j int) bool { return hunks[i].code < hunks[j].code })
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", d.From, d.To)
+	for _, h := range hunks {
+		fmt.Fprintf(w, "@@ %d %s @@\n", h.code, h.kind)
+		for _, line := range h.lines {
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintf(w, "\n%d added, %d removed, %d changed\n", len(d.Added), len(d.Removed), len(d.Changed))
+}
+
+// runDiff implements the diff subcommand, reporting whether the datasets differ
+func runDiff(args []string, w io.Writer) (bool, error) {
+	fs := newFlagSet("diff")
+	jsonOut := fs.Bool("json", false, "Output the differences as JSON")
+	overlay := fs.Bool("overlay", false, "Layer each data file over the built-in codes, as custom files are, before comparing")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return false, err
+	}
+	if len(positional) != 2 {
+		return false, fmt.Errorf("diff requires two data files, e.g. httpstatus diff builtin custom.yaml")
+	}
+
+	var datasets [2][]StatusCode
+	for i, source := range positional {
+		if datasets[i], err = loadDataset(source); err != nil {
+			return false, err
+		}
+		if *overlay && source != builtinDataset {
+			layers := []dataLayer{{source: builtinDataset, codes: builtinCodes}, {source: source, codes: datasets[i]}}
+			if datasets[i], _, err = mergeLayers(layers, mergeStrategies[0]); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	d := diffDatasets(datasets[0], datasets[1])
+	d.From, d.To = positional[0], positional[1]
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(d); err != nil {
+			return false, err
+		}
+	} else {
+		printDiff(w, d)
+	}
+	return !d.empty(), nil
+}
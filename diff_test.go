@@ -0,0 +1,137 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// diffFixture returns a custom dataset with one code added, removed and changed
+func diffFixture() (from, to []StatusCode) {
+	from = []StatusCode{
+		{Code: 404, Type: "Client Error", Short: strPtr("Not Found"), Long: strPtr("Requested resource could not be found")},
+		{Code: 420, Type: "Client Error", Short: strPtr("Enhance Your Calm")},
+		{Code: 500, Type: "Server Error", Short: strPtr("Internal Server Error")},
+	}
+	to = []StatusCode{
+		{Code: 209, Type: "Success", Short: strPtr("Custom Thing")},
+		{Code: 404, Type: "Client Error", Short: strPtr("Missing")},
+		{Code: 500, Type: "Server Error", Short: strPtr("Internal Server Error")},
+	}
+	return from, to
+}
+
+// Test added, removed and field-level changes are found
+func TestDiffDatasets(t *testing.T) {
+	d := diffDatasets(diffFixture())
+	if len(d.Added) != 1 || d.Added[0].Code != 209 {
+		t.Errorf("Unexpected added codes: %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].Code != 420 {
+		t.Errorf("Unexpected removed codes: %+v", d.Removed)
+	}
+	if len(d.Changed) != 1 || d.Changed[0].Code != 404 || len(d.Changed[0].Changes) != 2 {
+		t.Fatalf("Unexpected changed codes: %+v", d.Changed)
+	}
+	long := d.Changed[0].Changes[1]
+	if long.Field != "long" || long.From == nil || long.To != nil {
+		t.Errorf("Expected the long description to be removed, got %+v", long)
+	}
+
+	if same := diffDatasets(statusCodes, statusCodes); !same.empty() {
+		t.Errorf("Expected no differences, got %+v", same)
+	}
+}
+
+// Test the unified-style report
+func TestPrintDiff(t *testing.T) {
+	d := diffDatasets(diffFixture())
+	d.From, d.To = "builtin", "custom.yaml"
+
+	var buf bytes.Buffer
+	printDiff(&buf, d)
+	checkGolden(t, "testdata/diff/report.golden", buf.Bytes())
+
+	buf.Reset()
+	printDiff(&buf, diffDatasets(statusCodes, statusCodes))
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for identical datasets, got:\n%s", buf.String())
+	}
+}
+
+// Test the JSON report and the differ result of the subcommand
+func TestRunDiff(t *testing.T) {
+	from, to := diffFixture()
+	data, _ := json.Marshal(from)
+	fromPath := writeDataFile(t, "from.json", string(data))
+	data, _ = json.Marshal(to)
+	toPath := writeDataFile(t, "to.json", string(data))
+
+	var buf bytes.Buffer
+	differ, err := runDiff([]string{fromPath, toPath, "--json"}, &buf)
+	if err != nil || !differ {
+		t.Fatalf("Expected differences, got %t, %v", differ, err)
+	}
+	var d map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	for _, key := range []string{"from", "to", "added", "removed", "changed"} {
+		if _, ok := d[key]; !ok {
+			t.Errorf("Missing %s in JSON report", key)
+		}
+	}
+
+	buf.Reset()
+	differ, err = runDiff([]string{"builtin", "builtin", "--json"}, &buf)
+	if err != nil || differ {
+		t.Errorf("Expected no differences, got %t, %v", differ, err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"added": []`)) {
+		t.Errorf("Expected empty lists rather than null, got:\n%s", buf.String())
+	}
+
+	if _, err := runDiff([]string{"builtin"}, &buf); err == nil {
+		t.Error("Expected an error for a single data file")
+	}
+}
+
+// Test --overlay diffs a custom file as --custom would load it, so the
+// built-in codes it leaves out are not reported as removed
+func TestRunDiffOverlay(t *testing.T) {
+	path := writeDataFile(t, "custom.yaml", "- code: 599\n  type: Server Error\n  short: Network Connect Timeout\n  long: The network connection timed out\n")
+
+	var buf bytes.Buffer
+	differ, err := runDiff([]string{"--overlay", "builtin", path, "--json"}, &buf)
+	if err != nil || !differ {
+		t.Fatalf("Expected differences, got %t, %v", differ, err)
+	}
+	var d datasetDiff
+	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(d.Added) != 1 || d.Added[0].Code != 599 || len(d.Removed) != 0 || len(d.Changed) != 0 {
+		t.Errorf("Expected only 599 added, got %d added, %d removed, %d changed", len(d.Added), len(d.Removed), len(d.Changed))
+	}
+}
@@ -0,0 +1,398 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// enrichColumns are the columns appended to each CSV row
+var enrichColumns = []string{"status_type", "status_short", "status_long"}
+
+// runEnrich implements "httpstatus enrich [flags]"
+func runEnrich(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("enrich")
+	csvIn := fs.String("csv-in", "", "CSV file to enrich (- for stdin)")
+	column := fs.String("column", "status_code", "CSV column holding the status code, by name or 1-based index")
+	noHeader := fs.Bool("no-header", false, "The CSV has no header row (--column must be an index)")
+	jsonIn := fs.String("json-in", "", "JSON array or NDJSON file to enrich (- for stdin)")
+	field := fs.String("field", "", "Dotted path to the status code in each JSON record, e.g. response.status")
+	outPath := fs.String("out", "", "Write the enriched output to a file instead of stdout")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("enrich takes no arguments, got: '%s'", strings.Join(positional, " "))
+	}
+	if (*csvIn == "") == (*jsonIn == "") {
+		return fmt.Errorf("enrich requires exactly one of --csv-in or --json-in")
+	}
+	if *jsonIn != "" && *field == "" {
+		return fmt.Errorf("--json-in requires --field")
+	}
+
+	inPath := *csvIn
+	if *jsonIn != "" {
+		inPath = *jsonIn
+	}
+	in, err := openEnrichInput(inPath, stdin)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var out io.Writer = stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+
+	var unknown int
+	if *jsonIn != "" {
+		unknown, err = enrichJSON(in, bw, strings.Split(*field, "."))
+	} else {
+		unknown, err = enrichCSV(in, bw, *column, !*noHeader)
+	}
+	if flushErr := bw.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+	if unknown > 0 {
+		fmt.Fprintf(stderr, "warning: %d record(s) had an unknown or missing status code\n", unknown)
+	}
+	return nil
+}
+
+// openEnrichInput opens an input file, with "-" meaning stdin
+func openEnrichInput(path string, stdin io.Reader) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(path)
+}
+
+// recordTap keeps a copy of everything read so the raw text of each CSV
+// record can be passed through unchanged
+type recordTap struct {
+	r   io.Reader
+	buf []byte
+}
+
+func (t *recordTap) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	t.buf = append(t.buf, p[:n]...)
+	return n, err
+}
+
+// enrichCSV appends the status columns to every record, streaming row by
+// row; original fields are copied byte for byte to preserve their quoting.
+// It returns the number of rows whose code could not be resolved
+func enrichCSV(r io.Reader, w io.Writer, column string, header bool) (int, error) {
+	tap := &recordTap{r: r}
+	cr := csv.NewReader(tap)
+	cr.FieldsPerRecord = -1
+
+	var base int64
+	index := -1
+	unknown := 0
+	for row := 0; ; row++ {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			return unknown, nil
+		}
+		if err != nil {
+			return unknown, err
+		}
+
+		// Slice this record's raw text out of the tapped input
+		end := cr.InputOffset()
+		raw := tap.buf[:end-base]
+		tap.buf = tap.buf[end-base:]
+		base = end
+
+		if row == 0 {
+			if index, err = csvColumnIndex(column, fields, header); err != nil {
+				return unknown, err
+			}
+			if header {
+				writeEnrichedRecord(w, raw, enrichColumns)
+				continue
+			}
+		}
+
+		extra := []string{"", "", ""}
+		var sc StatusCode
+		found := false
+		if index < len(fields) {
+			if code, err := strconv.Atoi(strings.TrimSpace(fields[index])); err == nil {
+				sc, found = findStatusCode(code)
+			}
+		}
+		if found {
+			extra = []string{sc.Type, strValue(sc.Short), strValue(sc.Long)}
+		} else {
+			unknown++
+		}
+		writeEnrichedRecord(w, raw, extra)
+	}
+}
+
+// csvColumnIndex resolves --column to a zero-based field index
+func csvColumnIndex(column string, first []string, header bool) (int, error) {
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 || n > len(first) {
+			return 0, fmt.Errorf("invalid column: '%s' - the CSV has %d columns", column, len(first))
+		}
+		return n - 1, nil
+	}
+	if !header {
+		return 0, fmt.Errorf("invalid column: '%s' - must be a 1-based index with --no-header", column)
+	}
+	for i, name := range first {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("column '%s' not found in header: %s", column, strings.Join(first, ", "))
+}
+
+// writeEnrichedRecord writes a raw record followed by extra fields, keeping
+// its original line ending
+func writeEnrichedRecord(w io.Writer, raw []byte, extra []string) {
+	body, ending := raw, []byte(nil)
+	for _, eol := range [][]byte{[]byte("\r\n"), []byte("\n")} {
+		if bytes.HasSuffix(body, eol) {
+			body, ending = body[:len(body)-len(eol)], eol
+			break
+		}
+	}
+	w.Write(body)
+	for _, field := range extra {
+		io.WriteString(w, ","+quoteCSVField(field))
+	}
+	w.Write(ending)
+}
+
+// quoteCSVField quotes a field when encoding/csv would
+func quoteCSVField(s string) string {
+	if s == "" || (!strings.ContainsAny(s, ",\"\r\n") && s[0] != ' ' && s[0] != '\t') {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// statusInfo is the object added next to the status field of a JSON record
+type statusInfo struct {
+	Type  string `json:"type"`
+	Short string `json:"short"`
+	Long  string `json:"long"`
+}
+
+// orderedJSON is a JSON object that keeps its key order and leaves its
+// values as raw, unparsed JSON
+type orderedJSON struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func (o *orderedJSON) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("not a JSON object")
+	}
+	o.values = make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		o.set(tok.(string), value)
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func (o orderedJSON) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// set adds or replaces a key, appending new keys at the end
+func (o *orderedJSON) set(key string, value json.RawMessage) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// enrichResult reports what enrichJSONRecord did to a record
+type enrichResult int
+
+const (
+	fieldMissing enrichResult = iota
+	codeUnknown
+	enriched
+)
+
+// enrichJSONRecord adds a "<field>_info" sibling to the status field at
+// path; records are returned unchanged unless enriched
+func enrichJSONRecord(raw json.RawMessage, path []string) (json.RawMessage, enrichResult) {
+	var obj orderedJSON
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return raw, fieldMissing
+	}
+	value, ok := obj.values[path[0]]
+	if !ok {
+		return raw, fieldMissing
+	}
+
+	if len(path) > 1 {
+		child, result := enrichJSONRecord(value, path[1:])
+		if result != enriched {
+			return raw, result
+		}
+		obj.values[path[0]] = child
+	} else {
+		// Accept both 404 and "404"
+		var code json.Number
+		if err := json.Unmarshal(value, &code); err != nil {
+			var s string
+			if json.Unmarshal(value, &s) != nil {
+				return raw, codeUnknown
+			}
+			code = json.Number(strings.TrimSpace(s))
+		}
+		n, err := strconv.Atoi(code.String())
+		if err != nil {
+			return raw, codeUnknown
+		}
+		sc, found := findStatusCode(n)
+		if !found {
+			return raw, codeUnknown
+		}
+		info, _ := json.Marshal(statusInfo{Type: sc.Type, Short: strValue(sc.Short), Long: strValue(sc.Long)})
+		obj.set(path[0]+"_info", info)
+	}
+
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return raw, fieldMissing
+	}
+	return out, enriched
+}
+
+// enrichJSON enriches a JSON array or an NDJSON stream record by record,
+// writing the same shape back out. It returns the number of records whose
+// status field held an unknown code
+func enrichJSON(r io.Reader, w io.Writer, path []string) (int, error) {
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+	unknown := 0
+
+	enrich := func(raw json.RawMessage) json.RawMessage {
+		out, result := enrichJSONRecord(raw, path)
+		if result == codeUnknown {
+			unknown++
+		}
+		return out
+	}
+
+	// Peek past whitespace to tell an array from NDJSON
+	var first byte
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b[0])) {
+			first = b[0]
+			break
+		}
+		br.ReadByte()
+	}
+
+	if first == '[' {
+		dec.Token()
+		io.WriteString(w, "[")
+		for i := 0; dec.More(); i++ {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return unknown, err
+			}
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			w.Write(enrich(raw))
+		}
+		if _, err := dec.Token(); err != nil {
+			return unknown, err
+		}
+		io.WriteString(w, "]\n")
+		return unknown, nil
+	}
+
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return unknown, nil
+		} else if err != nil {
+			return unknown, err
+		}
+		w.Write(enrich(raw))
+		io.WriteString(w, "\n")
+	}
+}
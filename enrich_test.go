@@ -0,0 +1,188 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test CSV rows gain status columns with the original text untouched
+func TestEnrichCSV(t *testing.T) {
+	input := "id,\"status_code\",note\r\n" +
+		"1,404,\"said \"\"gone\"\"\"\r\n" +
+		"2, 503 ,\"multi\nline\"\r\n" +
+		"3,999,unknown\r\n" +
+		"4,,empty\r\n"
+	want := "id,\"status_code\",note,status_type,status_short,status_long\r\n" +
+		"1,404,\"said \"\"gone\"\"\",Client Error,Not Found,Requested resource could not be found\r\n" +
+		"2, 503 ,\"multi\nline\",Server Error,Service Unavailable,Server temporarily overloaded or down\r\n" +
+		"3,999,unknown,,,\r\n" +
+		"4,,empty,,,\r\n"
+
+	var out bytes.Buffer
+	unknown, err := enrichCSV(strings.NewReader(input), &out, "status_code", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("Unexpected output:\n%q\nwant:\n%q", out.String(), want)
+	}
+	if unknown != 2 {
+		t.Errorf("Expected 2 unknown rows, got %d", unknown)
+	}
+}
+
+// Test the column can be given by index, with or without a header
+func TestEnrichCSVColumnIndex(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := enrichCSV(strings.NewReader("a,b\nx,418"), &out, "2", true); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a,b,status_type,status_short,status_long\nx,418,Client Error,I'm a teapot,Server refuses to brew coffee (RFC 2324)"; out.String() != want {
+		t.Errorf("Unexpected output:\n%q", out.String())
+	}
+
+	out.Reset()
+	if _, err := enrichCSV(strings.NewReader("200\n201\n"), &out, "1", false); err != nil {
+		t.Fatal(err)
+	}
+	if want := "200,Success,OK,Standard response for successful HTTP requests\n201,Success,Created,New resource created as result of request\n"; out.String() != want {
+		t.Errorf("Unexpected headerless output:\n%q", out.String())
+	}
+
+	for _, tc := range []struct {
+		column string
+		header bool
+	}{{"missing", true}, {"0", true}, {"3", true}, {"code", false}} {
+		if _, err := enrichCSV(strings.NewReader("a,b\n1,2\n"), &bytes.Buffer{}, tc.column, tc.header); err == nil {
+			t.Errorf("Expected error for column %q (header=%v)", tc.column, tc.header)
+		}
+	}
+}
+
+// Test the subcommand writes to --out and warns about unknown codes
+func TestRunEnrich(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "incidents.csv")
+	out := filepath.Join(dir, "enriched.csv")
+	if err := os.WriteFile(in, []byte("status_code\n500\n600\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := runEnrich([]string{"--csv-in", in, "--out", out}, nil, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "500,Server Error,Internal Server Error") {
+		t.Errorf("Unexpected enriched file:\n%s", data)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected nothing on stdout with --out, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "1 record(s)") {
+		t.Errorf("Expected unknown-code warning, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	if err := runEnrich([]string{"--csv-in", "-", "--column", "1"}, strings.NewReader("code\n204\n"), &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "204,Success,No Content") {
+		t.Errorf("Unexpected stdin output:\n%s", stdout.String())
+	}
+
+	if err := runEnrich(nil, nil, &stdout, &stderr); err == nil {
+		t.Error("Expected error without --csv-in")
+	}
+}
+
+// Test NDJSON records gain a sibling status object, keeping key order
+func TestEnrichJSONLines(t *testing.T) {
+	input := `{"id":1,"response":{"status":404,"ms":12}}
+{"id":2,"response":{"status":"503"}}
+{"id":3,"request":{"path":"/"}}
+{"id":4,"response":{"status":999}}
+`
+	want := `{"id":1,"response":{"status":404,"ms":12,"status_info":{"type":"Client Error","short":"Not Found","long":"Requested resource could not be found"}}}
+{"id":2,"response":{"status":"503","status_info":{"type":"Server Error","short":"Service Unavailable","long":"Server temporarily overloaded or down"}}}
+{"id":3,"request":{"path":"/"}}
+{"id":4,"response":{"status":999}}
+`
+	var out bytes.Buffer
+	unknown, err := enrichJSON(strings.NewReader(input), &out, []string{"response", "status"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if unknown != 1 {
+		t.Errorf("Expected 1 unknown record, got %d", unknown)
+	}
+}
+
+// Test a JSON array is written back as an array
+func TestEnrichJSONArray(t *testing.T) {
+	input := "  [\n  {\"code\": 201},\n  {\"other\": true},\n  \"not an object\"\n]\n"
+	want := `[{"code":201,"code_info":{"type":"Success","short":"Created","long":"New resource created as result of request"}},{"other": true},"not an object"]` + "\n"
+
+	var out bytes.Buffer
+	if _, err := enrichJSON(strings.NewReader(input), &out, []string{"code"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	if _, err := enrichJSON(strings.NewReader(`[{"code":200}`), &bytes.Buffer{}, []string{"code"}); err == nil {
+		t.Error("Expected error for a truncated array")
+	}
+}
+
+// Test the JSON mode of the subcommand and its flag validation
+func TestRunEnrichJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := runEnrich([]string{"--json-in", "-", "--field", "status"}, strings.NewReader(`{"status":302}`), &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), `"status_info":{"type":"Redirection","short":"Found"`) {
+		t.Errorf("Unexpected output: %s", stdout.String())
+	}
+
+	for _, args := range [][]string{
+		{"--json-in", "-"},
+		{"--json-in", "-", "--csv-in", "x.csv", "--field", "status"},
+	} {
+		if err := runEnrich(args, strings.NewReader(""), &stdout, &stderr); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// setting is an effective setting and the layer that supplied it
+type setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// settingLayer is a value offered by one layer; an empty value is unset
+type settingLayer struct {
+	value  string
+	source string
+}
+
+// resolveSetting picks the first layer with a value, so layers are given
+// highest precedence first: command line, environment, config file. With
+// none set the default applies
+func resolveSetting(name, def string, layers ...settingLayer) setting {
+	for _, l := range layers {
+		if l.value != "" {
+			return setting{Name: name, Value: l.value, Source: l.source}
+		}
+	}
+	return setting{Name: name, Value: def, Source: "default"}
+}
+
+// flagSource describes a value given on the command line
+func flagSource(name string) string {
+	return "command line (--" + name + ")"
+}
+
+// envSource describes a value from an environment variable
+func envSource(name string) string {
+	return "env " + name
+}
+
+// configSource describes a value from a key in the config file
+func configSource(cfg appConfig, key string) string {
+	return fmt.Sprintf("config file %s (%s)", cfg.path, key)
+}
+
+// mergeStrategySetting resolves the merge strategy from --merge-strategy
+// and the config file
+func mergeStrategySetting(cfg appConfig, flagValue string) setting {
+	return resolveSetting("merge-strategy", mergeStrategies[0],
+		settingLayer{flagValue, flagSource("merge-strategy")},
+		settingLayer{cfg.MergeStrategy, configSource(cfg, "merge-strategy")})
+}
+
+// runEnv implements "httpstatus env [flags]", showing each effective
+// setting and where it came from
+func runEnv(args []string, w io.Writer) error {
+	fs := newFlagSet("env")
+	jsonOut := fs.Bool("json", false, "Output the settings as JSON")
+	strategy := fs.String("merge-strategy", "", "Merge strategy to explain, as given to a lookup")
+	color := fs.String("color", "", "Colour mode to explain, as given to a lookup")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("env takes no arguments, only the flags whose effect it should show")
+	}
+	if *strategy != "" && !validMergeStrategy(*strategy) {
+		return fmt.Errorf("invalid merge strategy: '%s' - must be one of %s", *strategy, strings.Join(mergeStrategies, ", "))
+	}
+	if *color != "" && !validColorMode(*color) {
+		return fmt.Errorf("invalid color mode: '%s' - must be one of %s", *color, strings.Join(colorModes, ", "))
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	customPath, _ := customDataPath()
+	settings := []setting{
+		{Name: "config-file", Value: cfg.path, Source: "default"},
+		{Name: "custom-file", Value: customPath, Source: "default"},
+		mergeStrategySetting(cfg, *strategy),
+		colorSetting(*color, os.Stdout),
+		errorFormat,
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE")
+	for _, s := range settings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Name, s.Value, s.Source)
+	}
+	return tw.Flush()
+}
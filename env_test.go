@@ -0,0 +1,161 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test the highest layer with a value wins, falling back to the default
+func TestResolveSetting(t *testing.T) {
+	flagLayer := settingLayer{"json", "command line (--x)"}
+	envLayer := settingLayer{"yaml", "env X"}
+	cfgLayer := settingLayer{"toml", "config file c.yaml (x)"}
+
+	tests := []struct {
+		layers []settingLayer
+		value  string
+		source string
+	}{
+		{[]settingLayer{flagLayer, envLayer, cfgLayer}, "json", "command line (--x)"},
+		{[]settingLayer{{}, envLayer, cfgLayer}, "yaml", "env X"},
+		{[]settingLayer{{}, {}, cfgLayer}, "toml", "config file c.yaml (x)"},
+		{[]settingLayer{{}, {}, {}}, "text", "default"},
+	}
+	for _, tt := range tests {
+		s := resolveSetting("x", "text", tt.layers...)
+		if s.Value != tt.value || s.Source != tt.source {
+			t.Errorf("Expected %s from %s, got %+v", tt.value, tt.source, s)
+		}
+	}
+}
+
+// runEnvJSON runs env with a full command line, as main dispatches it,
+// and returns the settings by name
+func runEnvJSON(t *testing.T, cliArgs ...string) map[string]setting {
+	t.Helper()
+	resetErrorFormat(t)
+	rest, err := takeErrorFormat(cliArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := runEnv(append(rest[1:], "--json"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var settings []setting
+	if err := json.Unmarshal(buf.Bytes(), &settings); err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]setting)
+	for _, s := range settings {
+		byName[s.Name] = s
+	}
+	return byName
+}
+
+// Test env reports the layer behind each setting
+func TestRunEnvProvenance(t *testing.T) {
+	cfg := useTempConfig(t)
+	t.Setenv("NO_COLOR", "")
+
+	settings := runEnvJSON(t, "env")
+	for _, name := range []string{"merge-strategy", "color", "error-format"} {
+		if !strings.HasPrefix(settings[name].Source, "default") {
+			t.Errorf("Expected %s to be the default, got %+v", name, settings[name])
+		}
+	}
+	if settings["config-file"].Value != filepath.Join(cfg, "config.yaml") {
+		t.Errorf("Unexpected config file: %+v", settings["config-file"])
+	}
+
+	os.WriteFile(filepath.Join(cfg, "config.yaml"), []byte("merge-strategy: keep-builtin\n"), 0644)
+	t.Setenv("NO_COLOR", "1")
+	settings = runEnvJSON(t, "--error-format", "json", "env")
+	if s := settings["merge-strategy"]; s.Value != "keep-builtin" || !strings.HasPrefix(s.Source, "config file ") || !strings.HasSuffix(s.Source, "(merge-strategy)") {
+		t.Errorf("Expected the strategy from the config file, got %+v", s)
+	}
+	if s := settings["color"]; s.Value != "never" || s.Source != "env NO_COLOR" {
+		t.Errorf("Expected colour to be off from NO_COLOR, got %+v", s)
+	}
+	if s := settings["error-format"]; s.Value != "json" || s.Source != "command line (--error-format)" {
+		t.Errorf("Expected the error format from the command line, got %+v", s)
+	}
+
+	settings = runEnvJSON(t, "env", "--merge-strategy", "error", "--color", "always")
+	if s := settings["merge-strategy"]; s.Value != "error" || s.Source != "command line (--merge-strategy)" {
+		t.Errorf("Expected the flag to beat the config file, got %+v", s)
+	}
+	if s := settings["color"]; s.Value != "always" || s.Source != "command line (--color)" {
+		t.Errorf("Expected the flag to beat NO_COLOR, got %+v", s)
+	}
+
+	// env explains the same decision a lookup makes
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "dumb")
+	settings = runEnvJSON(t, "env")
+	if s := settings["color"]; s != colorSetting("auto", os.Stdout) || s.Value != "never" || s.Source != "env TERM" {
+		t.Errorf("Expected colour to be off from TERM=dumb, got %+v", s)
+	}
+}
+
+// Test the error format's source follows the command line, including a
+// flag that repeats the default value
+func TestRunEnvErrorFormatSource(t *testing.T) {
+	useTempConfig(t)
+	tests := []struct {
+		args   []string
+		value  string
+		source string
+	}{
+		{[]string{"env"}, "text", "default"},
+		{[]string{"env", "--error-format", "text"}, "text", "command line (--error-format)"},
+		{[]string{"env", "--error-format=json"}, "json", "command line (--error-format)"},
+		{[]string{"--error-format", "json", "env"}, "json", "command line (--error-format)"},
+	}
+	for _, tt := range tests {
+		if s := runEnvJSON(t, tt.args...)["error-format"]; s.Value != tt.value || s.Source != tt.source {
+			t.Errorf("%v: expected %s from %s, got %+v", tt.args, tt.value, tt.source, s)
+		}
+	}
+}
+
+// Test the table lists every setting under a header
+func TestRunEnvTable(t *testing.T) {
+	useTempConfig(t)
+	var buf bytes.Buffer
+	if err := runEnv(nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[0], "SETTING") {
+		t.Errorf("Unexpected table:\n%s", buf.String())
+	}
+	if err := runEnv([]string{"--color", "sometimes"}, &buf); err == nil {
+		t.Error("Expected an invalid colour mode to fail")
+	}
+}
@@ -0,0 +1,248 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error kinds reported by --error-format json; scripts match on these,
+// so they must stay stable
+const (
+	errInvalidInput = "invalid_input"
+	errNotFound     = "not_found"
+	errIO           = "io_error"
+	errNetwork      = "network_error"
+)
+
+// errorFormats lists the values accepted by --error-format
+var errorFormats = []string{"text", "json"}
+
+// errorFormat is how errors are written to stderr, set from --error-format
+var errorFormat = resolveSetting("error-format", errorFormats[0])
+
+// errorFormatFlag is --error-format. Every flag set registers it, so the
+// flag set that owns an argument decides whether it is --error-format or
+// the value of another flag
+type errorFormatFlag struct{}
+
+func (errorFormatFlag) String() string {
+	return errorFormat.Value
+}
+
+func (errorFormatFlag) Set(value string) error {
+	if !slices.Contains(errorFormats, value) {
+		return fmt.Errorf("invalid error format: '%s' - must be one of %s", value, strings.Join(errorFormats, ", "))
+	}
+	errorFormat = setting{Name: "error-format", Value: value, Source: flagSource("error-format")}
+	return nil
+}
+
+// flagMessages holds the flag package's messages until parsing stops,
+// so an --error-format after a bad flag still decides how it is reported
+var flagMessages bytes.Buffer
+
+// flagOutput collects the flag package's messages in flagMessages
+type flagOutput struct{}
+
+func (flagOutput) Write(p []byte) (int, error) {
+	return flagMessages.Write(p)
+}
+
+// parseFlags parses args with fs. A parse error stops the flag set
+// before it reaches a later --error-format, so on error the remaining
+// arguments are scanned for one. The flag package's messages are then
+// written to stderr, unless --error-format json replaces them with the
+// JSON error
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil {
+		scanErrorFormat(args)
+	}
+	if errorFormat.Value != "json" {
+		os.Stderr.Write(flagMessages.Bytes())
+	}
+	flagMessages.Reset()
+	return err
+}
+
+// scanErrorFormat sets the last valid --error-format in args, up to any
+// "--"; invalid values are left to the error already being reported
+func scanErrorFormat(args []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "error-format" {
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return
+			}
+			value = args[i+1]
+		}
+		(errorFormatFlag{}).Set(value)
+	}
+}
+
+// cliError is an error with a stable kind, written as a single JSON
+// object by --error-format json
+type cliError struct {
+	Kind        string `json:"error"`
+	Message     string `json:"message"`
+	Input       string `json:"input,omitempty"`
+	Suggestions []int  `json:"suggestions,omitempty"`
+}
+
+func (e *cliError) Error() string {
+	return e.Message
+}
+
+// notFoundError reports an input that matched no status codes
+func notFoundError(input string) *cliError {
+	return &cliError{
+		Kind:        errNotFound,
+		Message:     fmt.Sprintf("no HTTP status codes found matching: '%s'", input),
+		Input:       input,
+		Suggestions: nearbyCodes(input),
+	}
+}
+
+// nearbyCodes returns up to three known codes in the same class within
+// ten of a three-digit code, closest first
+func nearbyCodes(input string) []int {
+	code, err := strconv.Atoi(input)
+	if err != nil || len(input) != 3 {
+		return nil
+	}
+
+	var nearby []int
+	for _, sc := range statusCodes {
+		if sc.Code/100 == code/100 && sc.Code != code && abs(sc.Code-code) <= 10 {
+			nearby = append(nearby, sc.Code)
+		}
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return abs(nearby[i]-code) < abs(nearby[j]-code)
+	})
+	if len(nearby) > 3 {
+		nearby = nearby[:3]
+	}
+	return nearby
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// classifyError returns the cliError for an error, inferring the kind
+// from the underlying error when it was not given one
+func classifyError(err error) *cliError {
+	var ce *cliError
+	if errors.As(err, &ce) {
+		classified := *ce
+		classified.Message = err.Error()
+		return &classified
+	}
+
+	// Check the network types first: a syscall.Errno inside a PathError
+	// also satisfies net.Error
+	kind := errInvalidInput
+	var opErr *net.OpError
+	var urlErr *url.Error
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &opErr), errors.As(err, &urlErr):
+		kind = errNetwork
+	case errors.As(err, &pathErr):
+		kind = errIO
+	}
+	return &cliError{Kind: kind, Message: err.Error()}
+}
+
+// writeErrorJSON writes an error as a single line of JSON
+func writeErrorJSON(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(classifyError(err))
+}
+
+// reportError writes an error to stderr in the --error-format
+func reportError(err error) {
+	if errorFormat.Value != "json" {
+		log.Print(err)
+		return
+	}
+	if werr := writeErrorJSON(os.Stderr, err); werr != nil {
+		log.Print(err)
+	}
+}
+
+// takeErrorFormat takes --error-format from the front of the arguments,
+// where it may come before a subcommand. After the first other argument
+// it is left to the flag set that parses the rest
+func takeErrorFormat(args []string) ([]string, error) {
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		if !strings.HasPrefix(args[0], "-") || name != "error-format" {
+			break
+		}
+		args = args[1:]
+		if !hasValue {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("flag needs an argument: -error-format")
+			}
+			value, args = args[0], args[1:]
+		}
+		if err := (errorFormatFlag{}).Set(value); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// newFlagSet creates a subcommand flag set; with --error-format json the
+// flag package's own messages give way to the JSON error
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(flagOutput{})
+	fs.Var(errorFormatFlag{}, "error-format", "Write errors to stderr as text or a json object")
+	return fs
+}
@@ -0,0 +1,238 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// decodeErrorJSON writes an error with --error-format json and decodes it
+func decodeErrorJSON(t *testing.T, err error) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	if werr := writeErrorJSON(&buf, err); werr != nil {
+		t.Fatalf("Unexpected error: %v", werr)
+	}
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 {
+		t.Errorf("Expected a single line of JSON, got %q", buf.String())
+	}
+	var obj map[string]interface{}
+	if jerr := json.Unmarshal(buf.Bytes(), &obj); jerr != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", jerr, buf.String())
+	}
+	return obj
+}
+
+// Test a not-found lookup reports the input and nearby codes
+func TestErrorJSONNotFound(t *testing.T) {
+	_, err := lookup(lookupQuery{args: []string{"490"}})
+	got := decodeErrorJSON(t, err)
+	want := map[string]interface{}{
+		"error":       "not_found",
+		"message":     "no HTTP status codes found matching: '490'",
+		"input":       "490",
+		"suggestions": []interface{}{499.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	_, err = lookup(lookupQuery{search: "no-such-keyword"})
+	if got := decodeErrorJSON(t, err); got["error"] != "not_found" || got["input"] != "no-such-keyword" {
+		t.Errorf("Unexpected search error: %v", got)
+	}
+}
+
+// Test invalid input omits the fields it has no value for
+func TestErrorJSONInvalidInput(t *testing.T) {
+	_, err := lookup(lookupQuery{codes: "abc"})
+	got := decodeErrorJSON(t, err)
+	if got["error"] != "invalid_input" || got["input"] != "abc" {
+		t.Errorf("Unexpected error object: %v", got)
+	}
+	if _, ok := got["suggestions"]; ok {
+		t.Error("Expected no suggestions for non-numeric input")
+	}
+
+	got = decodeErrorJSON(t, errors.New("--http2-style requires --example-response"))
+	want := map[string]interface{}{"error": "invalid_input", "message": "--http2-style requires --example-response"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// Test file errors are reported as io_error
+func TestErrorJSONIO(t *testing.T) {
+	_, err := os.Open(filepath.Join(t.TempDir(), "missing.csv"))
+	if got := decodeErrorJSON(t, err); got["error"] != "io_error" || got["message"] != err.Error() {
+		t.Errorf("Unexpected error object: %v", got)
+	}
+}
+
+// Test connection failures are reported as network_error
+func TestErrorJSONNetwork(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	_, err = http.Get("http://" + addr)
+	if err == nil {
+		t.Fatal("Expected a connection error")
+	}
+	if got := decodeErrorJSON(t, err); got["error"] != "network_error" {
+		t.Errorf("Unexpected error object: %v", got)
+	}
+}
+
+// Test wrapping keeps the kind but uses the full message
+func TestClassifyWrappedError(t *testing.T) {
+	err := classifyError(errors.Join(errors.New("quiz"), notFoundError("7")))
+	if err.Kind != errNotFound || err.Input != "7" || err.Message != "quiz\nno HTTP status codes found matching: '7'" {
+		t.Errorf("Unexpected classification: %+v", err)
+	}
+}
+
+// Test nearby codes stay within the class, closest first
+func TestNearbyCodes(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  []int
+	}{
+		{"490", []int{499}},
+		{"419", []int{418, 420, 417}},
+		{"509", []int{508, 510, 507}},
+		{"600", nil},
+		{"49", nil},
+		{"abc", nil},
+	}
+	for _, tc := range testCases {
+		if got := nearbyCodes(tc.input); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
+
+// resetErrorFormat restores the default error format now and after the test
+func resetErrorFormat(t *testing.T) {
+	t.Helper()
+	errorFormat = resolveSetting("error-format", errorFormats[0])
+	t.Cleanup(func() { errorFormat = resolveSetting("error-format", errorFormats[0]) })
+}
+
+// Test --error-format is only taken from the front of the command line
+func TestTakeErrorFormat(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		rest   []string
+		format string
+	}{
+		{[]string{"404"}, []string{"404"}, "text"},
+		{[]string{"--error-format", "json", "env"}, []string{"env"}, "json"},
+		{[]string{"-error-format=json", "serve", "--port", "80"}, []string{"serve", "--port", "80"}, "json"},
+		{[]string{"404", "--error-format", "json"}, []string{"404", "--error-format", "json"}, "text"},
+		{[]string{"-s", "--error-format"}, []string{"-s", "--error-format"}, "text"},
+	}
+	for _, tc := range testCases {
+		resetErrorFormat(t)
+		rest, err := takeErrorFormat(tc.args)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.args, err)
+		}
+		if !reflect.DeepEqual(rest, tc.rest) || errorFormat.Value != tc.format {
+			t.Errorf("%v: expected %v %s, got %v %s", tc.args, tc.rest, tc.format, rest, errorFormat.Value)
+		}
+	}
+
+	for _, args := range [][]string{{"--error-format", "yaml"}, {"--error-format"}, {"--error-format="}} {
+		resetErrorFormat(t)
+		if _, err := takeErrorFormat(args); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+// Test the flag set decides whether an argument is --error-format or
+// another flag's value
+func TestErrorFormatFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		search string
+		format string
+	}{
+		{[]string{"404", "--error-format", "json"}, "", "json"},
+		{[]string{"-s", "--error-format"}, "--error-format", "text"},
+		{[]string{"-s", "--error-format", "--error-format=json"}, "--error-format", "json"},
+	}
+	for _, tc := range testCases {
+		resetErrorFormat(t)
+		fs := newFlagSet("test")
+		search := fs.String("s", "", "Search term")
+		if _, err := parseInterspersed(fs, tc.args); err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.args, err)
+		}
+		if *search != tc.search || errorFormat.Value != tc.format {
+			t.Errorf("%v: expected search %q and format %s, got %q and %s", tc.args, tc.search, tc.format, *search, errorFormat.Value)
+		}
+	}
+
+	resetErrorFormat(t)
+	if _, err := parseInterspersed(newFlagSet("test"), []string{"--error-format", "yaml"}); err == nil {
+		t.Error("Expected an invalid error format to fail")
+	}
+}
+
+// Test an --error-format after an unknown flag still decides the format,
+// and the flag package's usage gives way to the JSON error
+func TestErrorFormatAfterBadFlag(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		format string
+	}{
+		{[]string{"--bogus", "--error-format", "json"}, "json"},
+		{[]string{"404", "--bogus", "--error-format=json"}, "json"},
+		{[]string{"--bogus", "--", "--error-format", "json"}, "text"},
+		{[]string{"--bogus", "--error-format", "yaml"}, "text"},
+	}
+	for _, tc := range testCases {
+		resetErrorFormat(t)
+		if _, err := parseInterspersed(newFlagSet("test"), tc.args); err == nil {
+			t.Fatalf("%v: expected an error for the unknown flag", tc.args)
+		}
+		if errorFormat.Value != tc.format {
+			t.Errorf("%v: expected format %s, got %s", tc.args, tc.format, errorFormat.Value)
+		}
+		if flagMessages.Len() != 0 {
+			t.Errorf("%v: expected the flag messages to be written or dropped, got %q", tc.args, flagMessages.String())
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// exampleHeaderValue returns a placeholder value for a header in an
+// example response: the header reference's example, unless the code
+// calls for something more specific
+func exampleHeaderValue(code int, name string) string {
+	switch {
+	case code == 416 && name == "Content-Range":
+		return "bytes */1000"
+	case code == 101 && name == "Connection":
+		return "Upgrade"
+	}
+	for _, h := range httpHeaders {
+		if h.Name == name {
+			return h.Example
+		}
+	}
+	return "example"
+}
+
+// exampleResponse builds a raw HTTP response for a code with CRLF line
+// endings; http2 drops the reason phrase and lowercases header names
+func exampleResponse(sc StatusCode, http2 bool) string {
+	short := shortFor(sc.Code)
+	var headers [][2]string
+	for _, name := range responseHeaders(sc.Code) {
+		headers = append(headers, [2]string{name, exampleHeaderValue(sc.Code, name)})
+	}
+
+	body := ""
+	if bodyAllowed(sc.Code) {
+		body = fmt.Sprintf("%d %s\n", sc.Code, short)
+		headers = append(headers,
+			[2]string{"Content-Type", "text/plain; charset=utf-8"},
+			[2]string{"Content-Length", fmt.Sprint(len(body))})
+	}
+
+	var b strings.Builder
+	if http2 {
+		fmt.Fprintf(&b, "HTTP/2 %d\r\n", sc.Code)
+	} else {
+		fmt.Fprintf(&b, "HTTP/1.1 %d %s\r\n", sc.Code, short)
+	}
+	for _, h := range headers {
+		name := h[0]
+		if http2 {
+			name = strings.ToLower(name)
+		}
+		fmt.Fprintf(&b, "%s: %s\r\n", name, h[1])
+	}
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return b.String()
+}
+
+// printExampleResponses writes an example response per code, separated by
+// a blank line
+func printExampleResponses(w io.Writer, codes []StatusCode, http2 bool) {
+	for i, sc := range codes {
+		// A response without a body already ends in a blank line
+		if i > 0 && bodyAllowed(codes[i-1].Code) {
+			fmt.Fprint(w, "\r\n")
+		}
+		fmt.Fprint(w, exampleResponse(sc, http2))
+	}
+}
@@ -0,0 +1,112 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Test example responses parse as HTTP/1.1 with the expected headers and bodies
+func TestExampleResponse(t *testing.T) {
+	testCases := []struct {
+		code    int
+		headers []string
+		body    string
+	}{
+		{200, nil, "200 OK\n"},
+		{204, nil, ""},
+		{304, nil, ""},
+		{301, []string{"Location"}, "301 Moved Permanently\n"},
+		{401, []string{"WWW-Authenticate"}, "401 Unauthorized\n"},
+		{405, []string{"Allow"}, "405 Method Not Allowed\n"},
+		{429, []string{"Retry-After"}, "429 Too Many Requests\n"},
+		{503, []string{"Retry-After"}, "503 Service Unavailable\n"},
+	}
+
+	for _, tc := range testCases {
+		sc, _ := findStatusCode(tc.code)
+		raw := exampleResponse(sc, false)
+		resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(raw)), nil)
+		if err != nil {
+			t.Errorf("%d: invalid response: %v\n%s", tc.code, err, raw)
+			continue
+		}
+		if resp.StatusCode != tc.code || resp.Status != strings.TrimPrefix(strings.SplitN(raw, "\r\n", 2)[0], "HTTP/1.1 ") {
+			t.Errorf("%d: unexpected status %q", tc.code, resp.Status)
+		}
+		for _, name := range tc.headers {
+			if resp.Header.Get(name) == "" {
+				t.Errorf("%d: missing %s header", tc.code, name)
+			}
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != tc.body {
+			t.Errorf("%d: expected body %q, got %q", tc.code, tc.body, body)
+		}
+		if tc.body == "" && resp.Header.Get("Content-Length") != "" {
+			t.Errorf("%d: unexpected Content-Length for a bodyless response", tc.code)
+		}
+	}
+}
+
+// Test the HTTP/2 style omits the reason phrase
+func TestExampleResponseHTTP2(t *testing.T) {
+	sc, _ := findStatusCode(405)
+	raw := exampleResponse(sc, true)
+	if !strings.HasPrefix(raw, "HTTP/2 405\r\nallow: GET, HEAD\r\n") {
+		t.Errorf("Unexpected HTTP/2 style response:\n%q", raw)
+	}
+}
+
+// Test multiple responses are separated by a single blank line
+func TestPrintExampleResponses(t *testing.T) {
+	codes, err := processInputs("200,204,404", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printExampleResponses(&buf, codes, false)
+
+	r := bufio.NewReader(&buf)
+	for _, expected := range []int{200, 204, 404} {
+		resp, err := http.ReadResponse(r, nil)
+		if err != nil {
+			t.Fatalf("Reading response %d: %v", expected, err)
+		}
+		io.ReadAll(resp.Body)
+		if resp.StatusCode != expected {
+			t.Errorf("Expected %d, got %d", expected, resp.StatusCode)
+		}
+		// Skip the separator after a body
+		if line, _ := r.Peek(2); string(line) == "\r\n" {
+			r.Discard(2)
+		}
+	}
+	if rest, _ := io.ReadAll(r); len(rest) != 0 {
+		t.Errorf("Unexpected trailing output: %q", rest)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// exitClassError is the exit status for failures under --exit-with-class,
+// kept clear of the class digits 1-5 that a successful lookup returns
+const exitClassError = 10
+
+// errorExitStatus returns the exit status used for failed lookups
+func errorExitStatus() int {
+	if *exitWithClass {
+		return exitClassError
+	}
+	return 1
+}
+
+// fatal reports the error and exits with the lookup error status
+func fatal(v ...interface{}) {
+	err, ok := v[0].(error)
+	if !ok || len(v) > 1 {
+		err = errors.New(fmt.Sprint(v...))
+	}
+	reportError(err)
+	os.Exit(errorExitStatus())
+}
+
+// fatalf is fatal with a format string
+func fatalf(format string, v ...interface{}) {
+	reportError(fmt.Errorf(format, v...))
+	os.Exit(errorExitStatus())
+}
+
+// classExitStatus returns the class digit of a single looked-up code,
+// e.g. 4 for 404, for use as the exit status
+func classExitStatus(results []StatusCode) (int, error) {
+	if len(results) != 1 {
+		return 0, &cliError{
+			Kind:    errInvalidInput,
+			Message: fmt.Sprintf("--exit-with-class needs exactly one status code, got %d", len(results)),
+		}
+	}
+	return results[0].Code / 100, nil
+}
@@ -0,0 +1,65 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test the class digit is returned for a single code
+func TestClassExitStatus(t *testing.T) {
+	for code, want := range map[int]int{100: 1, 204: 2, 308: 3, 404: 4, 503: 5} {
+		sc, _ := findStatusCode(code)
+		got, err := classExitStatus([]StatusCode{sc})
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %v", code, err)
+		}
+		if got != want {
+			t.Errorf("%d: expected exit status %d, got %d", code, want, got)
+		}
+	}
+}
+
+// Test anything but exactly one code is an input error
+func TestClassExitStatusMultiple(t *testing.T) {
+	for _, results := range [][]StatusCode{nil, statusCodes[:2]} {
+		_, err := classExitStatus(results)
+		if err == nil || !strings.Contains(err.Error(), "exactly one") {
+			t.Errorf("%d codes: expected an exactly-one error, got %v", len(results), err)
+		}
+	}
+}
+
+// Test failures move out of the class digit range under --exit-with-class
+func TestErrorExitStatus(t *testing.T) {
+	defer func(old bool) { *exitWithClass = old }(*exitWithClass)
+
+	*exitWithClass = false
+	if got := errorExitStatus(); got != 1 {
+		t.Errorf("Expected 1 by default, got %d", got)
+	}
+	*exitWithClass = true
+	if got := errorExitStatus(); got >= 1 && got <= 5 {
+		t.Errorf("Error status %d collides with a class digit", got)
+	}
+}
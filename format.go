@@ -0,0 +1,110 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// prettyVariants maps a format to the variant --pretty selects
+var prettyVariants = map[string]string{
+	"json": "json-pretty",
+	"xml":  "xml-pretty",
+	"yaml": "yaml-pretty",
+}
+
+// outputFormatFlags lists the output formats in the order they are
+// printed, with the flag that selects each
+var outputFormatFlags = []struct {
+	name    string
+	enabled *bool
+}{
+	{"json", jsonOutput},
+	{"json-pretty", jsonPretty},
+	{"xml", xmlOutput},
+	{"xml-pretty", xmlPretty},
+	{"xsd", xsdOutput},
+	{"yaml", yamlOutput},
+	{"yaml-pretty", yamlPretty},
+	{"toml", tomlOutput},
+	{"table", tableOutput},
+	{"markdown", markdownOutput},
+	{"markdown-gfm", markdownGFM},
+	{"html", htmlOutput},
+	{"html-full", htmlFull},
+	{"csv", csvOutput},
+	{"parquet", parquetOutput},
+	{"xlsx", xlsxOutput},
+	{"gen-go-test", genGoTest},
+	{"example-response", exampleOutput},
+	{"gen-apache", genApache},
+}
+
+// outputFormatNames returns the names accepted by --format
+func outputFormatNames() []string {
+	names := make([]string, len(outputFormatFlags))
+	for i, f := range outputFormatFlags {
+		names[i] = f.name
+	}
+	return names
+}
+
+// findOutputFormat returns the flag selecting a format
+func findOutputFormat(name string) (*bool, bool) {
+	for _, f := range outputFormatFlags {
+		if f.name == name {
+			return f.enabled, true
+		}
+	}
+	return nil, false
+}
+
+// applyFormatFlag sets the flag of each format named in a comma-separated
+// --format list, so the formats combine with any selected by their own
+// flags: "--format json --xml" prints both. pretty swaps json, xml and
+// yaml for their pretty variants, however they were selected
+func applyFormatFlag(list string, pretty bool) error {
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		enabled, ok := findOutputFormat(name)
+		if !ok {
+			return fmt.Errorf("invalid format: '%s' - must be one of %s", name, strings.Join(outputFormatNames(), ", "))
+		}
+		*enabled = true
+	}
+
+	if pretty {
+		for plain, variant := range prettyVariants {
+			enabled, _ := findOutputFormat(plain)
+			if *enabled {
+				*enabled = false
+				prettyEnabled, _ := findOutputFormat(variant)
+				*prettyEnabled = true
+			}
+		}
+	}
+	return nil
+}
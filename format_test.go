@@ -0,0 +1,79 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// selectedFormats returns the enabled formats and clears them again
+func selectedFormats() []string {
+	var names []string
+	for _, f := range outputFormatFlags {
+		if *f.enabled {
+			names = append(names, f.name)
+			*f.enabled = false
+		}
+	}
+	return names
+}
+
+// Test --format names select formats, merged with their own flags
+func TestApplyFormatFlag(t *testing.T) {
+	defer selectedFormats()
+
+	tests := []struct {
+		list   string
+		flags  []*bool
+		pretty bool
+		want   string
+	}{
+		{"json,csv,markdown", nil, false, "json,markdown,csv"},
+		{" JSON , ,table", nil, false, "json,table"},
+		{"json", []*bool{xmlOutput}, false, "json,xml"},
+		{"json,yaml", nil, true, "json-pretty,yaml-pretty"},
+		{"", []*bool{xmlOutput, csvOutput}, true, "xml-pretty,csv"},
+		{"json,json-pretty", nil, true, "json-pretty"},
+		{"parquet", nil, false, "parquet"},
+	}
+	for _, tt := range tests {
+		for _, f := range tt.flags {
+			*f = true
+		}
+		if err := applyFormatFlag(tt.list, tt.pretty); err != nil {
+			t.Fatalf("%q: %v", tt.list, err)
+		}
+		if got := strings.Join(selectedFormats(), ","); got != tt.want {
+			t.Errorf("%q (pretty %t): expected %s, got %s", tt.list, tt.pretty, tt.want, got)
+		}
+	}
+}
+
+// Test an unknown format lists the supported ones
+func TestApplyFormatFlagUnknown(t *testing.T) {
+	defer selectedFormats()
+	err := applyFormatFlag("json,jsn", false)
+	if err == nil || !strings.Contains(err.Error(), "'jsn'") || !strings.Contains(err.Error(), "json-pretty, xml") {
+		t.Errorf("Expected an error listing the formats, got %v", err)
+	}
+}
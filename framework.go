@@ -0,0 +1,229 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// frameworkMapping describes how a web framework names status codes
+type frameworkMapping struct {
+	// names holds the framework's identifier for each code it knows
+	names map[int]string
+	// construct renders the idiomatic way to respond with a code
+	construct func(code int, name string) string
+}
+
+// springNames are the org.springframework.http.HttpStatus constants
+var springNames = map[int]string{
+	100: "CONTINUE", 101: "SWITCHING_PROTOCOLS", 102: "PROCESSING", 103: "EARLY_HINTS",
+	200: "OK", 201: "CREATED", 202: "ACCEPTED", 203: "NON_AUTHORITATIVE_INFORMATION",
+	204: "NO_CONTENT", 205: "RESET_CONTENT", 206: "PARTIAL_CONTENT", 207: "MULTI_STATUS",
+	208: "ALREADY_REPORTED", 226: "IM_USED",
+	300: "MULTIPLE_CHOICES", 301: "MOVED_PERMANENTLY", 302: "FOUND", 303: "SEE_OTHER",
+	304: "NOT_MODIFIED", 307: "TEMPORARY_REDIRECT", 308: "PERMANENT_REDIRECT",
+	400: "BAD_REQUEST", 401: "UNAUTHORIZED", 402: "PAYMENT_REQUIRED", 403: "FORBIDDEN",
+	404: "NOT_FOUND", 405: "METHOD_NOT_ALLOWED", 406: "NOT_ACCEPTABLE",
+	407: "PROXY_AUTHENTICATION_REQUIRED", 408: "REQUEST_TIMEOUT", 409: "CONFLICT", 410: "GONE",
+	411: "LENGTH_REQUIRED", 412: "PRECONDITION_FAILED", 413: "PAYLOAD_TOO_LARGE",
+	414: "URI_TOO_LONG", 415: "UNSUPPORTED_MEDIA_TYPE", 416: "REQUESTED_RANGE_NOT_SATISFIABLE",
+	417: "EXPECTATION_FAILED", 418: "I_AM_A_TEAPOT", 422: "UNPROCESSABLE_ENTITY", 423: "LOCKED",
+	424: "FAILED_DEPENDENCY", 425: "TOO_EARLY", 426: "UPGRADE_REQUIRED", 428: "PRECONDITION_REQUIRED",
+	429: "TOO_MANY_REQUESTS", 431: "REQUEST_HEADER_FIELDS_TOO_LARGE", 451: "UNAVAILABLE_FOR_LEGAL_REASONS",
+	500: "INTERNAL_SERVER_ERROR", 501: "NOT_IMPLEMENTED", 502: "BAD_GATEWAY", 503: "SERVICE_UNAVAILABLE",
+	504: "GATEWAY_TIMEOUT", 505: "HTTP_VERSION_NOT_SUPPORTED", 506: "VARIANT_ALSO_NEGOTIATES",
+	507: "INSUFFICIENT_STORAGE", 508: "LOOP_DETECTED", 510: "NOT_EXTENDED",
+	511: "NETWORK_AUTHENTICATION_REQUIRED",
+}
+
+// pythonNames are the http.HTTPStatus members used by Django
+var pythonNames = map[int]string{
+	100: "CONTINUE", 101: "SWITCHING_PROTOCOLS", 102: "PROCESSING", 103: "EARLY_HINTS",
+	200: "OK", 201: "CREATED", 202: "ACCEPTED", 203: "NON_AUTHORITATIVE_INFORMATION",
+	204: "NO_CONTENT", 205: "RESET_CONTENT", 206: "PARTIAL_CONTENT", 207: "MULTI_STATUS",
+	208: "ALREADY_REPORTED", 226: "IM_USED",
+	300: "MULTIPLE_CHOICES", 301: "MOVED_PERMANENTLY", 302: "FOUND", 303: "SEE_OTHER",
+	304: "NOT_MODIFIED", 305: "USE_PROXY", 307: "TEMPORARY_REDIRECT", 308: "PERMANENT_REDIRECT",
+	400: "BAD_REQUEST", 401: "UNAUTHORIZED", 402: "PAYMENT_REQUIRED", 403: "FORBIDDEN",
+	404: "NOT_FOUND", 405: "METHOD_NOT_ALLOWED", 406: "NOT_ACCEPTABLE",
+	407: "PROXY_AUTHENTICATION_REQUIRED", 408: "REQUEST_TIMEOUT", 409: "CONFLICT", 410: "GONE",
+	411: "LENGTH_REQUIRED", 412: "PRECONDITION_FAILED", 413: "REQUEST_ENTITY_TOO_LARGE",
+	414: "REQUEST_URI_TOO_LONG", 415: "UNSUPPORTED_MEDIA_TYPE", 416: "REQUESTED_RANGE_NOT_SATISFIABLE",
+	417: "EXPECTATION_FAILED", 418: "IM_A_TEAPOT", 421: "MISDIRECTED_REQUEST", 422: "UNPROCESSABLE_ENTITY",
+	423: "LOCKED", 424: "FAILED_DEPENDENCY", 425: "TOO_EARLY", 426: "UPGRADE_REQUIRED",
+	428: "PRECONDITION_REQUIRED", 429: "TOO_MANY_REQUESTS", 431: "REQUEST_HEADER_FIELDS_TOO_LARGE",
+	451: "UNAVAILABLE_FOR_LEGAL_REASONS",
+	500: "INTERNAL_SERVER_ERROR", 501: "NOT_IMPLEMENTED", 502: "BAD_GATEWAY", 503: "SERVICE_UNAVAILABLE",
+	504: "GATEWAY_TIMEOUT", 505: "HTTP_VERSION_NOT_SUPPORTED", 506: "VARIANT_ALSO_NEGOTIATES",
+	507: "INSUFFICIENT_STORAGE", 508: "LOOP_DETECTED", 510: "NOT_EXTENDED",
+	511: "NETWORK_AUTHENTICATION_REQUIRED",
+}
+
+// djangoResponses are the HttpResponse subclasses Django ships for specific codes
+var djangoResponses = map[int]string{
+	301: "HttpResponsePermanentRedirect(url)",
+	302: "HttpResponseRedirect(url)",
+	304: "HttpResponseNotModified()",
+	400: "HttpResponseBadRequest()",
+	403: "HttpResponseForbidden()",
+	404: "HttpResponseNotFound()",
+	405: "HttpResponseNotAllowed(permitted_methods)",
+	410: "HttpResponseGone()",
+	500: "HttpResponseServerError()",
+}
+
+// rackNames are the Rack status symbols accepted by Rails
+var rackNames = map[int]string{
+	100: "continue", 101: "switching_protocols", 102: "processing", 103: "early_hints",
+	200: "ok", 201: "created", 202: "accepted", 203: "non_authoritative_information",
+	204: "no_content", 205: "reset_content", 206: "partial_content", 207: "multi_status",
+	208: "already_reported", 226: "im_used",
+	300: "multiple_choices", 301: "moved_permanently", 302: "found", 303: "see_other",
+	304: "not_modified", 305: "use_proxy", 307: "temporary_redirect", 308: "permanent_redirect",
+	400: "bad_request", 401: "unauthorized", 402: "payment_required", 403: "forbidden",
+	404: "not_found", 405: "method_not_allowed", 406: "not_acceptable",
+	407: "proxy_authentication_required", 408: "request_timeout", 409: "conflict", 410: "gone",
+	411: "length_required", 412: "precondition_failed", 413: "content_too_large",
+	414: "uri_too_long", 415: "unsupported_media_type", 416: "range_not_satisfiable",
+	417: "expectation_failed", 421: "misdirected_request", 422: "unprocessable_content",
+	423: "locked", 424: "failed_dependency", 425: "too_early", 426: "upgrade_required",
+	428: "precondition_required", 429: "too_many_requests", 431: "request_header_fields_too_large",
+	451: "unavailable_for_legal_reasons",
+	500: "internal_server_error", 501: "not_implemented", 502: "bad_gateway", 503: "service_unavailable",
+	504: "gateway_timeout", 505: "http_version_not_supported", 506: "variant_also_negotiates",
+	507: "insufficient_storage", 508: "loop_detected", 510: "not_extended",
+	511: "network_authentication_required",
+}
+
+// aspnetNames are the Microsoft.AspNetCore.Http.StatusCodes constants
+var aspnetNames = map[int]string{
+	100: "Status100Continue", 101: "Status101SwitchingProtocols", 102: "Status102Processing",
+	103: "Status103EarlyHints",
+	200: "Status200OK", 201: "Status201Created", 202: "Status202Accepted",
+	203: "Status203NonAuthoritative", 204: "Status204NoContent", 205: "Status205ResetContent",
+	206: "Status206PartialContent", 207: "Status207MultiStatus", 208: "Status208AlreadyReported",
+	226: "Status226IMUsed",
+	300: "Status300MultipleChoices", 301: "Status301MovedPermanently", 302: "Status302Found",
+	303: "Status303SeeOther", 304: "Status304NotModified", 305: "Status305UseProxy",
+	306: "Status306SwitchProxy", 307: "Status307TemporaryRedirect", 308: "Status308PermanentRedirect",
+	400: "Status400BadRequest", 401: "Status401Unauthorized", 402: "Status402PaymentRequired",
+	403: "Status403Forbidden", 404: "Status404NotFound", 405: "Status405MethodNotAllowed",
+	406: "Status406NotAcceptable", 407: "Status407ProxyAuthenticationRequired",
+	408: "Status408RequestTimeout", 409: "Status409Conflict", 410: "Status410Gone",
+	411: "Status411LengthRequired", 412: "Status412PreconditionFailed", 413: "Status413PayloadTooLarge",
+	414: "Status414UriTooLong", 415: "Status415UnsupportedMediaType", 416: "Status416RangeNotSatisfiable",
+	417: "Status417ExpectationFailed", 418: "Status418ImATeapot", 421: "Status421MisdirectedRequest",
+	422: "Status422UnprocessableEntity", 423: "Status423Locked", 424: "Status424FailedDependency",
+	426: "Status426UpgradeRequired", 428: "Status428PreconditionRequired",
+	429: "Status429TooManyRequests", 431: "Status431RequestHeaderFieldsTooLarge",
+	451: "Status451UnavailableForLegalReasons", 499: "Status499ClientClosedRequest",
+	500: "Status500InternalServerError", 501: "Status501NotImplemented", 502: "Status502BadGateway",
+	503: "Status503ServiceUnavailable", 504: "Status504GatewayTimeout",
+	505: "Status505HttpVersionNotsupported", 506: "Status506VariantAlsoNegotiates",
+	507: "Status507InsufficientStorage", 508: "Status508LoopDetected", 510: "Status510NotExtended",
+	511: "Status511NetworkAuthenticationRequired",
+}
+
+// aspnetHelpers are the ControllerBase helpers for common codes
+var aspnetHelpers = map[int]string{
+	200: "Ok()", 201: "Created(uri, value)", 202: "Accepted()", 204: "NoContent()",
+	400: "BadRequest()", 401: "Unauthorized()", 403: "Forbid()", 404: "NotFound()",
+	409: "Conflict()", 422: "UnprocessableEntity()",
+}
+
+// frameworks maps each --framework value to its mapping
+var frameworks = map[string]frameworkMapping{
+	"spring": {
+		names: springNames,
+		construct: func(code int, name string) string {
+			if code >= 400 {
+				return "throw new ResponseStatusException(HttpStatus." + name + ")"
+			}
+			return "ResponseEntity.status(HttpStatus." + name + ")"
+		},
+	},
+	"express": {
+		// Express accepts any numeric code, so every code has a mapping
+		construct: func(code int, name string) string {
+			return fmt.Sprintf("res.sendStatus(%d)", code)
+		},
+	},
+	"django": {
+		names: pythonNames,
+		construct: func(code int, name string) string {
+			if resp, ok := djangoResponses[code]; ok {
+				return resp
+			}
+			return "HttpResponse(status=HTTPStatus." + name + ")"
+		},
+	},
+	"rails": {
+		names: rackNames,
+		construct: func(code int, name string) string {
+			return "head :" + name
+		},
+	},
+	"aspnet": {
+		names: aspnetNames,
+		construct: func(code int, name string) string {
+			if helper, ok := aspnetHelpers[code]; ok {
+				return helper
+			}
+			return "StatusCode(StatusCodes." + name + ")"
+		},
+	},
+}
+
+// frameworkNames returns the supported --framework values in sorted order
+func frameworkNames() []string {
+	names := make([]string, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// frameworkConstruct returns the idiomatic construct for a code, or "" when
+// the framework has no name for it
+func frameworkConstruct(framework string, code int) string {
+	m := frameworks[framework]
+	if m.names == nil {
+		return m.construct(code, "")
+	}
+	name, ok := m.names[code]
+	if !ok {
+		return ""
+	}
+	return m.construct(code, name)
+}
+
+// applyFramework sets the framework construct on each code
+func applyFramework(codes []StatusCode, framework string) []StatusCode {
+	out := make([]StatusCode, len(codes))
+	for i, sc := range codes {
+		sc.Framework = strPtr(frameworkConstruct(framework, sc.Code))
+		out[i] = sc
+	}
+	return out
+}
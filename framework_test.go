@@ -0,0 +1,106 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Test the idiomatic construct for each framework
+func TestFrameworkConstruct(t *testing.T) {
+	testCases := []struct {
+		framework string
+		code      int
+		expected  string
+	}{
+		{"spring", 404, "throw new ResponseStatusException(HttpStatus.NOT_FOUND)"},
+		{"spring", 201, "ResponseEntity.status(HttpStatus.CREATED)"},
+		{"spring", 499, ""},
+		{"express", 404, "res.sendStatus(404)"},
+		{"express", 499, "res.sendStatus(499)"},
+		{"django", 404, "HttpResponseNotFound()"},
+		{"django", 429, "HttpResponse(status=HTTPStatus.TOO_MANY_REQUESTS)"},
+		{"django", 420, ""},
+		{"rails", 404, "head :not_found"},
+		{"rails", 422, "head :unprocessable_content"},
+		{"rails", 418, ""},
+		{"aspnet", 404, "NotFound()"},
+		{"aspnet", 503, "StatusCode(StatusCodes.Status503ServiceUnavailable)"},
+		{"aspnet", 425, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := frameworkConstruct(tc.framework, tc.code); got != tc.expected {
+			t.Errorf("%s %d: expected %q, got %q", tc.framework, tc.code, tc.expected, got)
+		}
+	}
+}
+
+// Test every framework maps the common codes
+func TestFrameworkCoverage(t *testing.T) {
+	for _, name := range frameworkNames() {
+		for _, code := range []int{200, 201, 204, 301, 400, 401, 403, 404, 409, 500, 503} {
+			if frameworkConstruct(name, code) == "" {
+				t.Errorf("%s has no mapping for %d", name, code)
+			}
+		}
+	}
+}
+
+// Test the construct appears in structured and text output
+func TestApplyFramework(t *testing.T) {
+	codes, err := processInputs("404,499", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes = applyFramework(prepareOutputs(codes, false, false), "spring")
+
+	var buf bytes.Buffer
+	printJSON(&buf, codes, false)
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[0]["framework"] != "throw new ResponseStatusException(HttpStatus.NOT_FOUND)" {
+		t.Errorf("Unexpected framework field: %v", decoded[0])
+	}
+	if value, ok := decoded[1]["framework"]; !ok || value != "" {
+		t.Errorf("Expected an empty framework field for an unmapped code, got %v", decoded[1])
+	}
+
+	buf.Reset()
+	printText(&buf, codes)
+	if !strings.Contains(buf.String(), "Framework: throw new ResponseStatusException(HttpStatus.NOT_FOUND)\n") {
+		t.Errorf("Expected framework line in text output:\n%s", buf.String())
+	}
+
+	// Without --framework the field is absent
+	plain, _ := processInputs("404", "", nil)
+	buf.Reset()
+	printJSON(&buf, plain, false)
+	if strings.Contains(buf.String(), "framework") {
+		t.Errorf("Expected no framework field by default: %s", buf.String())
+	}
+}
@@ -0,0 +1,129 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+)
+
+// validGoIdentifier reports whether name can be used as a Go identifier
+func validGoIdentifier(name string) bool {
+	return token.IsIdentifier(name)
+}
+
+// bodyAllowed reports whether a response with the code may carry a body,
+// following net/http
+func bodyAllowed(code int) bool {
+	return code >= 200 && code != 204 && code != 304
+}
+
+// generateGoTest builds a gofmt-formatted Go snippet with an httptest
+// handler serving each code and a matching slice of test cases
+func generateGoTest(codes []StatusCode, pkg, prefix string) ([]byte, error) {
+	var b bytes.Buffer
+	handler := prefix + "Handler"
+	cases := prefix + "TestCases"
+
+	fmt.Fprintf(&b, "// Generated by %s --gen-go-test.\n\n", AppName)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintln(&b, `import (`)
+	fmt.Fprintln(&b, `"fmt"`)
+	fmt.Fprintln(&b, `"net/http"`)
+	fmt.Fprintln(&b, `)`)
+
+	fmt.Fprintf(&b, "\n// %s responds with the status code given in the code query parameter,\n", handler)
+	fmt.Fprintf(&b, "// for use with httptest.NewServer(http.HandlerFunc(%s))\n", handler)
+	fmt.Fprintf(&b, "func %s(w http.ResponseWriter, r *http.Request) {\n", handler)
+	fmt.Fprintln(&b, `switch r.URL.Query().Get("code") {`)
+	seen := make(map[int]bool)
+	for _, sc := range codes {
+		if seen[sc.Code] {
+			continue
+		}
+		seen[sc.Code] = true
+		fmt.Fprintf(&b, "case %q:\n", strconv.Itoa(sc.Code))
+		if !bodyAllowed(sc.Code) {
+			fmt.Fprintf(&b, "w.WriteHeader(%d)\n", sc.Code)
+			continue
+		}
+		body, err := json.Marshal(struct {
+			Code   int    `json:"code"`
+			Status string `json:"status"`
+		}{sc.Code, shortFor(sc.Code)})
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(&b, `w.Header().Set("Content-Type", "application/json")`)
+		fmt.Fprintf(&b, "w.WriteHeader(%d)\n", sc.Code)
+		fmt.Fprintf(&b, "fmt.Fprint(w, %s)\n", goStringLiteral(string(body)))
+	}
+	fmt.Fprintln(&b, `default:`)
+	fmt.Fprintln(&b, `http.Error(w, "unknown status code", http.StatusBadRequest)`)
+	fmt.Fprintln(&b, `}`)
+	fmt.Fprintln(&b, `}`)
+
+	fmt.Fprintf(&b, "\n// %s lists the status codes served by %s\n", cases, handler)
+	fmt.Fprintf(&b, "var %s = []struct {\n", cases)
+	fmt.Fprintln(&b, `code int`)
+	fmt.Fprintln(&b, `reason string`)
+	fmt.Fprintln(&b, `description string`)
+	fmt.Fprintln(&b, `}{`)
+	listed := make(map[int]bool)
+	for _, sc := range codes {
+		if listed[sc.Code] {
+			continue
+		}
+		listed[sc.Code] = true
+		long := ""
+		if original, found := findStatusCode(sc.Code); found && original.Long != nil {
+			long = *original.Long
+		}
+		fmt.Fprintf(&b, "{%d, %s, %s},\n", sc.Code, strconv.Quote(shortFor(sc.Code)), strconv.Quote(long))
+	}
+	fmt.Fprintln(&b, `}`)
+
+	return format.Source(b.Bytes())
+}
+
+// goStringLiteral quotes s as a raw string when possible, for readability
+func goStringLiteral(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// printGoTest writes the generated Go test fixture
+func printGoTest(w io.Writer, codes []StatusCode, pkg, prefix string) error {
+	src, err := generateGoTest(codes, pkg, prefix)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
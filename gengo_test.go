@@ -0,0 +1,106 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// Test the generated code parses, is gofmt-clean and declares the expected identifiers
+func TestGenerateGoTest(t *testing.T) {
+	codes, err := processInputs("100,200,204,304,404,503", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generateGoTest(codes, "client", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(formatted, src) {
+		t.Errorf("Generated code is not gofmt-clean:\n%s", src)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "fixture_test.go", src, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("Generated code does not parse: %v\n%s", err, src)
+	}
+	if file.Name.Name != "client" {
+		t.Errorf("Expected package client, got %s", file.Name.Name)
+	}
+	declared := map[string]bool{}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			declared[d.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					declared[vs.Names[0].Name] = true
+				}
+			}
+		}
+	}
+	if !declared["apiHandler"] || !declared["apiTestCases"] {
+		t.Errorf("Expected apiHandler and apiTestCases, got %v", declared)
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"fmt.Fprint(w, `{\"code\":404,\"status\":\"Not Found\"}`)",
+		`{503, "Service Unavailable", "Server temporarily overloaded or down"},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in generated code:\n%s", want, out)
+		}
+	}
+	// Bodies are not allowed for 1xx, 204 and 304
+	for _, code := range []string{"100", "204", "304"} {
+		if !strings.Contains(out, "case \""+code+"\":\n\t\tw.WriteHeader("+code+")\n\tcase") {
+			t.Errorf("Expected bodyless case for %s:\n%s", code, out)
+		}
+	}
+}
+
+// Test identifier validation for the package name and prefix
+func TestValidGoIdentifier(t *testing.T) {
+	for _, name := range []string{"main", "client", "Status", "_x", "api2"} {
+		if !validGoIdentifier(name) {
+			t.Errorf("Expected %q to be valid", name)
+		}
+	}
+	for _, name := range []string{"", "2api", "my-pkg", "func", "a b"} {
+		if validGoIdentifier(name) {
+			t.Errorf("Expected %q to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// grepContexts lists the --context heuristics; "none" flags every code
+var grepContexts = []string{"none", "http"}
+
+// httpContextWords are word prefixes that make a line look like it is
+// talking about an HTTP response
+var httpContextWords = []string{"http", "status", "return", "response", "respond", "code", "error", "request", "get", "post", "put", "patch", "delete", "head"}
+
+// grepMatch is a status code found in the text
+type grepMatch struct {
+	Code  int    `json:"code"`
+	Short string `json:"short"`
+	Lines []int  `json:"lines"`
+}
+
+// runGrep implements "httpstatus grep [flags]", reading text from in
+func runGrep(args []string, in io.Reader, w io.Writer) error {
+	fs := newFlagSet("grep")
+	inline := fs.Bool("inline", false, "Echo the text with the codes on each line appended")
+	context := fs.String("context", "none", "Only flag codes on lines that look like HTTP talk: none or http")
+	ignore := fs.String("ignore", "", "Codes never to flag (comma-separated)")
+	jsonOut := fs.Bool("json", false, "Output the codes found as JSON")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("grep reads text from stdin, e.g. kubectl logs api | httpstatus grep")
+	}
+	if *context != "none" && *context != "http" {
+		return fmt.Errorf("invalid context: '%s' - must be one of %s", *context, strings.Join(grepContexts, ", "))
+	}
+	ignored := map[int]bool{}
+	if *ignore != "" {
+		for _, s := range strings.Split(*ignore, ",") {
+			code, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return fmt.Errorf("invalid code to ignore: '%s' - must be a number", strings.TrimSpace(s))
+			}
+			ignored[code] = true
+		}
+	}
+	if *inline && *jsonOut {
+		return fmt.Errorf("--inline and --json cannot be used together")
+	}
+
+	var matches []*grepMatch
+	byCode := map[int]*grepMatch{}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		var found []StatusCode
+		if *context == "none" || hasHTTPContext(line) {
+			for _, code := range codeTokens(line) {
+				sc, ok := findStatusCode(code)
+				if !ok || ignored[code] {
+					continue
+				}
+				found = append(found, sc)
+				m := byCode[code]
+				if m == nil {
+					m = &grepMatch{Code: code, Short: shortFor(sc.Code)}
+					byCode[code] = m
+					matches = append(matches, m)
+				}
+				if len(m.Lines) == 0 || m.Lines[len(m.Lines)-1] != lineNo {
+					m.Lines = append(m.Lines, lineNo)
+				}
+			}
+		}
+		if *inline {
+			fmt.Fprintln(w, annotateLine(line, found))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// Echoing the text succeeds either way, like a filter should
+	if *inline {
+		return nil
+	}
+	if len(matches) == 0 {
+		return &cliError{Kind: errNotFound, Message: "no status codes found in the input"}
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(matches)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, m := range matches {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", m.Code, m.Short, formatLines(m.Lines))
+	}
+	return tw.Flush()
+}
+
+// codeTokens returns the standalone three-digit numbers in a line, in
+// order. A number glued to letters or digits (8080, v200, 200ms) is not
+// standalone, and neither is one joined to another number by a dot, colon,
+// dash, slash or comma, which rules out IP addresses, versions, times,
+// dates and thousands. A colon directly before the number marks a port.
+func codeTokens(line string) []int {
+	r := []rune(line)
+	var codes []int
+	for i := 0; i < len(r); {
+		if !unicode.IsDigit(r[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(r) && unicode.IsDigit(r[i]) {
+			i++
+		}
+		if i-start != 3 {
+			continue
+		}
+		if start > 0 {
+			prev := r[start-1]
+			if isWordRune(prev) || prev == ':' || (isNumberJoiner(prev) && start > 1 && unicode.IsDigit(r[start-2])) {
+				continue
+			}
+		}
+		if i < len(r) {
+			next := r[i]
+			if isWordRune(next) || (isNumberJoiner(next) && i+1 < len(r) && unicode.IsDigit(r[i+1])) {
+				continue
+			}
+		}
+		code, _ := strconv.Atoi(string(r[start:i]))
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// isWordRune reports whether r continues a word or number
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// isNumberJoiner reports whether r can join two numbers into one value
+func isNumberJoiner(r rune) bool {
+	return strings.ContainsRune(".:-/,", r)
+}
+
+// hasHTTPContext reports whether a line contains a word suggesting it is
+// about HTTP, such as "status", "HTTP" or "returned"
+func hasHTTPContext(line string) bool {
+	words := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for _, prefix := range httpContextWords {
+			if strings.HasPrefix(word, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// annotateLine appends the codes found on a line to it
+func annotateLine(line string, found []StatusCode) string {
+	if len(found) == 0 {
+		return line
+	}
+	var notes []string
+	seen := map[int]bool{}
+	for _, sc := range found {
+		if !seen[sc.Code] {
+			seen[sc.Code] = true
+			notes = append(notes, fmt.Sprintf("%d %s", sc.Code, shortFor(sc.Code)))
+		}
+	}
+	return line + "  # " + strings.Join(notes, ", ")
+}
+
+// formatLines describes the line numbers a code was found on
+func formatLines(lines []int) string {
+	s := make([]string, len(lines))
+	for i, n := range lines {
+		s[i] = strconv.Itoa(n)
+	}
+	if len(lines) == 1 {
+		return "line " + s[0]
+	}
+	return "lines " + strings.Join(s, ", ")
+}
@@ -0,0 +1,149 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// grepFixture runs grep over the incident log fixture
+func grepFixture(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	f, err := os.Open("testdata/grep/incident.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	err = runGrep(args, f, &buf)
+	return buf.String(), err
+}
+
+// Test standalone numbers are found and numbers that are part of ports,
+// addresses, times, versions, units and longer numbers are not
+func TestCodeTokens(t *testing.T) {
+	tests := []struct {
+		line string
+		want []int
+	}{
+		{"HTTP/1.1 404 Not Found", []int{404}},
+		{"status=500, retrying", []int{500}},
+		{"got (503).", []int{503}},
+		{"error 404: not found", []int{404}},
+		{"/api/users/404", []int{404}},
+		{"listening on :8080 and 127.0.0.1", nil},
+		{"host 10.0.200.1", nil},
+		{"at 12:30:45.404", nil},
+		{"localhost:500", nil},
+		{"took 200ms in v200", nil},
+		{"1,500 rows on 2024-10-16", nil},
+		{"version 1.200.3", nil},
+		{"ticket 4040", nil},
+	}
+	for _, tt := range tests {
+		if got := codeTokens(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("codeTokens(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+// Test each code is listed once, in order of first appearance
+func TestGrepSummary(t *testing.T) {
+	out, err := grepFixture(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "502  Bad Gateway          line 2\n" +
+		"404  Not Found            line 4\n" +
+		"503  Service Unavailable  line 7\n" +
+		"301  Moved Permanently    line 8\n"
+	if out != want {
+		t.Errorf("Unexpected summary:\n%s", out)
+	}
+}
+
+// Test the http context heuristic drops lines that don't mention HTTP
+func TestGrepContext(t *testing.T) {
+	out, err := grepFixture(t, "--context", "http", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var matches []grepMatch
+	if err := json.Unmarshal([]byte(out), &matches); err != nil {
+		t.Fatal(err)
+	}
+	want := []grepMatch{{Code: 502, Short: "Bad Gateway", Lines: []int{2}}, {Code: 404, Short: "Not Found", Lines: []int{4}}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("Expected %+v, got %+v", want, matches)
+	}
+
+	if _, err := grepFixture(t, "--context", "fuzzy"); err == nil {
+		t.Error("Expected an invalid context to be rejected")
+	}
+}
+
+// Test ignored codes are not flagged and finding nothing is an error
+func TestGrepIgnore(t *testing.T) {
+	out, err := grepFixture(t, "--ignore", "301, 503")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "301") || strings.Contains(out, "503") {
+		t.Errorf("Expected ignored codes to be dropped:\n%s", out)
+	}
+
+	var buf bytes.Buffer
+	err = runGrep(nil, strings.NewReader("listening on :8080 since 2024\n"), &buf)
+	if e, ok := err.(*cliError); !ok || e.Kind != errNotFound {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// Test inline mode echoes every line and annotates the matching ones
+func TestGrepInline(t *testing.T) {
+	out, err := grepFixture(t, "--inline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile("testdata/grep/incident.log")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	original := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != len(original) {
+		t.Fatalf("Expected %d lines, got %d", len(original), len(lines))
+	}
+	if lines[0] != original[0] {
+		t.Errorf("Expected line 1 to be unchanged, got %q", lines[0])
+	}
+	if lines[3] != original[3]+"  # 404 Not Found" {
+		t.Errorf("Expected a single annotation for 404, got %q", lines[3])
+	}
+
+	var buf bytes.Buffer
+	if err := runGrep([]string{"--inline"}, strings.NewReader("nothing here\n"), &buf); err != nil || buf.String() != "nothing here\n" {
+		t.Errorf("Expected the text to pass through, got %v %q", err, buf.String())
+	}
+}
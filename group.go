@@ -0,0 +1,113 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// codeGroup is the codes of one type, for --group
+type codeGroup struct {
+	Type  string
+	Class int // class digit of the lowest code, e.g. 4
+	Codes []StatusCode
+}
+
+// heading names a group like "4xx Client Error"
+func (g codeGroup) heading() string {
+	return fmt.Sprintf("%dxx %s", g.Class, g.Type)
+}
+
+// groupByType splits codes by their Type; groups are ordered by their
+// lowest code and codes are in numeric order within each, and types
+// with no codes have no group
+func groupByType(codes []StatusCode) []codeGroup {
+	var groups []codeGroup
+	index := make(map[string]int)
+	for _, sc := range codes {
+		i, ok := index[sc.Type]
+		if !ok {
+			i = len(groups)
+			index[sc.Type] = i
+			groups = append(groups, codeGroup{Type: sc.Type})
+		}
+		groups[i].Codes = append(groups[i].Codes, sc)
+	}
+
+	for i := range groups {
+		group := groups[i].Codes
+		sort.SliceStable(group, func(a, b int) bool { return group[a].Code < group[b].Code })
+		groups[i].Class = group[0].Code / 100
+	}
+	sort.SliceStable(groups, func(a, b int) bool { return groups[a].Codes[0].Code < groups[b].Codes[0].Code })
+	return groups
+}
+
+// printMarkdownGrouped outputs a Markdown heading per type, each followed
+// by a table of its codes drawn by table
+func printMarkdownGrouped(w io.Writer, codes []StatusCode, table func(io.Writer, []StatusCode)) {
+	for i, g := range groupByType(codes) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %s\n\n", escapeMarkdownCell(g.heading()))
+		table(w, g.Codes)
+	}
+}
+
+// groupFormats are the formats --group changes; the others ignore it
+var groupFormats = map[string]bool{"text": true, "markdown": true, "markdown-gfm": true}
+
+// warnGroupIgnored warns about each selected format --group doesn't
+// apply to; with none selected the text output is grouped
+func warnGroupIgnored(w io.Writer, formats []string) {
+	for _, name := range formats {
+		if !groupFormats[name] {
+			fmt.Fprintf(w, "warning: --group only applies to text and Markdown, ignoring it for %s output\n", name)
+		}
+	}
+}
+
+// printTextGrouped outputs a "=== 4xx Client Error ===" banner per type,
+// each followed by the text output of its codes indented beneath it
+func printTextGrouped(w io.Writer, codes []StatusCode, opts textOptions) {
+	opts.group = false
+	for i, g := range groupByType(codes) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "=== %s ===\n", g.heading())
+
+		var buf bytes.Buffer
+		printTextOptions(&buf, g.Codes, opts)
+		for _, line := range strings.SplitAfter(buf.String(), "\n") {
+			if strings.TrimSpace(line) != "" {
+				line = "  " + line
+			}
+			io.WriteString(w, line)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Test codes are grouped by type in numeric order
+func TestGroupByType(t *testing.T) {
+	results, err := lookup(lookupQuery{codes: "503,404,200,500,201,418"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := groupByType(results)
+
+	want := []struct {
+		heading string
+		codes   []int
+	}{
+		{"2xx Success", []int{200, 201}},
+		{"4xx Client Error", []int{404, 418}},
+		{"5xx Server Error", []int{500, 503}},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("Expected %d groups, got %+v", len(want), groups)
+	}
+	for i, w := range want {
+		if got := codesOf(groups[i].Codes); groups[i].heading() != w.heading || fmt.Sprint(got) != fmt.Sprint(w.codes) {
+			t.Errorf("Group %d: expected %s %v, got %s %v", i, w.heading, w.codes, groups[i].heading(), got)
+		}
+	}
+}
+
+// Test grouped Markdown has a heading and table per class
+func TestPrintMarkdownGrouped(t *testing.T) {
+	results, err := lookup(lookupQuery{codes: "404,200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results = prepareOutputs(results, false, false)
+	var buf bytes.Buffer
+	printMarkdownTables(&buf, results, true, printMarkdown)
+
+	want := "## 2xx Success\n\n" +
+		"| Code | Type | Short | Long |\n|------|------|-------|------|\n| 200 | Success | OK |  |\n\n" +
+		"## 4xx Client Error\n\n" +
+		"| Code | Type | Short | Long |\n|------|------|-------|------|\n| 404 | Client Error | Not Found |  |\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	printMarkdownTables(&buf, results, false, printMarkdown)
+	if strings.Contains(buf.String(), "##") || strings.Count(buf.String(), "| Code |") != 1 {
+		t.Errorf("Expected a single table without --group, got:\n%s", buf.String())
+	}
+}
+
+// Test grouped text puts a banner over each class's indented codes
+func TestPrintTextGrouped(t *testing.T) {
+	results, err := lookup(lookupQuery{args: []string{"503", "418", "100", "404"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printTextOptions(&buf, prepareOutputs(results, false, false), textOptions{group: true})
+
+	want := `=== 1xx Informational ===
+  Code: 100
+  Type: Informational
+  Short: Continue
+
+=== 4xx Client Error ===
+  Code: 404
+  Type: Client Error
+  Short: Not Found
+
+  ---
+  Code: 418
+  Type: Client Error
+  Short: I'm a teapot
+
+=== 5xx Server Error ===
+  Code: 503
+  Type: Server Error
+  Short: Service Unavailable
+`
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+// Test --group warns for the formats it doesn't change
+func TestWarnGroupIgnored(t *testing.T) {
+	var buf bytes.Buffer
+	warnGroupIgnored(&buf, []string{"markdown", "json", "markdown-gfm", "table"})
+	want := "warning: --group only applies to text and Markdown, ignoring it for json output\n" +
+		"warning: --group only applies to text and Markdown, ignoring it for table output\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	warnGroupIgnored(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warning for grouped text, got %q", buf.String())
+	}
+}
@@ -0,0 +1,274 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// httpHeader describes a common request or response header
+type httpHeader struct {
+	Name        string `json:"name" xml:"name" yaml:"name" toml:"name"`
+	Direction   string `json:"direction" xml:"direction" yaml:"direction" toml:"direction"`
+	Description string `json:"description" xml:"description" yaml:"description" toml:"description"`
+	Example     string `json:"example" xml:"example" yaml:"example" toml:"example"`
+	Statuses    []int  `json:"statuses" xml:"statuses>status" yaml:"statuses,flow" toml:"statuses"`
+
+	// inResponse means a response with one of Statuses carries the
+	// header, as Location does a redirect. Request headers, and those
+	// like Content-Length whose statuses reject the request's own, don't
+	inResponse bool
+}
+
+// httpHeaderCollection wraps headers for XML output
+type httpHeaderCollection struct {
+	XMLName xml.Name     `xml:"http_headers"`
+	Headers []httpHeader `xml:"http_header"`
+}
+
+// httpHeaders are the most common headers, alphabetically. Statuses are
+// the codes a header typically accompanies or causes
+var httpHeaders = []httpHeader{
+	{"Accept", "request", "Media types the client can handle in the response", "text/html, application/json;q=0.9", []int{406}, false},
+	{"Accept-Encoding", "request", "Content codings, such as compression, the client can handle", "gzip, br", []int{406, 415}, false},
+	{"Accept-Language", "request", "Natural languages the client prefers", "en-GB, en;q=0.8", []int{406}, false},
+	{"Accept-Ranges", "response", "Whether the server supports range requests, and in what unit", "bytes", []int{206}, true},
+	{"Access-Control-Allow-Origin", "response", "Which origin may read the response in a cross-origin request", "https://example.com", nil, true},
+	{"Age", "response", "Seconds the response has been in a cache", "3600", nil, true},
+	{"Allow", "response", "Methods the target resource supports", "GET, HEAD", []int{405}, true},
+	{"Authorization", "request", "Credentials authenticating the client to the server", "Bearer eyJhbGciOi...", []int{401, 403}, false},
+	{"Cache-Control", "both", "Caching directives for requests and responses", "max-age=3600, public", []int{304}, true},
+	{"Connection", "both", "Options for the current connection, such as close or upgrade", "keep-alive", []int{101}, true},
+	{"Content-Encoding", "both", "Codings applied to the content, such as compression", "gzip", []int{415}, false},
+	{"Content-Language", "both", "Natural language of the intended audience", "en", nil, false},
+	{"Content-Length", "both", "Size of the content in bytes", "348", []int{411, 413}, false},
+	{"Content-Location", "response", "URI of the representation in the response", "/docs/report.en.html", nil, true},
+	{"Content-Range", "response", "Where a partial body belongs in the full representation", "bytes 0-99/1000", []int{206, 416}, true},
+	{"Content-Type", "both", "Media type of the content", "application/json; charset=utf-8", []int{415}, false},
+	{"Cookie", "request", "Cookies previously set by the server", "session=abc123", nil, false},
+	{"Date", "both", "When the message was originated", "Tue, 15 Oct 2024 08:12:31 GMT", nil, false},
+	{"ETag", "response", "Opaque validator for the current representation", `"33a64df5"`, []int{200, 304, 412}, true},
+	{"Expect", "request", "Behaviour the client expects from the server before sending the content", "100-continue", []int{100, 417}, false},
+	{"Expires", "response", "When the response becomes stale", "Wed, 16 Oct 2024 08:12:31 GMT", nil, true},
+	{"Host", "request", "Host and port of the target URI", "example.com", []int{400, 421}, false},
+	{"If-Match", "request", "Only perform the request if the ETag matches", `"33a64df5"`, []int{412, 428}, false},
+	{"If-Modified-Since", "request", "Only send the representation if it changed after this date", "Tue, 15 Oct 2024 08:12:31 GMT", []int{304}, false},
+	{"If-None-Match", "request", "Only perform the request if no ETag matches", `"33a64df5"`, []int{304, 412}, false},
+	{"If-Range", "request", "Only send the range if the representation is unchanged", `"33a64df5"`, []int{200, 206}, false},
+	{"If-Unmodified-Since", "request", "Only perform the request if unchanged since this date", "Tue, 15 Oct 2024 08:12:31 GMT", []int{412, 428}, false},
+	{"Last-Modified", "response", "When the origin believes the representation last changed", "Tue, 15 Oct 2024 08:12:31 GMT", []int{304}, true},
+	{"Location", "response", "URI of a created resource or redirect target", "https://example.com/new-location", []int{201, 301, 302, 303, 307, 308}, true},
+	{"Origin", "request", "Origin of a cross-origin or POST request", "https://example.com", []int{403}, false},
+	{"Proxy-Authenticate", "response", "Authentication scheme the proxy requires", `Basic realm="proxy"`, []int{407}, true},
+	{"Proxy-Authorization", "request", "Credentials authenticating the client to a proxy", "Basic dXNlcjpwYXNz", []int{407}, false},
+	{"Range", "request", "Request only part of the representation", "bytes=0-99", []int{206, 416}, false},
+	{"RateLimit", "response", "Remaining quota and reset time, from the IETF draft", `"default";r=0;t=120`, []int{429}, true},
+	{"RateLimit-Policy", "response", "Quota policies applied to the client, from the IETF draft", `"default";q=100;w=60`, []int{429}, true},
+	{"Referer", "request", "Address of the page the request came from", "https://example.com/page", nil, false},
+	{"Retry-After", "response", "How long to wait before retrying, in seconds or as a date", "120", []int{413, 429, 503}, true},
+	{"Server", "response", "Software handling the request on the origin", "nginx/1.27.0", nil, true},
+	{"Set-Cookie", "response", "Cookie for the client to store and send back", "session=abc123; Secure; HttpOnly", nil, true},
+	{"Strict-Transport-Security", "response", "Tells browsers to only use HTTPS for the host", "max-age=63072000; includeSubDomains", nil, true},
+	{"Transfer-Encoding", "both", "Codings applied to the message for transfer, such as chunked", "chunked", []int{411, 501}, false},
+	{"Upgrade", "both", "Protocols the sender would like to switch to", "HTTP/2", []int{101, 426}, true},
+	{"User-Agent", "request", "Software making the request", "curl/8.7.1", nil, false},
+	{"Vary", "response", "Request headers that selected this representation", "Accept-Encoding", nil, true},
+	{"Via", "both", "Proxies the message passed through", "1.1 proxy.example.com", nil, false},
+	{"WWW-Authenticate", "response", "Authentication scheme the server requires", `Bearer realm="example"`, []int{401}, true},
+	{"X-RateLimit-Limit", "response", "Requests allowed in the current window, by convention", "100", []int{429}, true},
+	{"X-RateLimit-Remaining", "response", "Requests left in the current window, by convention", "0", []int{429}, true},
+	{"X-RateLimit-Reset", "response", "When the current window resets, by convention", "120", []int{429}, true},
+}
+
+// runHeader implements "httpstatus header [flags] [name|prefix...]"
+func runHeader(args []string, w io.Writer) error {
+	fs := newFlagSet("header")
+	rf := newReferenceFlags(fs, "Search the header names and descriptions")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := rf.validate(); err != nil {
+		return err
+	}
+
+	headers, err := findHeaders(positional)
+	if err != nil {
+		return err
+	}
+	if *rf.search != "" {
+		headers = searchHeaders(headers, *rf.search)
+		if len(headers) == 0 {
+			return &cliError{Kind: errNotFound, Message: "No HTTP headers found matching your criteria", Input: *rf.search}
+		}
+	}
+
+	docs := make([]interface{}, len(headers))
+	rows := make([][]string, len(headers))
+	for i, h := range headers {
+		docs[i] = h
+		rows[i] = []string{h.Name, h.Direction, h.Description, h.Example, joinCodes(h.Statuses, " ")}
+	}
+	rf.render(w, referenceSet{
+		records: headers,
+		xml:     httpHeaderCollection{Headers: headers},
+		docs:    docs,
+		columns: []string{"name", "direction", "description", "example", "statuses"},
+		rows:    rows,
+		text:    func(w io.Writer) { printHeadersText(w, headers) },
+		toml:    func(w io.Writer) { printHeadersTOML(w, headers) },
+	})
+	return nil
+}
+
+// findHeaders resolves names case-insensitively: an exact name gives that
+// header and anything else lists the headers starting with it, so
+// "content-" gives every Content-* header. No names gives every header
+func findHeaders(names []string) ([]httpHeader, error) {
+	if len(names) == 0 {
+		return httpHeaders, nil
+	}
+
+	var headers []httpHeader
+	seen := map[string]bool{}
+	for _, name := range names {
+		matches := matchHeaders(name)
+		if len(matches) == 0 {
+			return nil, &cliError{Kind: errNotFound, Message: fmt.Sprintf("unknown HTTP header: '%s'", name), Input: name}
+		}
+		for _, h := range matches {
+			if !seen[h.Name] {
+				seen[h.Name] = true
+				headers = append(headers, h)
+			}
+		}
+	}
+	return headers, nil
+}
+
+// matchHeaders returns the header named name, or else those starting with it
+func matchHeaders(name string) []httpHeader {
+	var prefixed []httpHeader
+	for _, h := range httpHeaders {
+		if strings.EqualFold(h.Name, name) {
+			return []httpHeader{h}
+		}
+		if len(h.Name) > len(name) && strings.EqualFold(h.Name[:len(name)], name) {
+			prefixed = append(prefixed, h)
+		}
+	}
+	return prefixed
+}
+
+// searchHeaders returns the headers whose name or description contains
+// the term, ignoring case
+func searchHeaders(headers []httpHeader, term string) []httpHeader {
+	lowerTerm := strings.ToLower(term)
+	var results []httpHeader
+	for _, h := range headers {
+		if strings.Contains(strings.ToLower(h.Name), lowerTerm) ||
+			strings.Contains(strings.ToLower(h.Description), lowerTerm) {
+			results = append(results, h)
+		}
+	}
+	return results
+}
+
+// headersFor returns the names of the headers related to a status code
+func headersFor(code int) []string {
+	var names []string
+	for _, h := range httpHeaders {
+		for _, c := range h.Statuses {
+			if c == code {
+				names = append(names, h.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// responseHeaders returns the names of the headers a response with the
+// code carries, for annotating and building example responses
+func responseHeaders(code int) []string {
+	var names []string
+	for _, h := range httpHeaders {
+		if h.inResponse && slices.Contains(h.Statuses, code) {
+			names = append(names, h.Name)
+		}
+	}
+	return names
+}
+
+// joinCodes formats status codes separated by sep
+func joinCodes(codes []int, sep string) string {
+	s := make([]string, len(codes))
+	for i, c := range codes {
+		s[i] = strconv.Itoa(c)
+	}
+	return strings.Join(s, sep)
+}
+
+// printHeadersText outputs one labelled line per field, like printText
+func printHeadersText(w io.Writer, headers []httpHeader) {
+	for i, h := range headers {
+		if i > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprintf(w, "Header: %s\n", h.Name)
+		fmt.Fprintf(w, "Direction: %s\n", h.Direction)
+		fmt.Fprintf(w, "Description: %s\n", h.Description)
+		fmt.Fprintf(w, "Example: %s: %s\n", h.Name, h.Example)
+		if len(h.Statuses) > 0 {
+			statuses := make([]string, len(h.Statuses))
+			for i, code := range h.Statuses {
+				statuses[i] = strconv.Itoa(code)
+				if short := shortFor(code); short != "" {
+					statuses[i] += " " + short
+				}
+			}
+			fmt.Fprintf(w, "Statuses: %s\n", strings.Join(statuses, ", "))
+		}
+	}
+}
+
+// printHeadersTOML outputs a [[header]] table per header, in order
+func printHeadersTOML(w io.Writer, headers []httpHeader) {
+	enc := toml.NewEncoder(w)
+	enc.Indent = ""
+	if err := enc.Encode(struct {
+		Headers []httpHeader `toml:"header"`
+	}{headers}); err != nil {
+		fatalf("TOML error: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Test the header data is sorted, complete and only names known codes
+func TestHTTPHeaders(t *testing.T) {
+	if len(httpHeaders) < 40 {
+		t.Errorf("Expected at least 40 headers, got %d", len(httpHeaders))
+	}
+	for i, h := range httpHeaders {
+		if i > 0 && strings.ToLower(httpHeaders[i-1].Name) >= strings.ToLower(h.Name) {
+			t.Errorf("Headers out of order at %s", h.Name)
+		}
+		if h.Description == "" || h.Example == "" {
+			t.Errorf("Incomplete header %+v", h)
+		}
+		if h.Direction != "request" && h.Direction != "response" && h.Direction != "both" {
+			t.Errorf("Unexpected direction for %s: %s", h.Name, h.Direction)
+		}
+		for _, code := range h.Statuses {
+			if _, found := findStatusCode(code); !found {
+				t.Errorf("%s lists unknown code %d", h.Name, code)
+			}
+		}
+	}
+}
+
+// Test the headers a response carries come from the header reference, so
+// the two stay in step both ways
+func TestHeaderCrossReference(t *testing.T) {
+	for _, sc := range statusCodes {
+		related := headersFor(sc.Code)
+		for _, name := range responseHeaders(sc.Code) {
+			if !slices.Contains(related, name) {
+				t.Errorf("%s is in a %d response but its entry doesn't list it", name, sc.Code)
+			}
+		}
+	}
+	for code, want := range map[int][]string{
+		101: {"Connection", "Upgrade"},
+		301: {"Location"},
+		411: nil,
+		413: {"Retry-After"},
+		415: nil,
+		426: {"Upgrade"},
+	} {
+		if got := responseHeaders(code); !slices.Equal(got, want) {
+			t.Errorf("Expected a %d response to carry %v, got %v", code, want, got)
+		}
+	}
+
+	sc, _ := findStatusCode(429)
+	var buf bytes.Buffer
+	printTextOptions(&buf, []StatusCode{sc}, textOptions{related: true})
+	if !strings.Contains(buf.String(), "Headers: RateLimit, RateLimit-Policy, Retry-After,") {
+		t.Errorf("Expected the related headers in text output:\n%s", buf.String())
+	}
+}
+
+// Test lookups ignore case and fall back to a prefix
+func TestFindHeaders(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  []string
+	}{
+		{[]string{"retry-after"}, []string{"Retry-After"}},
+		{[]string{"ETAG"}, []string{"ETag"}},
+		{[]string{"content-"}, []string{"Content-Encoding", "Content-Language", "Content-Length", "Content-Location", "Content-Range", "Content-Type"}},
+		{[]string{"if-match", "If-"}, []string{"If-Match", "If-Modified-Since", "If-None-Match", "If-Range", "If-Unmodified-Since"}},
+	}
+	for _, tt := range tests {
+		headers, err := findHeaders(tt.names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, h := range headers {
+			got = append(got, h.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("findHeaders(%v) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+
+	if _, err := findHeaders([]string{"X-Nope"}); err == nil {
+		t.Error("Expected an unknown header to fail")
+	}
+}
+
+// Test the header subcommand renders JSON and text
+func TestRunHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runHeader([]string{"Retry-After", "--json"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var headers []httpHeader
+	if err := json.Unmarshal(buf.Bytes(), &headers); err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 1 || headers[0].Name != "Retry-After" || len(headers[0].Statuses) != 3 {
+		t.Errorf("Unexpected headers: %+v", headers)
+	}
+
+	buf.Reset()
+	if err := runHeader([]string{"--search", "proxy", "--csv"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 3 || lines[0] != "name,direction,description,example,statuses" {
+		t.Errorf("Unexpected search result:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runHeader([]string{"allow"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "Header: Allow\nDirection: response\nDescription: Methods the target resource supports\nExample: Allow: GET, HEAD\nStatuses: 405 Method Not Allowed\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected text output:\n%s", buf.String())
+	}
+}
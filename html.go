@@ -0,0 +1,122 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlTable renders the rows shared by --html and --html-full; each row
+// carries a class named after the status type, e.g. client-error
+const htmlTable = `<table class="httpstatus">
+<thead>
+<tr>{{range .Fields}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr class="{{.Class}}">{{range .Cells}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+`
+
+// htmlPage wraps the table in a standalone page with inline CSS
+const htmlPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>HTTP Status Codes</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #ddd; vertical-align: top; }
+th { background: #f4f4f4; }
+td:first-child { font-family: monospace; font-weight: bold; }
+tr.informational td:first-child { color: #0366d6; }
+tr.success td:first-child { color: #22863a; }
+tr.redirection td:first-child { color: #6f42c1; }
+tr.client-error td:first-child { color: #b08800; }
+tr.server-error td:first-child { color: #cb2431; }
+</style>
+</head>
+<body>
+<h1>HTTP Status Codes</h1>
+{{template "table" .}}</body>
+</html>
+`
+
+var (
+	htmlTableTemplate = template.Must(template.New("table").Parse(htmlTable))
+	htmlPageTemplate  = template.Must(template.Must(htmlTableTemplate.Clone()).New("page").Parse(htmlPage))
+)
+
+// htmlRow is one table row and its CSS class
+type htmlRow struct {
+	Class string
+	Cells []string
+}
+
+// htmlClass derives a CSS class from a status type: lowercase, with runs
+// of anything but letters and digits turned into a hyphen
+func htmlClass(statusType string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(statusType) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+// printHTML outputs an HTML table with a column per field, or a whole
+// page around it when full is set; cell contents are escaped
+func printHTML(w io.Writer, codes []StatusCode, fields []metadataField, full bool) error {
+	data := struct {
+		Fields []string
+		Rows   []htmlRow
+	}{}
+	for _, f := range fields {
+		data.Fields = append(data.Fields, f.label)
+	}
+	for _, sc := range codes {
+		row := htmlRow{Class: htmlClass(sc.Type)}
+		for _, f := range fields {
+			value, _ := f.value(sc)
+			row.Cells = append(row.Cells, value)
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	if full {
+		return htmlPageTemplate.Execute(w, data)
+	}
+	return htmlTableTemplate.Execute(w, data)
+}
@@ -0,0 +1,79 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test the HTML table escapes cells and classes rows by type
+func TestPrintHTML(t *testing.T) {
+	short, long := "Bad <Gateway>", `Say "hi" & <b>bye</b>`
+	codes := []StatusCode{
+		{Code: 418, Type: "Client Error", Short: strPtr("I'm a teapot")},
+		{Code: 502, Type: "Server Error", Short: &short, Long: &long},
+	}
+
+	var buf bytes.Buffer
+	if err := printHTML(&buf, codes, baseFields, false); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<thead>\n<tr><th>Code</th><th>Type</th><th>Short</th><th>Long</th></tr>\n</thead>",
+		`<tr class="client-error"><td>418</td><td>Client Error</td><td>I&#39;m a teapot</td><td></td></tr>`,
+		`<td>Bad &lt;Gateway&gt;</td><td>Say &#34;hi&#34; &amp; &lt;b&gt;bye&lt;/b&gt;</td>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<html") {
+		t.Error("Expected only a table without --html-full")
+	}
+
+	buf.Reset()
+	if err := printHTML(&buf, codes, baseFields, true); err != nil {
+		t.Fatal(err)
+	}
+	page := buf.String()
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") || !strings.Contains(page, "<style>") || !strings.Contains(page, `<tr class="server-error">`) {
+		t.Errorf("Expected a standalone page, got:\n%s", page)
+	}
+}
+
+// Test CSS classes derived from status types
+func TestHTMLClass(t *testing.T) {
+	tests := map[string]string{
+		"Client Error":  "client-error",
+		"Success":       "success",
+		"Server  Error": "server-error",
+		" Weird/Type! ": "weird-type",
+	}
+	for in, want := range tests {
+		if got := htmlClass(in); got != want {
+			t.Errorf("htmlClass(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -22,18 +22,21 @@ https://github.com/yodanator/httpstatus
 package main
 
 import (
-	"encoding/csv"
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
@@ -42,12 +45,27 @@ func strPtr(s string) *string {
 	return &s
 }
 
+// strValue returns the string p points to, or "" for nil; custom codes
+// may leave out short and long
+func strValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
 // StatusCode represents an HTTP status code with metadata
 type StatusCode struct {
-	Code  int     `json:"code" xml:"code" yaml:"code"`
-	Type  string  `json:"type" xml:"type" yaml:"type"`
-	Short *string `json:"short,omitempty" xml:"short,omitempty" yaml:"short,omitempty"`
-	Long  *string `json:"long,omitempty" xml:"long,omitempty" yaml:"long,omitempty"`
+	Code  int     `json:"code" xml:"code" yaml:"code" toml:"code"`
+	Type  string  `json:"type" xml:"type" yaml:"type" toml:"type"`
+	Short *string `json:"short,omitempty" xml:"short,omitempty" yaml:"short,omitempty" toml:"short,omitempty"`
+	Long  *string `json:"long,omitempty" xml:"long,omitempty" yaml:"long,omitempty" toml:"long,omitempty"`
+
+	// Framework is the idiomatic construct for the code, set by --framework
+	Framework *string `json:"framework,omitempty" xml:"framework,omitempty" yaml:"framework,omitempty" toml:"framework,omitempty"`
+
+	// Registration is the IANA registration status, set by --full-metadata
+	Registration *string `json:"registration,omitempty" xml:"registration,omitempty" yaml:"registration,omitempty" toml:"registration,omitempty"`
 }
 
 // HTTPStatusCollection wraps status codes for XML output
@@ -56,6 +74,33 @@ type HTTPStatusCollection struct {
 	Codes   []StatusCode `xml:"http_status"`
 }
 
+// httpStatusElement is the root element of a --single XML document
+type httpStatusElement struct {
+	XMLName xml.Name `xml:"http_status"`
+	StatusCode
+}
+
+// outputPayload returns what JSON and YAML output encodes: the codes, the
+// --with-meta envelope, or with --single the only code as a bare object
+func outputPayload(codes []StatusCode, single bool, meta *metaEnvelope) any {
+	if meta != nil {
+		return meta
+	}
+	if single && len(codes) == 1 {
+		return codes[0]
+	}
+	return codes
+}
+
+// xmlPayload returns the XML document root: the collection, or with
+// --single the only code as an http_status element
+func xmlPayload(codes []StatusCode, single bool) any {
+	if single && len(codes) == 1 {
+		return httpStatusElement{StatusCode: codes[0]}
+	}
+	return HTTPStatusCollection{Codes: codes}
+}
+
 // Application variables (set at build time)
 var (
 	AppName    = "httpstatus"
@@ -146,32 +191,211 @@ var statusCodes = []StatusCode{
 // Package-level variables for flags
 var (
 	codeFlag       = flag.String("c", "", "HTTP status code(s) (comma-separated) (either this, search, or none for all codes)")
-	searchFlag     = flag.String("search", "", "Search for HTTP status codes by keyword in short or long description")
+	searchAll      = flag.String("search-all", "", "Only codes whose descriptions contain every comma-separated term")
 	longFlag       = flag.Bool("l", false, "Output long description")
 	allFlag        = flag.Bool("a", false, "Output both short and long descriptions")
+	fullMetadata   = flag.Bool("full-metadata", false, "Output every available field")
 	jsonOutput     = flag.Bool("json", false, "Output as JSON (raw)")
 	jsonPretty     = flag.Bool("json-pretty", false, "Output as pretty JSON")
 	xmlOutput      = flag.Bool("xml", false, "Output as XML (raw)")
 	xmlPretty      = flag.Bool("xml-pretty", false, "Output as pretty XML")
+	xsdOutput      = flag.Bool("xsd", false, "Output the XML Schema for the XML output")
 	yamlOutput     = flag.Bool("yaml", false, "Output as YAML (raw)")
 	yamlPretty     = flag.Bool("yaml-pretty", false, "Output as pretty YAML")
+	yamlList       = flag.Bool("yaml-list", false, "Output --yaml-pretty as a single sequence too, instead of one document per code")
 	tomlOutput     = flag.Bool("toml", false, "Output as TOML")
 	tableOutput    = flag.Bool("table", false, "Output as text table")
+	formatFlag     = flag.String("format", "", "Output formats (comma-separated), e.g. json,csv")
+	prettyFlag     = flag.Bool("pretty", false, "Use the pretty variant of json, xml and yaml")
+	tableStyle     = flag.String("table-style", "compact", "Table style: plain, ascii, unicode, compact or github")
+	widthFlag      = flag.Int("width", 0, "Fit tables to this many columns instead of the terminal width")
+	fullFlag       = flag.Bool("full", false, "Wrap long table cells instead of truncating them")
+	iconsFlag      = flag.Bool("icons", false, "Mark each code in text and table output with a class icon")
+	asciiFlag      = flag.Bool("ascii", false, "Use ASCII icons such as [OK] with --icons")
+	relatedFlag    = flag.Bool("related", false, "Also list related headers and references in text output")
+	fromCurl       = flag.Bool("from-curl", false, "Describe the responses in curl -i or -v output read from stdin")
+	protocolFlag   = flag.String("protocol", "http", "Code space to look up: http status codes, or h2/h3 error codes")
+	frameworkFlag  = flag.String("framework", "", "Show how to respond with each code in spring, express, django, rails or aspnet")
 	markdownOutput = flag.Bool("markdown", false, "Output as Markdown table")
+	markdownGFM    = flag.Bool("markdown-gfm", false, "Output as a GitHub Markdown table with inline codes and #status-<code> anchors")
+	groupFlag      = flag.Bool("group", false, "Group text and Markdown output under a heading per class")
+	htmlOutput     = flag.Bool("html", false, "Output as an HTML table")
+	htmlFull       = flag.Bool("html-full", false, "Output as a standalone HTML page")
+	genGoTest      = flag.Bool("gen-go-test", false, "Output a Go httptest handler and test cases")
+	goPackage      = flag.String("go-package", "main", "Package name for --gen-go-test")
+	goVarPrefix    = flag.String("go-var-prefix", "status", "Prefix for the identifiers generated by --gen-go-test")
+	exampleOutput  = flag.Bool("example-response", false, "Output an example raw HTTP response for each code")
+	http2Style     = flag.Bool("http2-style", false, "Use an HTTP/2 status line without a reason phrase in --example-response")
+	genApache      = flag.Bool("gen-apache", false, "Output Apache ErrorDocument directives for the codes")
+	inlineMessage  = flag.Bool("inline-message", false, "Use the short description as the --gen-apache message instead of a path")
 	csvOutput      = flag.Bool("csv", false, "Output as CSV")
+	quoteFlag      = flag.String("quote", "minimal", "CSV quoting: minimal, all or nonnumeric")
+	excelHint      = flag.Bool("excel-hint", false, "Start CSV output with a sep=, line for Excel")
+	bomFlag        = flag.Bool("bom", false, "Start CSV output with a UTF-8 byte order mark for Excel")
+	delimiterFlag  = flag.String("delimiter", ",", "CSV field separator, a single character such as ; or tab")
+	parquetOutput  = flag.Bool("parquet", false, "Output as Parquet (requires --to-file)")
+	xlsxOutput     = flag.Bool("xlsx", false, "Output as an Excel workbook (requires --to-file)")
+	parquetCodec   = flag.String("parquet-compression", "snappy", "Parquet compression: snappy, zstd or none")
 	toFileBase     = flag.String("to-file", "", "Save output to files with base name (automatic extensions)")
+	pickFlag       = flag.Bool("pick", false, "Interactively choose which of the matched codes to output")
+	copyFlag       = flag.Bool("copy", false, "Also copy the output to the system clipboard")
+	copyOnly       = flag.Bool("copy-only", false, "Copy the output to the system clipboard instead of printing it")
+	errorsFlag     = flag.Bool("errors", false, "Only 4xx and 5xx codes")
+	clientErrors   = flag.Bool("client-errors", false, "Only 4xx codes")
+	serverErrors   = flag.Bool("server-errors", false, "Only 5xx codes")
+	successFlag    = flag.Bool("success", false, "Only 2xx codes")
+	redirectsFlag  = flag.Bool("redirects", false, "Only 3xx codes")
+	informational  = flag.Bool("informational", false, "Only 1xx codes")
+	preserveOrder  = flag.Bool("preserve-input-order", false, "Output codes in the order they were given on the command line")
+	duplicatesFlag = flag.Bool("allow-duplicates", false, "Output a code once for every input that matches it")
+	schemaFlag     = flag.String("schema", "", "Print a schema of the output: a JSON Schema as json or yaml, or an XSD as xml")
+	withMeta       = flag.Bool("with-meta", false, "Wrap JSON and YAML output in an envelope with the count, query, time and version")
+	singleFlag     = flag.Bool("single", false, "Output the only matching code as a bare JSON, YAML or XML object instead of a list")
+	countFlag      = flag.Bool("count", false, "Print only the number of matching codes")
+	shortOnly      = flag.Bool("short-only", false, "Print only the reason phrase of each code, one per line")
+	fallbackCode   = flag.Bool("fallback-code", false, "With --short-only, print the code itself when it has no reason phrase")
+	codesOnly      = flag.Bool("codes-only", false, "Print only the code numbers, one per line, or a JSON array with --json")
+	oneLine        = flag.Bool("one-line", false, "With --codes-only, print the codes on one line separated by spaces")
+	exitWithClass  = flag.Bool("exit-with-class", false, "Exit with the class digit of the single code looked up, e.g. 4 for 404")
+	registration   = flag.String("registration", "", "Only codes with this IANA registration: permanent, provisional or unofficial")
+	showGaps       = flag.Bool("show-gaps", false, "Also list the unassigned code points in the classes or prefixes looked up")
+	dataFile       = flag.String("data", "", "Data file to use instead of the built-in codes")
+	mergeStrategy  = flag.String("merge-strategy", "", "How codes defined twice resolve: override, error or keep-builtin (default override)")
+	showOverrides  = flag.Bool("show-overrides", false, "List the codes defined by data files rather than the built-in dataset")
+	sortFlag       = flag.String("sort", "", "Sort the results by code, type or short")
+	reverseFlag    = flag.Bool("reverse", false, "Reverse the order of the results")
+	limitFlag      = flag.Int("limit", 0, "Output at most this many codes, 0 for all")
+	offsetFlag     = flag.Int("offset", 0, "Skip this many codes before output, for paging with --limit")
+	colorFlag      = flag.String("color", "auto", "Colour output: auto, always or never (NO_COLOR is honoured by auto)")
 	helpFlag       = flag.Bool("help", false, "Show help information")
 	versionFlag    = flag.Bool("version", false, "Show version information")
 )
 
 func main() {
+	// Output cut short by a closed pipe ends the program quietly
+	ignoreSIGPIPE()
+	stdout := quitOnBrokenPipe(os.Stdout)
+
+	// --error-format applies everywhere, so it may come before a subcommand
+	cliArgs, err := takeErrorFormat(os.Args[1:])
+	if err != nil {
+		fatal(err)
+	}
+
+	// Subcommands take over the command line before the lookup flags are parsed
+	if len(cliArgs) > 0 {
+		// custom and diff read data files themselves, so a broken custom
+		// file cannot stop them from diagnosing it
+		switch cliArgs[0] {
+		case "monitor", "serve", "enrich", "quiz", "suggest", "troubleshoot", "grep":
+			if _, err := loadData(dataOptions{}); err != nil {
+				fatal(err)
+			}
+		}
+
+		switch cliArgs[0] {
+		case "monitor":
+			if err := runMonitor(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "serve":
+			if err := runServe(cliArgs[1:]); err != nil {
+				fatal(err)
+			}
+			return
+		case "enrich":
+			if err := runEnrich(cliArgs[1:], os.Stdin, stdout, os.Stderr); err != nil {
+				fatal(err)
+			}
+			return
+		case "quiz":
+			if err := runQuiz(cliArgs[1:], os.Stdin, stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "suggest":
+			if err := runSuggest(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "troubleshoot":
+			if err := runTroubleshoot(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "methods":
+			if err := runMethods(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "header":
+			if err := runHeader(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "env":
+			if err := runEnv(cliArgs[1:], stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "grep":
+			if err := runGrep(cliArgs[1:], os.Stdin, stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "custom":
+			if err := runCustom(cliArgs[1:], os.Stdin, stdout); err != nil {
+				fatal(err)
+			}
+			return
+		case "diff":
+			// Like diff(1): 1 means the datasets differ, 2 means trouble
+			differ, err := runDiff(cliArgs[1:], stdout)
+			if err != nil {
+				reportError(err)
+				os.Exit(2)
+			}
+			if differ {
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Aliases for flags
 	flag.StringVar(codeFlag, "code", "", "HTTP status code(s) (comma-separated) (either this, search, or none for all codes)")
-	flag.StringVar(searchFlag, "s", "", "Search for HTTP status codes by keyword (shorthand)")
+	var searchFlags stringList
+	flag.Var(&searchFlags, "search", "Search for HTTP status codes by keyword in short or long description (repeat to require every term)")
+	flag.Var(&searchFlags, "s", "Search for HTTP status codes by keyword (shorthand)")
 	flag.BoolVar(longFlag, "long", false, "Output long description")
 	flag.BoolVar(allFlag, "all", false, "Output both short and long descriptions")
-
-	flag.Parse()
+	flag.StringVar(formatFlag, "o", "", "Output formats (comma-separated) (shorthand)")
+
+	var customFiles, codesFiles, excludeFlags, typeFlags, searchNot stringList
+	var porcelain porcelainFlag
+	flag.Var(&searchNot, "search-not", "Leave out codes whose description contains this keyword (repeatable)")
+	flag.Var(&typeFlags, "type", "Only codes of this type, e.g. \"Client Error\" or redirection (comma-separated, repeatable)")
+	flag.Var(&excludeFlags, "exclude", "Codes, prefixes or ranges to leave out (comma-separated, repeatable)")
+	flag.Var(&excludeFlags, "x", "Codes, prefixes or ranges to leave out (shorthand)")
+	flag.Var(&porcelain, "porcelain", "Print a stable tab-separated line per code for scripts; --porcelain=v1 pins the version")
+	flag.Var(&codesFiles, "codes-file", "File listing codes to look up like -c, - for stdin (repeatable)")
+	flag.Var(&customFiles, "custom", "Data file of codes to add or replace (repeatable, applied in order)")
+
+	// Report bad flags like any other error rather than exiting with status 2
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	flag.CommandLine.SetOutput(flagOutput{})
+	flag.Var(errorFormatFlag{}, "error-format", "Write errors to stderr as text or a json object")
+
+	// Allow flags after the status code, e.g. "httpstatus 4 --json"
+	args, ordered, codeAt, err := parseLookupArgs(flag.CommandLine, cliArgs, codeFlag)
+	if errors.Is(err, flag.ErrHelp) {
+		// The flag package has already printed the usage
+		os.Exit(0)
+	}
+	if err != nil {
+		fatal(err)
+	}
 
 	// Handle help flag
 	if *helpFlag {
@@ -186,35 +410,375 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *mergeStrategy != "" && !validMergeStrategy(*mergeStrategy) {
+		fatalf("invalid merge strategy: '%s' - must be one of %s", *mergeStrategy, strings.Join(mergeStrategies, ", "))
+	}
+	origins, err := loadData(dataOptions{data: *dataFile, custom: customFiles, strategy: *mergeStrategy})
+	if err != nil {
+		fatal(err)
+	}
+	if *showOverrides {
+		printOverrides(stdout, origins)
+		return
+	}
+
+	// The schema lists the types of the loaded data
+	if *schemaFlag != "" {
+		if err := writeSchema(*schemaFlag, *toFileBase); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	// Annotate curl output instead of looking up codes
+	if *fromCurl {
+		if err := annotateCurl(os.Stdin, stdout); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	// --format selects formats through their own flags, so the checks
+	// below see them either way
+	if err := applyFormatFlag(*formatFlag, *prettyFlag); err != nil {
+		fatal(err)
+	}
+
+	if !validTableStyle(*tableStyle) {
+		fatalf("invalid table style: '%s' - must be one of %s", *tableStyle, strings.Join(tableStyles, ", "))
+	}
+
+	if *widthFlag < 0 {
+		fatalf("invalid width: '%d' - must be zero or positive", *widthFlag)
+	}
+
+	if _, ok := frameworks[*frameworkFlag]; *frameworkFlag != "" && !ok {
+		fatalf("invalid framework: '%s' - must be one of %s", *frameworkFlag, strings.Join(frameworkNames(), ", "))
+	}
+
+	if !validGoIdentifier(*goPackage) || *goPackage == "_" {
+		fatalf("invalid Go package name: '%s' - must be a Go identifier", *goPackage)
+	}
+	if !validGoIdentifier(*goVarPrefix) {
+		fatalf("invalid Go variable prefix: '%s' - must be a Go identifier", *goVarPrefix)
+	}
+
+	if *http2Style && !*exampleOutput {
+		fatal("--http2-style requires --example-response")
+	}
+	if *inlineMessage && !*genApache {
+		fatal("--inline-message requires --gen-apache")
+	}
+	if !validCSVQuote(*quoteFlag) {
+		fatalf("invalid quote mode: '%s' - must be one of %s", *quoteFlag, strings.Join(csvQuoteModes, ", "))
+	}
+	delimiter, err := parseDelimiter(*delimiterFlag)
+	if err != nil {
+		fatal(err)
+	}
+	if *parquetOutput && *toFileBase == "" {
+		fatal("--parquet writes binary output and requires --to-file")
+	}
+	if *xlsxOutput && *toFileBase == "" {
+		fatal("--xlsx writes binary output and requires --to-file, e.g. --xlsx --to-file codes")
+	}
+	if _, ok := parquetCodecs[*parquetCodec]; !ok {
+		fatalf("invalid parquet compression: '%s' - must be one of %s", *parquetCodec, strings.Join(parquetCodecNames(), ", "))
+	}
+
+	if *countFlag {
+		for _, f := range outputFormatFlags {
+			if *f.enabled && f.name != "json" {
+				fatalf("--count cannot be used with --%s, only with --json", f.name)
+			}
+		}
+		if *duplicatesFlag || *pickFlag || *exitWithClass {
+			fatal("--count cannot be used with --allow-duplicates, --pick or --exit-with-class")
+		}
+	}
+
+	if *fallbackCode && !*shortOnly {
+		fatal("--fallback-code requires --short-only")
+	}
+	if *shortOnly && (*countFlag || *pickFlag) {
+		fatal("--short-only cannot be used with --count or --pick")
+	}
+	if porcelain.err != nil {
+		fatal(porcelain.err)
+	}
+	if porcelain.version != "" {
+		for _, f := range outputFormatFlags {
+			if *f.enabled {
+				fatalf("--porcelain cannot be used with --%s", f.name)
+			}
+		}
+		if *shortOnly || *codesOnly || *countFlag || *pickFlag {
+			fatal("--porcelain cannot be used with --short-only, --codes-only, --count or --pick")
+		}
+	}
+	if *oneLine && !*codesOnly {
+		fatal("--one-line requires --codes-only")
+	}
+	if *codesOnly {
+		for _, f := range outputFormatFlags {
+			if *f.enabled && f.name != "json" && f.name != "json-pretty" {
+				fatalf("--codes-only cannot be used with --%s, only with --json or --json-pretty", f.name)
+			}
+		}
+		if *oneLine && (*jsonOutput || *jsonPretty) {
+			fatal("--one-line cannot be used with --json or --json-pretty")
+		}
+		if *shortOnly || *countFlag || *pickFlag {
+			fatal("--codes-only cannot be used with --short-only, --count or --pick")
+		}
+	}
+	if *asciiFlag && !*iconsFlag {
+		fatal("--ascii requires --icons")
+	}
+	if *singleFlag && *withMeta {
+		fatal("--single cannot be used with --with-meta")
+	}
+	if *singleFlag && *xsdOutput {
+		fatal("--single cannot be used with --xsd, which describes the list")
+	}
+
+	if *registration != "" && !validRegistration(*registration) {
+		fatalf("invalid registration: '%s' - must be one of %s", *registration, strings.Join(registrations, ", "))
+	}
+
+	dataset, isProtocol := protocolDatasets[*protocolFlag]
+	if !isProtocol && *protocolFlag != "http" {
+		fatalf("invalid protocol: '%s' - must be one of %s", *protocolFlag, strings.Join(protocolNames(), ", "))
+	}
+
 	// Process inputs
-	results, err := processInputs(*codeFlag, *searchFlag, flag.Args())
+	query := lookupQuery{
+		codes:           *codeFlag,
+		searchAll:       append(searchFlags, splitTerms(*searchAll)...),
+		args:            args,
+		classes:         shortcutClasses(),
+		allowDuplicates: *duplicatesFlag,
+		exclude:         excludeFlags,
+		searchNot:       searchNot,
+		types:           typeFlags,
+	}
+	if *preserveOrder && *sortFlag != "" {
+		fatal("--sort cannot be used with --preserve-input-order")
+	}
+	if *preserveOrder {
+		// Treat -c values as if given in place among the arguments
+		query.codes, query.args, query.codeArgs = "", ordered, codeAt
+	}
+	if len(codesFiles) > 0 {
+		if *fromCurl {
+			fatal("--codes-file cannot be used with --from-curl")
+		}
+		tokens, err := readCodesFiles(codesFiles, os.Stdin)
+		if err != nil {
+			fatal(err)
+		}
+		// An empty list is an empty lookup, not a request for every code
+		if len(tokens) == 0 && query.codes == "" && len(query.args) == 0 && len(query.searchTerms()) == 0 && len(query.classes) == 0 {
+			if *countFlag {
+				if err := printCount(stdout, 0, *jsonOutput); err != nil {
+					fatal(err)
+				}
+				return
+			}
+			fatal(&cliError{Kind: errNotFound, Message: "No HTTP status codes found matching your criteria", Input: strings.Join(codesFiles, ",")})
+		}
+		if *preserveOrder {
+			// Listed codes are parsed like -c values
+			for _, token := range tokens {
+				query.codeArgs = append(query.codeArgs, len(query.args))
+				query.args = append(query.args, token)
+			}
+		} else if len(tokens) > 0 {
+			query.codes = strings.Trim(query.codes+","+strings.Join(tokens, ","), ",")
+		}
+	}
+	var results []StatusCode
+	if isProtocol {
+		if len(query.classes) > 0 {
+			fatal("class filters such as --errors cannot be used with --protocol")
+		}
+		if *exitWithClass {
+			fatal("--exit-with-class cannot be used with --protocol")
+		}
+		if *registration != "" || *showGaps {
+			fatal("--registration and --show-gaps cannot be used with --protocol")
+		}
+		if len(excludeFlags) > 0 || len(typeFlags) > 0 || len(searchNot) > 0 {
+			fatal("--exclude, --type and --search-not cannot be used with --protocol")
+		}
+		results, err = lookupProtocol(dataset, query)
+	} else {
+		results, err = lookup(query)
+	}
+	if *countFlag {
+		results, err = countable(results, err)
+	}
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
+	}
+
+	if *registration != "" {
+		results = filterRegistration(results, *registration)
+		if len(results) == 0 && !*countFlag {
+			fatal(&cliError{Kind: errNotFound, Message: fmt.Sprintf("no %s HTTP status codes found matching your criteria", *registration)})
+		}
+	}
+	gapRanges := gapPrefixes(query)
+	if *showGaps && len(gapRanges) == 0 {
+		fatal("--show-gaps needs a class or partial code, e.g. httpstatus 4 --show-gaps")
+	}
+
+	// Unassigned codes shown by --show-gaps are not matches, so the count
+	// is taken before they are added
+	if *countFlag {
+		if err := printCount(stdout, len(results), *jsonOutput); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	// Let the user narrow the results down interactively
+	if *pickFlag {
+		results, err = pickInteractive(results)
+		if errors.Is(err, errPickCancelled) {
+			os.Exit(errorExitStatus())
+		}
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	// Only a single code has a class to exit with
+	exitStatus := 0
+	if *exitWithClass {
+		if exitStatus, err = classExitStatus(results); err != nil {
+			fatal(err)
+		}
+	}
+
+	// Unassigned code points are listed after the lookup is settled, so
+	// they never count towards --exit-with-class
+	if *showGaps {
+		results = withGaps(results, unassignedCodes(gapRanges))
+	}
+
+	if *sortFlag != "" || *reverseFlag {
+		if results, err = sortResults(results, *sortFlag, *reverseFlag); err != nil {
+			fatal(err)
+		}
+	}
+	if *offsetFlag != 0 || *limitFlag > 0 {
+		if results, err = pageResults(results, *offsetFlag, *limitFlag); err != nil {
+			fatal(err)
+		}
+	}
+
+	// --single only makes sense when the lookup settles on one code
+	if *singleFlag && len(results) != 1 {
+		fatal(&cliError{Kind: errInvalidInput, Message: fmt.Sprintf("--single needs exactly one status code, got %d", len(results))})
+	}
+
+	// --short-only is for scripts, so it wins over every format flag
+	if *shortOnly {
+		if err := printShortOnly(stdout, results, *fallbackCode); err != nil {
+			fatal(&cliError{Kind: errIO, Message: err.Error()})
+		}
+		if exitStatus != 0 {
+			os.Exit(exitStatus)
+		}
+		return
+	}
+
+	// --porcelain is frozen for scripts, whatever the text output becomes
+	if porcelain.version != "" {
+		if err := printPorcelain(stdout, results, porcelain.version); err != nil {
+			fatal(err)
+		}
+		if exitStatus != 0 {
+			os.Exit(exitStatus)
+		}
+		return
+	}
+
+	// --codes-only lists bare numbers, as a JSON array for jq users
+	if *codesOnly {
+		if *jsonOutput || *jsonPretty {
+			printJSONValue(stdout, codeNumbers(results), *jsonPretty)
+		} else if err := printCodesOnly(stdout, results, *oneLine); err != nil {
+			fatal(&cliError{Kind: errIO, Message: err.Error()})
+		}
+		if exitStatus != 0 {
+			os.Exit(exitStatus)
+		}
+		return
 	}
 
 	// Prepare output based on flags
-	outputs := prepareOutputs(results, *longFlag, *allFlag)
+	outputs := prepareOutputs(results, *longFlag, *allFlag || *fullMetadata)
+	if *frameworkFlag != "" {
+		outputs = applyFramework(outputs, *frameworkFlag)
+	}
+	if *fullMetadata && !isProtocol {
+		outputs = applyRegistration(outputs)
+	}
 
 	// Handle multiple output formats
-	outputFormats := []struct {
+	outputFormats := make([]struct {
 		name    string
 		enabled bool
-	}{
-		{"json", *jsonOutput},
-		{"json-pretty", *jsonPretty},
-		{"xml", *xmlOutput},
-		{"xml-pretty", *xmlPretty},
-		{"yaml", *yamlOutput},
-		{"yaml-pretty", *yamlPretty},
-		{"toml", *tomlOutput},
-		{"table", *tableOutput},
-		{"markdown", *markdownOutput},
-		{"csv", *csvOutput},
+	}, len(outputFormatFlags))
+	var selected []string
+	for i, f := range outputFormatFlags {
+		outputFormats[i].name, outputFormats[i].enabled = f.name, *f.enabled
+		if *f.enabled {
+			selected = append(selected, f.name)
+		}
+	}
+
+	// Only JSON and YAML have somewhere to put the envelope
+	var meta *metaEnvelope
+	if *withMeta {
+		meta = newMetaEnvelope(query, outputs, time.Now())
+		warnMetaIgnored(os.Stderr, selected)
+	}
+	if *groupFlag {
+		warnGroupIgnored(os.Stderr, selected)
+	}
+
+	if !validColorMode(*colorFlag) {
+		fatalf("invalid color mode: '%s' - must be one of %s", *colorFlag, strings.Join(colorModes, ", "))
+	}
+
+	// Capture the output for the clipboard, alongside or instead of stdout
+	var out io.Writer = stdout
+	var clip bytes.Buffer
+	if *copyOnly {
+		out = &clip
+	} else if *copyFlag {
+		out = io.MultiWriter(stdout, &clip)
+	}
+
+	// Only a terminal gets colour, never the clipboard or a file
+	color := !*copyFlag && !*copyOnly && useColor(*colorFlag, os.Stdout)
+
+	// Tables fit the terminal unless --width says otherwise; the clipboard
+	// only gets an explicit width
+	width := *widthFlag
+	if !*copyFlag && !*copyOnly {
+		width = terminalWidth(*widthFlag, os.Stdout)
 	}
 
 	// Handle file output if requested
 	if *toFileBase != "" {
-		writeOutputToFiles(outputFormats, outputs, *toFileBase)
+		if *copyFlag || *copyOnly {
+			fatal("--copy cannot be combined with --to-file")
+		}
+		writeOutputToFiles(outputFormats, outputs, *toFileBase, meta)
 	} else {
 		anyOutput := false
 		for _, format := range outputFormats {
@@ -222,49 +786,195 @@ func main() {
 				anyOutput = true
 				switch format.name {
 				case "json":
-					printJSON(os.Stdout, outputs, false)
+					printJSONValue(out, outputPayload(outputs, *singleFlag, meta), false)
 				case "json-pretty":
-					printJSON(os.Stdout, outputs, true)
+					highlighted(out, color, highlightJSON, func(w io.Writer) { printJSONValue(w, outputPayload(outputs, *singleFlag, meta), true) })
 				case "xml":
-					printXML(os.Stdout, outputs, false)
+					printXMLValue(out, xmlPayload(outputs, *singleFlag), false)
 				case "xml-pretty":
-					printXML(os.Stdout, outputs, true)
+					printXMLValue(out, xmlPayload(outputs, *singleFlag), true)
+				case "xsd":
+					printXSD(out)
 				case "yaml":
-					printYAML(os.Stdout, outputs, false)
+					printYAMLCodes(out, outputs, false, *singleFlag, meta)
 				case "yaml-pretty":
-					printYAML(os.Stdout, outputs, true)
+					highlighted(out, color, highlightYAML, func(w io.Writer) { printYAMLCodes(w, outputs, !*yamlList, *singleFlag, meta) })
 				case "toml":
-					printTOML(os.Stdout, outputs)
+					printTOML(out, outputs)
 				case "table":
-					printTable(os.Stdout, outputs)
+					printTableOptions(out, outputs, tableFields(outputs), tableOptions{style: *tableStyle, width: width, wrap: *fullFlag, color: color, icons: flagIcons()})
 				case "markdown":
-					printMarkdown(os.Stdout, outputs)
+					printMarkdownTables(out, outputs, *groupFlag, printMarkdown)
+				case "markdown-gfm":
+					printMarkdownTables(out, outputs, *groupFlag, printMarkdownGFM)
+				case "html", "html-full":
+					if err := printHTML(out, outputs, tableFields(outputs), format.name == "html-full"); err != nil {
+						fatal(err)
+					}
 				case "csv":
-					printCSV(os.Stdout, outputs)
+					printCSVWith(out, outputs, csvOptions{quote: *quoteFlag, excelHint: *excelHint, delimiter: delimiter, bom: *bomFlag, fields: tableFields(outputs)})
+				case "gen-go-test":
+					if err := printGoTest(out, outputs, *goPackage, *goVarPrefix); err != nil {
+						fatal(err)
+					}
+				case "example-response":
+					printExampleResponses(out, outputs, *http2Style)
+				case "gen-apache":
+					printApache(out, os.Stderr, outputs, *inlineMessage)
 				}
 			}
 		}
 
 		// Default text output if no format specified
 		if !anyOutput {
-			printText(os.Stdout, outputs)
+			printTextOptions(out, outputs, textOptions{color: color, icons: flagIcons(), group: *groupFlag, related: *relatedFlag})
+		}
+
+		if *copyFlag || *copyOnly {
+			if err := copyToClipboard(clip.Bytes()); err != nil {
+				fatal(&cliError{Kind: errIO, Message: err.Error()})
+			}
+		}
+	}
+
+	if exitStatus != 0 {
+		os.Exit(exitStatus)
+	}
+}
+
+// lookupQuery describes the status codes requested on the command line
+type lookupQuery struct {
+	codes   string   // comma-separated -c/--code value
+	search  string   // --search keyword
+	args    []string // positional codes and prefixes
+	classes []string // class prefixes from the shortcut filters, e.g. "4"
+
+	// codeArgs are the indexes of args that are -c values moved into
+	// place by --preserve-input-order, parsed as codes rather than phrases
+	codeArgs []int
+
+	// allowDuplicates keeps a code once for every token that matches it
+	allowDuplicates bool
+
+	// exclude lists codes, prefixes and ranges never to return
+	exclude []string
+
+	// types restricts every result to these Type values, ignoring case
+	types []string
+
+	// searchAll are search terms that must all appear, in addition to search
+	searchAll []string
+
+	// searchNot drops every code whose description contains any of these
+	searchNot []string
+}
+
+// searchTerms returns every search term of the query; a code must
+// contain all of them to match
+func (q lookupQuery) searchTerms() []string {
+	var terms []string
+	if q.search != "" {
+		terms = append(terms, q.search)
+	}
+	for _, term := range q.searchAll {
+		if term != "" {
+			terms = append(terms, term)
 		}
 	}
+	return terms
+}
+
+// splitTerms splits a comma-separated --search-all value
+func splitTerms(s string) []string {
+	var terms []string
+	for _, term := range strings.Split(s, ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
 }
 
 // processInputs handles the input processing and returns the status codes to display
 func processInputs(codeStr, searchStr string, args []string) ([]StatusCode, error) {
+	return lookup(lookupQuery{codes: codeStr, search: searchStr, args: args})
+}
+
+// lookup resolves a query to the status codes to display; codes, positional
+// arguments and classes are unioned, and a search is narrowed to the classes
+// when any are given
+func lookup(q lookupQuery) ([]StatusCode, error) {
+	codeStr, args := q.codes, q.args
+	terms := q.searchTerms()
 	var results []StatusCode
 	seen := make(map[int]bool) // Track seen codes to prevent duplicates
 
-	// Helper to add status code if not seen
+	excluded, err := parseExclusions(q.exclude)
+	if err != nil {
+		return nil, err
+	}
+	wantType, err := parseTypes(q.types)
+	if err != nil {
+		return nil, err
+	}
+
+	// Helper to add status code if not seen; excluded codes, codes of
+	// other types and codes matching --search-not are never added, so the
+	// remaining codes keep their order
 	addIfNotSeen := func(sc StatusCode) {
-		if !seen[sc.Code] {
+		if excluded(sc.Code) || !wantType(sc.Type) || matchesAnyTerm(sc, q.searchNot) {
+			return
+		}
+		if q.allowDuplicates || !seen[sc.Code] {
 			seen[sc.Code] = true
 			results = append(results, sc)
 		}
 	}
 
+	// addCode adds the codes matched by one -c entry: a code, a range or
+	// a numeric prefix, never a reason phrase
+	addCode := func(part string) error {
+		// Expand an inclusive range such as 400-417
+		if matches, isRange, err := lookupRange(part); isRange {
+			if err != nil {
+				return err
+			}
+			for _, sc := range matches {
+				addIfNotSeen(sc)
+			}
+			return nil
+		}
+
+		// Validate input is numeric
+		if _, err := strconv.Atoi(part); err != nil {
+			return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid status code: '%s' - must be numeric", part), Input: part}
+		}
+
+		// Try to parse as exact code
+		if codeInt, err := strconv.Atoi(part); err == nil {
+			if sc, found := findStatusCode(codeInt); found {
+				addIfNotSeen(sc)
+				return nil
+			}
+		}
+
+		// Handle partial code match
+		var matches []StatusCode
+		for _, sc := range statusCodes {
+			codeStr := strconv.Itoa(sc.Code)
+			if strings.HasPrefix(codeStr, part) {
+				matches = append(matches, sc)
+			}
+		}
+		if len(matches) == 0 {
+			return notFoundError(part)
+		}
+		for _, sc := range matches {
+			addIfNotSeen(sc)
+		}
+		return nil
+	}
+
 	// Process code flag (comma-separated)
 	if codeStr != "" {
 		parts := strings.Split(codeStr, ",")
@@ -273,40 +983,15 @@ func processInputs(codeStr, searchStr string, args []string) ([]StatusCode, erro
 			if part == "" {
 				continue
 			}
-
-			// Validate input is numeric
-			if _, err := strconv.Atoi(part); err != nil {
-				return nil, fmt.Errorf("invalid status code: '%s' - must be numeric", part)
-			}
-
-			// Try to parse as exact code
-			if codeInt, err := strconv.Atoi(part); err == nil {
-				if sc, found := findStatusCode(codeInt); found {
-					addIfNotSeen(sc)
-					continue
-				}
-			}
-
-			// Handle partial code match
-			var matches []StatusCode
-			for _, sc := range statusCodes {
-				codeStr := strconv.Itoa(sc.Code)
-				if strings.HasPrefix(codeStr, part) {
-					matches = append(matches, sc)
-				}
-			}
-			if len(matches) == 0 {
-				return nil, fmt.Errorf("no HTTP status codes found matching: '%s'", part)
-			}
-			for _, sc := range matches {
-				addIfNotSeen(sc)
+			if err := addCode(part); err != nil {
+				return nil, err
 			}
 		}
 	}
 
 	// Process positional arguments (comma-separated or single)
 	if len(args) > 0 {
-		for _, arg := range args {
+		for i, arg := range args {
 			argParts := strings.Split(arg, ",")
 			for _, part := range argParts {
 				part = strings.TrimSpace(part)
@@ -314,6 +999,37 @@ func processInputs(codeStr, searchStr string, args []string) ([]StatusCode, erro
 					continue
 				}
 
+				// -c values placed among the arguments stay codes
+				if slices.Contains(q.codeArgs, i) {
+					if err := addCode(part); err != nil {
+						return nil, err
+					}
+					continue
+				}
+
+				// Anything not starting with a digit is a reason phrase
+				if !startsWithDigit(part) {
+					matches, err := lookupPhrase(part)
+					if err != nil {
+						return nil, err
+					}
+					for _, sc := range matches {
+						addIfNotSeen(sc)
+					}
+					continue
+				}
+
+				// Expand an inclusive range such as 300-308
+				if matches, isRange, err := lookupRange(part); isRange {
+					if err != nil {
+						return nil, err
+					}
+					for _, sc := range matches {
+						addIfNotSeen(sc)
+					}
+					continue
+				}
+
 				// Try to parse as exact code
 				if codeInt, err := strconv.Atoi(part); err == nil {
 					if sc, found := findStatusCode(codeInt); found {
@@ -331,7 +1047,7 @@ func processInputs(codeStr, searchStr string, args []string) ([]StatusCode, erro
 					}
 				}
 				if len(matches) == 0 {
-					return nil, fmt.Errorf("no HTTP status codes found matching: '%s'", part)
+					return nil, notFoundError(part)
 				}
 				for _, sc := range matches {
 					addIfNotSeen(sc)
@@ -340,66 +1056,444 @@ func processInputs(codeStr, searchStr string, args []string) ([]StatusCode, erro
 		}
 	}
 
+	// Process class shortcuts, restricting any search to those classes
+	for _, class := range q.classes {
+		for _, sc := range searchAllTerms(filterStatusCodes(statusCodes, class, ""), terms) {
+			addIfNotSeen(sc)
+		}
+	}
+
 	// Process search
-	if searchStr != "" {
-		searchResults := searchStatusCodes(searchStr)
-		for _, sc := range searchResults {
+	if len(terms) > 0 && len(q.classes) == 0 {
+		for _, sc := range searchAllTerms(statusCodes, terms) {
 			addIfNotSeen(sc)
 		}
 	}
 
 	// If no filters applied, show all codes
-	if codeStr == "" && len(args) == 0 && searchStr == "" {
-		results = statusCodes
-	} else if len(results) == 0 {
-		log.Fatal("No HTTP status codes found matching your criteria")
+	if codeStr == "" && len(args) == 0 && len(terms) == 0 && len(q.classes) == 0 {
+		for _, sc := range statusCodes {
+			addIfNotSeen(sc)
+		}
+	}
+	if len(results) == 0 {
+		return nil, &cliError{Kind: errNotFound, Message: "No HTTP status codes found matching your criteria", Input: strings.Join(terms, ",")}
 	}
 
 	return results, nil
 }
 
+// statusTypes returns the Type values of the loaded codes in code order,
+// including any new types from custom data
+func statusTypes() []string {
+	var types []string
+	seen := make(map[string]bool)
+	for _, sc := range statusCodes {
+		if !seen[sc.Type] {
+			seen[sc.Type] = true
+			types = append(types, sc.Type)
+		}
+	}
+	return types
+}
+
+// parseTypes builds a matcher for --type values, which name Type values
+// ignoring case; with none, every type matches
+func parseTypes(values []string) (func(t string) bool, error) {
+	if len(values) == 0 {
+		return func(string) bool { return true }, nil
+	}
+
+	known := statusTypes()
+	wanted := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			match := ""
+			for _, t := range known {
+				if strings.EqualFold(t, part) {
+					match = t
+				}
+			}
+			if match == "" {
+				return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid type: '%s' - must be one of %s", part, strings.Join(known, ", ")), Input: part}
+			}
+			wanted[match] = true
+		}
+	}
+	return func(t string) bool { return wanted[t] }, nil
+}
+
+// parseExclusions builds a matcher for --exclude values: exact codes,
+// prefixes such as 44, and ranges such as 418-420. Values that match
+// nothing are fine, since excluding an absent code changes nothing
+func parseExclusions(values []string) (func(code int) bool, error) {
+	var prefixes []string
+	var ranges [][2]int
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if from, to, isRange := strings.Cut(part, "-"); isRange {
+				lo, errLo := strconv.Atoi(from)
+				hi, errHi := strconv.Atoi(to)
+				if errLo != nil || errHi != nil || lo < 0 || lo > hi {
+					return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid exclusion: '%s' - must be a code, prefix or range, e.g. 418, 44 or 418-420", part), Input: part}
+				}
+				ranges = append(ranges, [2]int{lo, hi})
+				continue
+			}
+			if _, err := strconv.Atoi(part); err != nil || strings.HasPrefix(part, "+") {
+				return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid exclusion: '%s' - must be a code, prefix or range, e.g. 418, 44 or 418-420", part), Input: part}
+			}
+			prefixes = append(prefixes, part)
+		}
+	}
+
+	return func(code int) bool {
+		s := strconv.Itoa(code)
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(s, prefix) {
+				return true
+			}
+		}
+		for _, r := range ranges {
+			if code >= r[0] && code <= r[1] {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// lookupRange returns the known codes in an inclusive range such as
+// 400-417, which may span classes. isRange is false when part is not a
+// range at all; a reversed range is rejected rather than guessed at
+func lookupRange(part string) (matches []StatusCode, isRange bool, err error) {
+	from, to, found := strings.Cut(part, "-")
+	if !found {
+		return nil, false, nil
+	}
+	lo, errLo := strconv.Atoi(strings.TrimSpace(from))
+	hi, errHi := strconv.Atoi(strings.TrimSpace(to))
+	if errLo != nil || errHi != nil || lo < 0 {
+		return nil, true, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid range: '%s' - must be two codes, e.g. 400-417", part), Input: part}
+	}
+	if lo > hi {
+		return nil, true, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid range: '%s' - the start must not be greater than the end, e.g. %d-%d", part, hi, lo), Input: part}
+	}
+
+	for _, sc := range statusCodes {
+		if sc.Code >= lo && sc.Code <= hi {
+			matches = append(matches, sc)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, true, notFoundError(part)
+	}
+	return matches, true, nil
+}
+
+// startsWithDigit reports whether a positional argument looks like a code,
+// prefix or range rather than a reason phrase
+func startsWithDigit(part string) bool {
+	return part != "" && part[0] >= '0' && part[0] <= '9'
+}
+
+// lookupPhrase finds codes by their short description, ignoring case; an
+// exact match is preferred, otherwise every code containing the phrase
+func lookupPhrase(phrase string) ([]StatusCode, error) {
+	var exact, partial []StatusCode
+	lower := strings.ToLower(phrase)
+	for _, sc := range statusCodes {
+		if sc.Short == nil {
+			continue
+		}
+		short := strings.ToLower(*sc.Short)
+		if short == lower {
+			exact = append(exact, sc)
+		} else if strings.Contains(short, lower) {
+			partial = append(partial, sc)
+		}
+	}
+	if len(exact) > 0 {
+		return exact, nil
+	}
+	if len(partial) == 0 {
+		return nil, notFoundError(phrase)
+	}
+	return partial, nil
+}
+
+// shortcutClasses returns the class prefixes selected by the shortcut
+// filter flags, in class order; overlapping flags simply union
+func shortcutClasses() []string {
+	selected := map[string]bool{
+		"1": *informational,
+		"2": *successFlag,
+		"3": *redirectsFlag,
+		"4": *errorsFlag || *clientErrors,
+		"5": *errorsFlag || *serverErrors,
+	}
+	var classes []string
+	for _, class := range []string{"1", "2", "3", "4", "5"} {
+		if selected[class] {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+// stringList is a flag.Value that accumulates every occurrence of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// parseInterspersed parses a subcommand's flags, allowing them to appear
+// before or after its positional arguments, and returns the positionals
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := parseFlags(fs, args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// parseLookupArgs parses the lookup flags like parseInterspersed, and also
+// returns the -c tokens and positional arguments in command-line order,
+// with the indexes of the -c tokens among them
+func parseLookupArgs(fs *flag.FlagSet, args []string, code *string) ([]string, []string, []int, error) {
+	var positional, ordered []string
+	var codeAt []int
+	for {
+		prev := *code
+		if err := parseFlags(fs, args); err != nil {
+			return nil, nil, nil, err
+		}
+		if *code != prev {
+			codeAt = append(codeAt, len(ordered))
+			ordered = append(ordered, *code)
+		}
+		if fs.NArg() == 0 {
+			return positional, ordered, codeAt, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		ordered = append(ordered, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
 func printHelp() {
 	fmt.Printf("%s %s\n\n", AppName, AppVersion)
 	fmt.Println("A CLI tool for looking up HTTP status codes with multiple output formats")
 	fmt.Printf("Source code and license: %s\n\n", GitHubURL)
 
 	fmt.Println("USAGE:")
-	fmt.Println("  httpstatus [flags] [status_code|partial_code]")
+	fmt.Println("  httpstatus [flags] [status_code|partial_code|reason_phrase]")
+	fmt.Println("  httpstatus \"Not Found\"")
 	fmt.Println("  httpstatus --search \"search term\"")
 	fmt.Println("  httpstatus --code \"200,404\"")
 	fmt.Println("  httpstatus \"4,5\" --json-pretty")
 	fmt.Println("  httpstatus --to-file output --json --csv")
 	fmt.Println("  httpstatus --table  # Show all codes in table format")
+	fmt.Println("  httpstatus monitor <url> [flags]")
+	fmt.Println("  httpstatus serve [flags]")
+	fmt.Println("  httpstatus quiz [flags]")
+	fmt.Println("  httpstatus enrich --csv-in <file>|--json-in <file> [flags]")
+	fmt.Println("  httpstatus methods [flags] [method]")
+	fmt.Println("  httpstatus header [flags] [name|prefix]")
+	fmt.Println("  httpstatus grep [flags] < text")
+	fmt.Println("  httpstatus env [--json]")
+	fmt.Println("  httpstatus custom import codes.csv")
+	fmt.Println("  httpstatus diff builtin custom.yaml")
 	fmt.Println("\nFLAGS:")
-	fmt.Println("  -c, --code <codes>   HTTP status code(s) to look up (comma-separated)")
-	fmt.Println("  -s, --search <term>  Search status codes by keyword")
+	fmt.Println("  -c, --code <codes>   HTTP status code(s) or ranges to look up (comma-separated), e.g. 200,400-417")
+	fmt.Println("  -s, --search <term>  Search status codes by keyword (repeat to require every term)")
+	fmt.Println("  --search-all <terms> Only codes matching every comma-separated term, e.g. gateway,timeout")
+	fmt.Println("  --search-not <term>  Leave out codes whose description contains the keyword (repeatable)")
+	fmt.Println("  --type <type>        Only codes of this type, e.g. \"Client Error\" or redirection (comma-separated)")
+	fmt.Println("  -x, --exclude <codes>  Leave out codes, prefixes or ranges (comma-separated), e.g. 418,44")
+	fmt.Println("  --codes-file <file>  Read codes to look up like -c from a file, - for stdin (repeatable)")
 	fmt.Println("  -l, --long           Show long description only")
 	fmt.Println("  -a, --all            Show both short and long descriptions")
+	fmt.Println("  --full-metadata      Show every available field (code, type, short, long, framework, registration)")
+	fmt.Println("  --errors             Only 4xx and 5xx codes")
+	fmt.Println("  --client-errors      Only 4xx codes")
+	fmt.Println("  --server-errors      Only 5xx codes")
+	fmt.Println("  --success            Only 2xx codes")
+	fmt.Println("  --redirects          Only 3xx codes")
+	fmt.Println("  --informational      Only 1xx codes")
+	fmt.Println("  --preserve-input-order  Output codes in the order given on the command line")
+	fmt.Println("  --sort <key>         Sort the results by code, type or short")
+	fmt.Println("  --reverse            Reverse the order of the results")
+	fmt.Println("  --limit <n>          Output at most n codes, 0 for all (after --sort)")
+	fmt.Println("  --offset <n>         Skip the first n codes, for paging with --limit")
+	fmt.Println("  --protocol <p>       Look up http status codes (default), or h2/h3 error codes in hex or decimal")
+	fmt.Println("  --framework <name>   Show how to respond with each code in spring, express, django, rails or aspnet")
+	fmt.Println("  --registration <r>   Only codes with this IANA registration: permanent, provisional or unofficial")
+	fmt.Println("  --show-gaps          Also list the unassigned code points in the classes or prefixes looked up")
+	fmt.Println("  --data <file>        Data file to use instead of the built-in codes")
+	fmt.Println("  --custom <file>      Data file of codes to add or replace (repeatable, applied in order)")
+	fmt.Println("  --merge-strategy <s> How codes defined twice resolve: override (default), error or keep-builtin")
+	fmt.Println("  --show-overrides     List the codes defined by data files rather than the built-in dataset")
+	fmt.Println("  --allow-duplicates   Output a code once for every input that matches it")
+	fmt.Println("  --with-meta          Wrap JSON and YAML output in {count, query, generated_at, version, results}")
+	fmt.Println("  --single             Output the only matching code as a bare JSON, YAML or XML object, not a list")
+	fmt.Println("  --count              Print only the number of matching codes, or {\"count\": n} with --json")
+	fmt.Println("  --exit-with-class    Exit with the class digit of a single code, e.g. 4 for 404 (errors exit 10)")
+	fmt.Println("  --error-format <f>   Write errors as text (default) or json, also for subcommands")
+	fmt.Println("  --color <when>       Colour text, --table, --json-pretty and --yaml-pretty: auto (default, terminals only), always or never")
+	fmt.Println("  -o, --format <list>  Output formats (comma-separated), e.g. json,csv,markdown")
+	fmt.Println("  --pretty             Use the pretty variant of json, xml and yaml")
 	fmt.Println("  --json               Output as JSON")
 	fmt.Println("  --json-pretty        Output as formatted JSON")
 	fmt.Println("  --xml                Output as XML")
 	fmt.Println("  --xml-pretty         Output as formatted XML")
+	fmt.Println("  --xsd                Output the XML Schema for the XML output (written next to --xml files)")
+	fmt.Println("  --schema <format>    Print a schema of the output: a JSON Schema as json or yaml, or an XSD as xml")
 	fmt.Println("  --yaml               Output as YAML")
-	fmt.Println("  --yaml-pretty        Output as formatted YAML")
-	fmt.Println("  --toml               Output as TOML")
+	fmt.Println("  --yaml-pretty        Output as formatted YAML, one document per code")
+	fmt.Println("  --yaml-list          Keep --yaml-pretty output a single sequence, as --yaml is")
+	fmt.Println("  --toml               Output as TOML, a [[status]] table per code")
 	fmt.Println("  --table              Output as text table")
+	fmt.Println("  --table-style <style>  Table style: plain, ascii, unicode, compact (default) or github")
+	fmt.Println("  --width <n>            Fit tables to n columns (default: terminal width)")
+	fmt.Println("  --full                 Wrap long table cells instead of truncating them")
+	fmt.Println("  --short-only           Print only the reason phrase of each code, ignoring format flags")
+	fmt.Println("  --fallback-code        With --short-only, print the code when it has no reason phrase")
+	fmt.Println("  --codes-only           Print only the code numbers, one per line, or a JSON array with --json")
+	fmt.Println("  --one-line             With --codes-only, print the codes on one line separated by spaces")
+	fmt.Println("  --porcelain[=v1]       Print a stable line per code for scripts: code, type, short and long separated by tabs")
+	fmt.Println("  --related              Also list related headers and references in text output")
+	fmt.Println("  --icons                Mark each code in text and table output with a class icon")
+	fmt.Println("  --ascii                With --icons, use ASCII icons such as [OK] and [CLI]")
 	fmt.Println("  --markdown           Output as Markdown table")
+	fmt.Println("  --markdown-gfm       Output as a GitHub Markdown table with inline codes and #status-<code> anchors")
+	fmt.Println("  --group              Group text under === 4xx Client Error === banners, or --markdown and --markdown-gfm under ## headings")
+	fmt.Println("  --html               Output as an HTML table, rows classed by type, e.g. client-error")
+	fmt.Println("  --html-full          Output as a standalone HTML page with inline CSS")
 	fmt.Println("  --csv                Output as CSV")
+	fmt.Println("  --quote <mode>       CSV quoting: minimal (default), all or nonnumeric")
+	fmt.Println("  --excel-hint         Start CSV output with a sep=, line so Excel detects the delimiter")
+	fmt.Println("  --bom                Start CSV output with a UTF-8 byte order mark so Excel reads it as UTF-8")
+	fmt.Println("  --delimiter <c>      CSV field separator, e.g. ; for European Excel, or tab (or \\t) for TSV")
+	fmt.Println("  --parquet            Output as Parquet (requires --to-file)")
+	fmt.Println("  --xlsx               Output as an Excel workbook (requires --to-file)")
+	fmt.Println("  --parquet-compression <c>  Parquet compression: snappy (default), zstd or none")
+	fmt.Println("  --gen-go-test        Output a Go httptest handler and test cases for the codes")
+	fmt.Println("  --go-package <name>  Package name for --gen-go-test (default main)")
+	fmt.Println("  --go-var-prefix <p>  Prefix for generated identifiers (default status)")
+	fmt.Println("  --example-response   Output an example raw HTTP/1.1 response for each code")
+	fmt.Println("  --http2-style        Omit the reason phrase from --example-response status lines")
+	fmt.Println("  --gen-apache         Output Apache ErrorDocument directives for 4xx and 5xx codes")
+	fmt.Println("  --inline-message     Use the short description as the ErrorDocument message")
 	fmt.Println("  --to-file <base>     Save output to files with base name (automatic extensions)")
+	fmt.Println("  --from-curl          Describe the responses in curl -i or -v output read from stdin")
+	fmt.Println("  --pick               Choose from the matched codes interactively (tab to multi-select)")
+	fmt.Println("  --copy               Also copy the output to the system clipboard")
+	fmt.Println("  --copy-only          Copy the output to the clipboard instead of printing it")
 	fmt.Println("  --help               Show this help message")
 	fmt.Println("  --version            Show version information")
 
+	fmt.Println("\nSUBCOMMANDS:")
+	fmt.Println("  monitor <url>        Probe a URL repeatedly and report each status")
+	fmt.Println("      --interval <d>   Time between probes (default 30s)")
+	fmt.Println("      --count <n>      Number of probes, 0 for unlimited (default 0)")
+	fmt.Println("      --until-status <code>  Exit as soon as this status is observed")
+	fmt.Println("      --max-duration <d>     Stop monitoring after this long")
+	fmt.Println("      --timeout <d>    Timeout for each probe (default 10s)")
+	fmt.Println("      --ndjson         Output one JSON object per probe")
+	fmt.Println("  serve                Serve the status codes as a JSON API")
+	fmt.Println("      --addr <addr>    host:port, port, or unix:///path/to.sock (default :8080)")
+	fmt.Println("      --drain-timeout <d>    Time allowed for in-flight requests on shutdown (default 10s)")
+	fmt.Println("      --shutdown-delay <d>   Time to keep serving with /readyz failing before shutting down (default 5s)")
+	fmt.Println("      --socket-mode <mode>   Permissions for a unix socket (default 0660)")
+	fmt.Println("      --tls-cert <file>      TLS certificate (PEM), reloaded on SIGHUP")
+	fmt.Println("      --tls-key <file>       TLS private key (PEM)")
+	fmt.Println("      --tls-self-signed      Serve HTTPS with a generated self-signed certificate")
+	fmt.Println("      --cors-origin <origin> Origin allowed to call the API (repeatable, * for any)")
+	fmt.Println("      --cors-credentials     Allow credentialed cross-origin requests")
+	fmt.Println("      --cache-max-age <d>    Cache-Control max-age for API responses (default 1h)")
+	fmt.Println("      --rate-limit <n/unit>  Requests per client IP, e.g. 100/min (default 0, disabled)")
+	fmt.Println("      --trusted-proxies <list>  Proxy IPs/CIDRs whose X-Forwarded-For is honoured")
+	fmt.Println("      --metrics              Expose Prometheus metrics at /metrics (default true)")
+	fmt.Println("      --metrics-addr <addr>  Serve /metrics on a separate address")
+	fmt.Println("      --default-format <f>   Format when the client has no preference (default json)")
+	fmt.Println("      --access-log <file>    Append JSON access logs to a file (default stderr)")
+	fmt.Println("      --no-access-log        Disable access logging")
+	fmt.Println("  quiz                 Test yourself on status codes and reason phrases")
+	fmt.Println("      --class <n>      Only ask about one class, e.g. 4")
+	fmt.Println("      --count <n>      Number of questions (default 10)")
+	fmt.Println("      --seed <n>       Random seed for a repeatable quiz")
+	fmt.Println("  enrich               Add status descriptions to CSV rows or JSON records")
+	fmt.Println("      --csv-in <file>  CSV file to read, - for stdin")
+	fmt.Println("      --column <col>   Column with the status code, by name or 1-based index (default status_code)")
+	fmt.Println("      --no-header      The CSV has no header row")
+	fmt.Println("      --json-in <file> JSON array or NDJSON file to read, - for stdin")
+	fmt.Println("      --field <path>   Dotted path to the status code in each JSON record")
+	fmt.Println("      --out <file>     Write to a file instead of stdout")
+	fmt.Println("  suggest <description>  Suggest codes for a scenario, with reasons and alternatives")
+	fmt.Println("      --limit <n>      Maximum number of suggestions (default 3)")
+	fmt.Println("      --json           Output suggestions as JSON")
+	fmt.Println("  troubleshoot <code>  Checklist of likely causes for operational codes, e.g. 502")
+	fmt.Println("      --markdown       Output a markdown task list")
+	fmt.Println("      --json           Output JSON with an id per checklist entry")
+	fmt.Println("  methods [method]     HTTP methods with their safe, idempotent and cacheable properties")
+	fmt.Println("      -s, --search <term>  Search the method names and descriptions")
+	fmt.Println("      --json, --xml, --yaml, --toml, --table, --markdown, --csv (and -pretty variants)")
+	fmt.Println("  header [name|prefix] Common headers with an example and the statuses they accompany")
+	fmt.Println("      -s, --search <term>  Search the header names and descriptions")
+	fmt.Println("      --json, --xml, --yaml, --toml, --table, --markdown, --csv (and -pretty variants)")
+	fmt.Println("  grep                 Find the status codes in text read from stdin")
+	fmt.Println("      --inline         Echo the text with the codes on each line appended")
+	fmt.Println("      --context <c>    none (default), or http to only flag lines that mention HTTP")
+	fmt.Println("      --ignore <codes> Codes never to flag (comma-separated)")
+	fmt.Println("      --json           Output the codes found as JSON")
+	fmt.Println("  env                  Show each effective setting and where it came from")
+	fmt.Println("      --json           Output the settings as JSON")
+	fmt.Println("      --merge-strategy <s>, --color <when>  Show the effect of these lookup flags")
+	fmt.Println("  custom import <csv>  Import code,type,short,long rows into the custom data file")
+	fmt.Println("      --overwrite      Replace existing custom codes without asking")
+	fmt.Println("      --skip-existing  Keep existing custom codes without asking")
+	fmt.Println("      --file <path>    Custom data file (default custom.yaml in the config directory)")
+	fmt.Println("  custom validate [file]  Check a data file, reporting problems by line and column")
+	fmt.Println("      --strict         Fail on warnings as well as errors")
+	fmt.Println("  diff <from> <to>     Compare two JSON/YAML data files, or builtin; exits 1 on differences")
+	fmt.Println("      --json           Output the differences as JSON")
+
 	fmt.Println("\nEXAMPLES:")
 	fmt.Println("  Look up multiple status codes:")
 	fmt.Println("      httpstatus -c \"200,404\"")
 	fmt.Println("  Look up all 4xx and 5xx codes:")
 	fmt.Println("      httpstatus \"4,5\"")
+	fmt.Println("  Look up the redirect codes 300 to 308:")
+	fmt.Println("      httpstatus 300-308")
 	fmt.Println("  Search for 'not found' and show 404:")
 	fmt.Println("      httpstatus --search \"not found\" --code 404")
 	fmt.Println("  Get status 200 and 201 in JSON format:")
 	fmt.Println("      httpstatus 200,201 --json")
 	fmt.Println("  Export all 2xx codes to CSV:")
 	fmt.Println("      httpstatus 2 --csv --to-file success_codes")
+	fmt.Println("  Wait until a deploy is healthy (at most 5 minutes):")
+	fmt.Println("      httpstatus monitor https://example.com/health --interval 5s --until-status 200 --max-duration 5m")
 
 	fmt.Println("\nPARTIAL CODE LOOKUP:")
 	fmt.Println("  You can enter just the first digit (e.g., '4') or first two digits (e.g., '41')")
@@ -428,23 +1522,55 @@ func searchStatusCodes(term string) []StatusCode {
 	lowerTerm := strings.ToLower(term)
 
 	for _, sc := range statusCodes {
-		shortLower := ""
-		if sc.Short != nil {
-			shortLower = strings.ToLower(*sc.Short)
-		}
-		longLower := ""
-		if sc.Long != nil {
-			longLower = strings.ToLower(*sc.Long)
+		if matchesSearch(sc, lowerTerm) {
+			results = append(results, sc)
 		}
+	}
+	return results
+}
 
-		if strings.Contains(shortLower, lowerTerm) ||
-			strings.Contains(longLower, lowerTerm) {
+// searchAllTerms returns the codes whose short or long description
+// contains every term, ignoring case
+func searchAllTerms(codes []StatusCode, terms []string) []StatusCode {
+	var results []StatusCode
+	for _, sc := range codes {
+		all := true
+		for _, term := range terms {
+			all = all && matchesSearch(sc, strings.ToLower(term))
+		}
+		if all {
 			results = append(results, sc)
 		}
 	}
 	return results
 }
 
+// matchesAnyTerm reports whether the short or long description contains
+// any of the terms, ignoring case
+func matchesAnyTerm(sc StatusCode, terms []string) bool {
+	for _, term := range terms {
+		if term != "" && matchesSearch(sc, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSearch reports whether a lowercase term appears in the short or long description
+func matchesSearch(sc StatusCode, lowerTerm string) bool {
+	shortLower := ""
+	if sc.Short != nil {
+		shortLower = strings.ToLower(*sc.Short)
+	}
+	longLower := ""
+	if sc.Long != nil {
+		longLower = strings.ToLower(*sc.Long)
+	}
+
+	return strings.Contains(shortLower, lowerTerm) ||
+		strings.Contains(longLower, lowerTerm)
+}
+
 // findStatusCode looks up a specific status code
 func findStatusCode(code int) (StatusCode, bool) {
 	for _, sc := range statusCodes {
@@ -473,37 +1599,80 @@ func prepareOutputs(codes []StatusCode, long, all bool) []StatusCode {
 	return outputs
 }
 
-// printText outputs human-readable text
+// printText outputs human-readable text, one labelled line per present field
 func printText(w io.Writer, codes []StatusCode) {
+	printTextOptions(w, codes, textOptions{})
+}
+
+// textOptions controls how text output is decorated
+type textOptions struct {
+	color bool           // colour each code's lines by its class
+	icons map[int]string // class icons to start each code with, or nil
+	group bool           // a banner per class with its codes indented
+
+	// related adds Headers: and See: lines pointing at the header and
+	// methods references
+	related bool
+}
+
+// printTextOptions outputs plain text decorated as opts say
+func printTextOptions(w io.Writer, codes []StatusCode, opts textOptions) {
+	if opts.group {
+		printTextGrouped(w, codes, opts)
+		return
+	}
 	for i, sc := range codes {
 		if i > 0 {
 			fmt.Fprintln(w)
 			fmt.Fprintln(w, "---")
 		}
-		fmt.Fprintf(w, "Code: %d\nType: %s\n", sc.Code, sc.Type)
-		if sc.Short != nil && sc.Long != nil {
-			fmt.Fprintf(w, "Short: %s\nLong: %s\n", *sc.Short, *sc.Long)
-		} else if sc.Long != nil {
-			fmt.Fprintf(w, "Long: %s\n", *sc.Long)
-		} else if sc.Short != nil {
-			fmt.Fprintf(w, "Short: %s\n", *sc.Short)
+		prefix := ""
+		if icon := classIcon(opts.icons, sc.Code); icon != "" {
+			prefix = icon + " "
+		}
+		line := func(format string, a ...any) {
+			s := prefix + fmt.Sprintf(format, a...)
+			prefix = ""
+			if opts.color {
+				s = paintClass(sc.Code, s)
+			}
+			fmt.Fprintln(w, s)
+		}
+		for _, f := range metadataFields {
+			if value, ok := f.value(sc); ok {
+				line("%s: %s", f.label, value)
+			}
+		}
+		if !opts.related {
+			continue
+		}
+		if names := headersFor(sc.Code); len(names) > 0 {
+			line("Headers: %s (see httpstatus header <name>)", strings.Join(names, ", "))
+		}
+		if see, ok := methodSeeAlso[sc.Code]; ok {
+			line("See: %s", see)
 		}
 	}
 }
 
 // printJSON outputs JSON format
 func printJSON(w io.Writer, codes []StatusCode, pretty bool) {
+	printJSONValue(w, codes, pretty)
+}
+
+// printJSONValue outputs any value as JSON
+func printJSONValue(w io.Writer, v any, pretty bool) {
 	var data []byte
 	var err error
 
 	if pretty {
-		data, err = json.MarshalIndent(codes, "", "  ")
+		data, err = json.MarshalIndent(v, "", "  ")
 	} else {
-		data, err = json.Marshal(codes)
+		data, err = json.Marshal(v)
 	}
 
 	if err != nil {
-		log.Fatalf("JSON error: %v", err)
+		fatalf("JSON error: %v", err)
 	}
 	fmt.Fprintln(w, string(data))
 }
@@ -511,71 +1680,100 @@ func printJSON(w io.Writer, codes []StatusCode, pretty bool) {
 // printXML outputs XML format
 func printXML(w io.Writer, codes []StatusCode, pretty bool) {
 	// Wrap in a root element for valid XML
-	collection := HTTPStatusCollection{Codes: codes}
+	printXMLValue(w, HTTPStatusCollection{Codes: codes}, pretty)
+}
 
+// printXMLValue outputs any value as an XML document
+func printXMLValue(w io.Writer, v any, pretty bool) {
 	var data []byte
 	var err error
 
 	if pretty {
-		data, err = xml.MarshalIndent(collection, "", "  ")
+		data, err = xml.MarshalIndent(v, "", "  ")
 	} else {
-		data, err = xml.Marshal(collection)
+		data, err = xml.Marshal(v)
 	}
 
 	if err != nil {
-		log.Fatalf("XML error: %v", err)
+		fatalf("XML error: %v", err)
 	}
 
 	// Add XML header
 	fmt.Fprint(w, xml.Header+string(data))
 }
 
-// printYAML outputs YAML format
+// printYAML outputs YAML format: a single sequence, or with pretty one
+// document per code separated by ---
 func printYAML(w io.Writer, codes []StatusCode, pretty bool) {
+	if !pretty {
+		printYAMLValue(w, codes)
+		return
+	}
 	for i, sc := range codes {
-		if pretty && i > 0 {
+		if i > 0 {
 			fmt.Fprintln(w, "---")
 		}
 		data, err := yaml.Marshal(sc)
 		if err != nil {
-			log.Fatalf("YAML error: %v", err)
+			fatalf("YAML error: %v", err)
 		}
 		fmt.Fprintln(w, string(data))
 	}
 }
 
-// printTOML outputs TOML format
-func printTOML(w io.Writer, codes []StatusCode) {
-	for i, sc := range codes {
-		if i > 0 {
-			fmt.Fprintln(w)
-		}
-		fmt.Fprintf(w, "[%d]\n", sc.Code)
-		fmt.Fprintf(w, "type = \"%s\"\n", sc.Type)
+// printYAMLValue outputs any value as a single YAML document
+func printYAMLValue(w io.Writer, v any) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		fatalf("YAML error: %v", err)
+	}
+	fmt.Fprintln(w, string(data))
+}
 
-		if sc.Short != nil {
-			fmt.Fprintf(w, "short = \"%s\"\n", escapeTOMLString(*sc.Short))
-		}
+// printYAMLCodes outputs YAML format; the --with-meta envelope and a
+// --single code are always a single document
+func printYAMLCodes(w io.Writer, codes []StatusCode, pretty, single bool, meta *metaEnvelope) {
+	if pretty && meta == nil {
+		printYAML(w, codes, true)
+		return
+	}
+	printYAMLValue(w, outputPayload(codes, single, meta))
+}
 
-		if sc.Long != nil {
-			fmt.Fprintf(w, "long = \"%s\"\n", escapeTOMLString(*sc.Long))
-		}
+// printTOML outputs TOML format
+func printTOML(w io.Writer, codes []StatusCode) {
+	// An array of tables keeps the codes in order, one [[status]] each
+	enc := toml.NewEncoder(w)
+	enc.Indent = ""
+	if err := enc.Encode(tomlCollection{Status: codes}); err != nil {
+		fatalf("TOML error: %v", err)
 	}
 }
 
-// escapeTOMLString escapes special characters in TOML strings
-func escapeTOMLString(s string) string {
-	// TOML requires escaping backslashes and quotes
-	return strings.ReplaceAll(strings.ReplaceAll(s, "\\", "\\\\"), "\"", "\\\"")
+// tomlCollection is the root of the TOML output
+type tomlCollection struct {
+	Status []StatusCode `toml:"status"`
+}
+
+// tableFields returns the table and CSV columns, which grow to every
+// populated field with --full-metadata
+func tableFields(codes []StatusCode) []metadataField {
+	if *fullMetadata {
+		return populatedFields(codes)
+	}
+	return baseFields
 }
 
 // printTable outputs tabular text format
 func printTable(w io.Writer, codes []StatusCode) {
-	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	defer tw.Flush()
+	printTableStyle(w, codes, "compact")
+}
 
-	// Header
-	fmt.Fprintln(tw, "CODE\tTYPE\tSHORT\tLONG")
+// printMarkdown outputs Markdown table format
+func printMarkdown(w io.Writer, codes []StatusCode) {
+	// Table header
+	fmt.Fprintln(w, "| Code | Type | Short | Long |")
+	fmt.Fprintln(w, "|------|------|-------|------|")
 
 	for _, sc := range codes {
 		short := ""
@@ -588,79 +1786,122 @@ func printTable(w io.Writer, codes []StatusCode) {
 			long = *sc.Long
 		}
 
-		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", sc.Code, sc.Type, short, long)
+		fmt.Fprintf(w, "| %d | %s | %s | %s |\n", sc.Code, escapeMarkdownCell(sc.Type), escapeMarkdownCell(short), escapeMarkdownCell(long))
 	}
 }
 
-// printMarkdown outputs Markdown table format
-func printMarkdown(w io.Writer, codes []StatusCode) {
-	// Table header
+// printMarkdownTables outputs one Markdown table drawn by table, or with
+// group a heading and table per class
+func printMarkdownTables(w io.Writer, codes []StatusCode, group bool, table func(io.Writer, []StatusCode)) {
+	if group {
+		printMarkdownGrouped(w, codes, table)
+		return
+	}
+	table(w, codes)
+}
+
+// printMarkdownGFM outputs a Markdown table for GitHub docs: the code is
+// inline code with an anchor to link to, e.g. #status-404, and the code
+// column is right-aligned
+func printMarkdownGFM(w io.Writer, codes []StatusCode) {
 	fmt.Fprintln(w, "| Code | Type | Short | Long |")
-	fmt.Fprintln(w, "|------|------|-------|------|")
+	fmt.Fprintln(w, "|-----:|------|-------|------|")
 
 	for _, sc := range codes {
-		short := ""
+		short, long := "", ""
 		if sc.Short != nil {
 			short = *sc.Short
 		}
-
-		long := ""
 		if sc.Long != nil {
 			long = *sc.Long
 		}
-
-		fmt.Fprintf(w, "| %d | %s | %s | %s |\n", sc.Code, sc.Type, short, long)
+		fmt.Fprintf(w, "| <a id=\"status-%d\"></a>`%d` | %s | %s | %s |\n", sc.Code, sc.Code, escapeMarkdownCell(sc.Type), escapeMarkdownCell(short), escapeMarkdownCell(long))
 	}
 }
 
 // printCSV outputs CSV format
 func printCSV(w io.Writer, codes []StatusCode) {
-	cw := csv.NewWriter(w)
+	printCSVWith(w, codes, csvOptions{})
+}
+
+// printCSVWith outputs CSV format with the given options
+func printCSVWith(w io.Writer, codes []StatusCode, opts csvOptions) {
+	cw := newCSVRecordWriter(w, opts)
 	defer cw.Flush()
 
+	fields := opts.fields
+	if fields == nil {
+		fields = baseFields
+	}
+
 	// Write header
-	cw.Write([]string{"Code", "Type", "Short", "Long"})
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.label
+	}
+	cw.Write(header)
 
 	for _, sc := range codes {
-		short := ""
-		if sc.Short != nil {
-			short = *sc.Short
-		}
-
-		long := ""
-		if sc.Long != nil {
-			long = *sc.Long
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i], _ = f.value(sc)
 		}
-
-		cw.Write([]string{
-			strconv.Itoa(sc.Code),
-			sc.Type,
-			short,
-			long,
-		})
+		cw.Write(record)
 	}
 }
 
-// writeOutputToFiles saves output to files based on format
+// writeOutputToFiles saves output to files based on format; meta is the
+// --with-meta envelope for JSON and YAML, or nil
 func writeOutputToFiles(formats []struct {
 	name    string
 	enabled bool
-}, codes []StatusCode, basePath string) {
+}, codes []StatusCode, basePath string, meta *metaEnvelope) {
 	extMap := map[string]string{
-		"json":        ".json",
-		"json-pretty": ".json",
-		"xml":         ".xml",
-		"xml-pretty":  ".xml",
-		"yaml":        ".yaml",
-		"yaml-pretty": ".yaml",
-		"toml":        ".toml",
-		"table":       ".txt",
-		"markdown":    ".md",
-		"csv":         ".csv",
+		"json":             ".json",
+		"json-pretty":      ".json",
+		"xml":              ".xml",
+		"xml-pretty":       ".xml",
+		"xsd":              ".xsd",
+		"yaml":             ".yaml",
+		"yaml-pretty":      ".yaml",
+		"toml":             ".toml",
+		"table":            ".txt",
+		"markdown":         ".md",
+		"markdown-gfm":     ".md",
+		"html":             ".html",
+		"html-full":        ".html",
+		"csv":              ".csv",
+		"parquet":          ".parquet",
+		"xlsx":             ".xlsx",
+		"gen-go-test":      "_test.go",
+		"example-response": ".http",
+		"gen-apache":       ".conf",
 	}
 
+	// Formats sharing an extension would overwrite each other's file
+	saved := make(map[string]string)
 	for _, format := range formats {
-		if !format.enabled {
+		ext, ok := extMap[format.name]
+		if !format.enabled || !ok {
+			continue
+		}
+		if other, clash := saved[ext]; clash {
+			fatal(&cliError{Kind: errInvalidInput, Message: fmt.Sprintf("--%s and --%s cannot both be saved with --to-file: both write %s", other, format.name, basePath+ext)})
+		}
+		saved[ext] = format.name
+	}
+
+	// The schema is written next to an XML export; it describes the
+	// collection, not a --single element
+	xmlExport := false
+	for _, format := range formats {
+		if format.enabled && (format.name == "xml" || format.name == "xml-pretty") && !*singleFlag {
+			xmlExport = true
+		}
+	}
+
+	for _, format := range formats {
+		if !format.enabled && !(format.name == "xsd" && xmlExport) {
 			continue
 		}
 
@@ -673,32 +1914,62 @@ func writeOutputToFiles(formats []struct {
 		filename := basePath + ext
 		file, err := os.Create(filename)
 		if err != nil {
-			log.Printf("Error creating %s: %v", filename, err)
+			reportError(&cliError{Kind: errIO, Message: fmt.Sprintf("Error creating %s: %v", filename, err)})
 			continue
 		}
 		defer file.Close()
 
 		switch format.name {
 		case "json":
-			printJSON(file, codes, false)
+			printJSONValue(file, outputPayload(codes, *singleFlag, meta), false)
 		case "json-pretty":
-			printJSON(file, codes, true)
+			printJSONValue(file, outputPayload(codes, *singleFlag, meta), true)
 		case "xml":
-			printXML(file, codes, false)
+			printXMLValue(file, xmlPayload(codes, *singleFlag), false)
 		case "xml-pretty":
-			printXML(file, codes, true)
+			printXMLValue(file, xmlPayload(codes, *singleFlag), true)
+		case "xsd":
+			printXSD(file)
 		case "yaml":
-			printYAML(file, codes, false)
+			printYAMLCodes(file, codes, false, *singleFlag, meta)
 		case "yaml-pretty":
-			printYAML(file, codes, true)
+			printYAMLCodes(file, codes, !*yamlList, *singleFlag, meta)
 		case "toml":
 			printTOML(file, codes)
 		case "table":
-			printTable(file, codes)
+			printTableOptions(file, codes, tableFields(codes), tableOptions{style: *tableStyle, width: *widthFlag, wrap: *fullFlag, icons: flagIcons()})
 		case "markdown":
-			printMarkdown(file, codes)
+			printMarkdownTables(file, codes, *groupFlag, printMarkdown)
+		case "markdown-gfm":
+			printMarkdownTables(file, codes, *groupFlag, printMarkdownGFM)
+		case "html", "html-full":
+			if err := printHTML(file, codes, tableFields(codes), format.name == "html-full"); err != nil {
+				reportError(&cliError{Kind: errIO, Message: fmt.Sprintf("Error writing %s: %v", filename, err)})
+				continue
+			}
 		case "csv":
-			printCSV(file, codes)
+			// main has already rejected an invalid delimiter
+			delimiter, _ := parseDelimiter(*delimiterFlag)
+			printCSVWith(file, codes, csvOptions{quote: *quoteFlag, excelHint: *excelHint, delimiter: delimiter, bom: *bomFlag, fields: tableFields(codes)})
+		case "parquet":
+			if err := printParquet(file, codes, *parquetCodec); err != nil {
+				reportError(&cliError{Kind: errIO, Message: fmt.Sprintf("Error writing %s: %v", filename, err)})
+				continue
+			}
+		case "xlsx":
+			if err := printXLSX(file, codes, tableFields(codes)); err != nil {
+				reportError(&cliError{Kind: errIO, Message: fmt.Sprintf("Error writing %s: %v", filename, err)})
+				continue
+			}
+		case "gen-go-test":
+			if err := printGoTest(file, codes, *goPackage, *goVarPrefix); err != nil {
+				reportError(&cliError{Kind: errIO, Message: fmt.Sprintf("Error writing %s: %v", filename, err)})
+				continue
+			}
+		case "example-response":
+			printExampleResponses(file, codes, *http2Style)
+		case "gen-apache":
+			printApache(file, os.Stderr, codes, *inlineMessage)
 		}
 		log.Printf("Output saved to %s", filename)
 	}
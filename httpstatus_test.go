@@ -24,12 +24,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
@@ -193,21 +198,32 @@ func TestPrintYAML(t *testing.T) {
 	codes := []StatusCode{{Code: 200, Type: "Success", Short: strPtr("OK"), Long: strPtr("All good")}}
 	var buf bytes.Buffer
 
-	// Test single item
+	// Test single item, which is still a sequence
 	printYAML(&buf, codes, false)
 	output := buf.String()
 
 	// Parse output to verify valid YAML
-	var decoded StatusCode
+	var decoded []StatusCode
 	if err := yaml.Unmarshal([]byte(output), &decoded); err != nil {
 		t.Fatalf("Invalid YAML output: %v\nOutput: %s", err, output)
 	}
 
 	// Verify content
-	if decoded.Code != 200 || *decoded.Short != "OK" {
+	if len(decoded) != 1 || decoded[0].Code != 200 || *decoded[0].Short != "OK" {
 		t.Errorf("Unexpected YAML content: %+v", decoded)
 	}
 
+	// Test multiple items as one sequence document
+	buf.Reset()
+	printYAML(&buf, append(codes, StatusCode{Code: 201, Type: "Success", Short: strPtr("Created")}), false)
+	decoded = nil
+	if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid YAML sequence: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 2 || decoded[1].Code != 201 || strings.Contains(buf.String(), "---") {
+		t.Errorf("Expected a single sequence of 2 codes, got:\n%s", buf.String())
+	}
+
 	// Test multiple items with pretty output
 	buf.Reset()
 	codes = []StatusCode{
@@ -256,7 +272,8 @@ func TestPrintTOML(t *testing.T) {
 	output := buf.String()
 
 	expected := []string{
-		"[200]",
+		"[[status]]",
+		"code = 200",
 		"type = \"Success\"",
 		"short = \"OK\"",
 		"long = \"All good\"",
@@ -359,7 +376,7 @@ func TestWriteOutputToFiles(t *testing.T) {
 
 	codes := []StatusCode{{Code: 200, Type: "Success", Short: strPtr("OK")}}
 
-	writeOutputToFiles(formats, codes, basePath)
+	writeOutputToFiles(formats, codes, basePath, nil)
 
 	// Check that files were created
 	expectedFiles := []string{
@@ -406,32 +423,13 @@ func TestUnknownFormatFileOutput(t *testing.T) {
 		log.SetOutput(os.Stderr)
 	}()
 
-	writeOutputToFiles(formats, codes, basePath)
+	writeOutputToFiles(formats, codes, basePath, nil)
 
 	if !strings.Contains(buf.String(), "Skipping unknown format") {
 		t.Error("Expected warning about unknown format")
 	}
 }
 
-// Test TOML escaping
-func TestTOMLEscaping(t *testing.T) {
-	testCases := []struct {
-		input    string
-		expected string
-	}{
-		{`Hello "World"`, `Hello \"World\"`},
-		{`Back\Slash`, `Back\\Slash`},
-		{`No special chars`, `No special chars`},
-	}
-
-	for _, tc := range testCases {
-		result := escapeTOMLString(tc.input)
-		if result != tc.expected {
-			t.Errorf("For input '%s', expected '%s', got '%s'", tc.input, tc.expected, result)
-		}
-	}
-}
-
 // Test prepareOutputs with empty long/short
 func TestPrepareOutputsWithNil(t *testing.T) {
 	// Create a test-specific status with nil descriptions
@@ -583,6 +581,158 @@ func TestDuplicatePrevention(t *testing.T) {
 	}
 }
 
+// codesOf returns the codes of a result set in order
+func codesOf(results []StatusCode) []int {
+	codes := []int{}
+	for _, r := range results {
+		codes = append(codes, r.Code)
+	}
+	return codes
+}
+
+// Test the class shortcut filters
+func TestLookupClasses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    lookupQuery
+		expected []int
+	}{
+		{"errors narrow search", lookupQuery{search: "timeout", classes: []string{"4", "5"}}, []int{408, 504}},
+		{"server errors narrow search", lookupQuery{search: "timeout", classes: []string{"5"}}, []int{504}},
+		{"codes union with class", lookupQuery{codes: "404", classes: []string{"1"}}, []int{404, 100, 101, 102, 103}},
+		{"codes dedup with class", lookupQuery{codes: "101", classes: []string{"1"}}, []int{101, 100, 102, 103}},
+	}
+
+	for _, tc := range testCases {
+		results, err := lookup(tc.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+
+	results, err := lookup(lookupQuery{classes: []string{"2", "3"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, r := range results {
+		if r.Code < 200 || r.Code >= 400 {
+			t.Errorf("Unexpected code %d for 2xx/3xx classes", r.Code)
+		}
+	}
+	if len(results) != len(filterStatusCodes(statusCodes, "2", ""))+len(filterStatusCodes(statusCodes, "3", "")) {
+		t.Errorf("Expected every 2xx and 3xx code, got %d", len(results))
+	}
+}
+
+// Test -c values and arguments are recorded in command-line order
+func TestParseLookupArgs(t *testing.T) {
+	testCases := []struct {
+		args              []string
+		positional, order string
+		codeAt            []int
+	}{
+		{[]string{"500", "-c", "404,201", "--json"}, "500", "500|404,201", []int{1}},
+		{[]string{"-c", "404", "500", "418"}, "500|418", "404|500|418", []int{0}},
+		{[]string{"500", "418", "--code=201"}, "500|418", "500|418|201", []int{2}},
+		{[]string{"--json", "3"}, "3", "3", nil},
+	}
+
+	for _, tc := range testCases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		code := fs.String("c", "", "")
+		fs.StringVar(code, "code", "", "")
+		fs.Bool("json", false, "")
+
+		positional, ordered, codeAt, err := parseLookupArgs(fs, tc.args, code)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.args, err)
+			continue
+		}
+		if got := strings.Join(positional, "|"); got != tc.positional {
+			t.Errorf("%v: expected positionals %q, got %q", tc.args, tc.positional, got)
+		}
+		if got := strings.Join(ordered, "|"); got != tc.order {
+			t.Errorf("%v: expected order %q, got %q", tc.args, tc.order, got)
+		}
+		if fmt.Sprint(codeAt) != fmt.Sprint(tc.codeAt) {
+			t.Errorf("%v: expected -c at %v, got %v", tc.args, tc.codeAt, codeAt)
+		}
+	}
+}
+
+// Test -c values moved into place by --preserve-input-order are still
+// parsed as codes, so a phrase fails there as it does without the flag
+func TestLookupOrderedCodes(t *testing.T) {
+	for _, codes := range []string{"teapot", "404,teapot"} {
+		_, unordered := lookup(lookupQuery{codes: codes})
+		_, ordered := lookup(lookupQuery{args: []string{"500", codes}, codeArgs: []int{1}})
+		if unordered == nil || ordered == nil || ordered.Error() != unordered.Error() {
+			t.Errorf("-c %s: expected the same error with and without input order, got %v and %v", codes, unordered, ordered)
+		}
+	}
+
+	results, err := lookup(lookupQuery{args: []string{"teapot", "500-501"}, codeArgs: []int{1}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(codesOf(results)); got != "[418 500 501]" {
+		t.Errorf("Expected [418 500 501], got %s", got)
+	}
+}
+
+// Test results follow token order, with each code placed at its first token
+func TestLookupInputOrder(t *testing.T) {
+	testCases := []struct {
+		query    lookupQuery
+		expected []int
+	}{
+		{lookupQuery{args: []string{"500", "404,201"}}, []int{500, 404, 201}},
+		{lookupQuery{args: []string{"418", "41"}}, []int{418, 410, 411, 412, 413, 414, 415, 416, 417}},
+		{lookupQuery{args: []string{"201", "20", "201"}}, []int{201, 200, 202, 203, 204, 205, 206, 207, 208}},
+		{lookupQuery{args: []string{"504"}, search: "teapot"}, []int{504, 418}},
+	}
+
+	for _, tc := range testCases {
+		results, err := lookup(tc.query)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.query.args, err)
+			continue
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+			t.Errorf("%v: expected %v, got %v", tc.query.args, tc.expected, got)
+		}
+	}
+}
+
+// Test --allow-duplicates keeps a code for every token that matches it
+func TestLookupAllowDuplicates(t *testing.T) {
+	testCases := []struct {
+		query    lookupQuery
+		expected []int
+	}{
+		{lookupQuery{codes: "404,404", allowDuplicates: true}, []int{404, 404}},
+		{lookupQuery{args: []string{"201", "20"}, allowDuplicates: true}, []int{201, 200, 201, 202, 203, 204, 205, 206, 207, 208}},
+		{lookupQuery{codes: "418", search: "teapot", allowDuplicates: true}, []int{418, 418}},
+		{lookupQuery{search: "teapot", classes: []string{"4"}, allowDuplicates: true}, []int{418}},
+		{lookupQuery{codes: "404,404"}, []int{404}},
+	}
+
+	for _, tc := range testCases {
+		results, err := lookup(tc.query)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", tc.query, err)
+			continue
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.query, tc.expected, got)
+		}
+	}
+}
+
 // Test invalid code input
 func TestInvalidCodeInput(t *testing.T) {
 	_, err := processInputs("abc", "", nil)
@@ -607,3 +757,351 @@ func TestEmptyInput(t *testing.T) {
 		t.Errorf("Expected all codes, got %d instead of %d", len(results), len(statusCodes))
 	}
 }
+
+// Test ranges expand to the known codes in them, across classes and mixed
+// with single codes, in -c and positional arguments alike
+func TestCodeRanges(t *testing.T) {
+	tests := []struct {
+		codes string
+		args  []string
+		want  []int
+	}{
+		{"204-301", nil, []int{204, 205, 206, 207, 208, 226, 300, 301}},
+		{"", []string{"300-308"}, []int{300, 301, 302, 303, 304, 305, 306, 307, 308}},
+		{"200,400-404,503", nil, []int{200, 400, 401, 402, 403, 404, 503}},
+		{"", []string{"404-404"}, []int{404}},
+		{"401-403,402", nil, []int{401, 402, 403}},
+	}
+	for _, tt := range tests {
+		results, err := processInputs(tt.codes, "", tt.args)
+		if err != nil {
+			t.Fatalf("%q %v: %v", tt.codes, tt.args, err)
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%q %v: expected %v, got %v", tt.codes, tt.args, tt.want, got)
+		}
+	}
+}
+
+// Test empty, reversed and malformed ranges are errors
+func TestCodeRangeErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  string
+		want  string
+	}{
+		{"600-699", errNotFound, "no HTTP status codes found matching: '600-699'"},
+		{"500-400", errInvalidInput, "invalid range: '500-400' - the start must not be greater than the end, e.g. 400-500"},
+		{"4a-5", errInvalidInput, "invalid range: '4a-5' - must be two codes, e.g. 400-417"},
+		{"-404", errInvalidInput, "invalid range: '-404' - must be two codes, e.g. 400-417"},
+	}
+	for _, tt := range tests {
+		_, err := processInputs(tt.input, "", nil)
+		e, ok := err.(*cliError)
+		if !ok || e.Kind != tt.kind || e.Message != tt.want {
+			t.Errorf("%s: expected %s %q, got %v", tt.input, tt.kind, tt.want, err)
+		}
+	}
+}
+
+// Test exclusions drop codes, prefixes and ranges while the rest keep
+// their order, and excluding an absent code changes nothing
+func TestLookupExclude(t *testing.T) {
+	tests := []struct {
+		q    lookupQuery
+		want []int
+	}{
+		{lookupQuery{args: []string{"41"}, exclude: []string{"418,419"}}, []int{410, 411, 412, 413, 414, 415, 416, 417}},
+		{lookupQuery{args: []string{"44"}, exclude: []string{"449"}}, []int{444}},
+		{lookupQuery{codes: "503,404,200", exclude: []string{"4"}}, []int{503, 200}},
+		{lookupQuery{args: []string{"300-308"}, exclude: []string{"301-303", "306"}}, []int{300, 304, 305, 307, 308}},
+		{lookupQuery{codes: "404", exclude: []string{"999", "5"}}, []int{404}},
+		{lookupQuery{codes: "404,404", allowDuplicates: true, exclude: []string{"405"}}, []int{404, 404}},
+	}
+	for _, tt := range tests {
+		results, err := lookup(tt.q)
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.q, err)
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.q, tt.want, got)
+		}
+	}
+
+	results, err := lookup(lookupQuery{exclude: []string{"1,2,3,4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sc := range results {
+		if sc.Code < 500 {
+			t.Errorf("Expected only 5xx codes, got %d", sc.Code)
+		}
+	}
+}
+
+// Test excluding every match is an empty result and bad values are errors
+func TestLookupExcludeErrors(t *testing.T) {
+	_, err := lookup(lookupQuery{codes: "404", exclude: []string{"40"}})
+	if e, ok := err.(*cliError); !ok || e.Kind != errNotFound {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	for _, bad := range []string{"abc", "420-418", "+4", "4x"} {
+		_, err := lookup(lookupQuery{args: []string{"4"}, exclude: []string{bad}})
+		if e, ok := err.(*cliError); !ok || e.Kind != errInvalidInput {
+			t.Errorf("%s: expected an invalid input error, got %v", bad, err)
+		}
+	}
+}
+
+// Test --type narrows every other filter, ignoring case
+func TestLookupType(t *testing.T) {
+	tests := []struct {
+		q    lookupQuery
+		want []int
+	}{
+		{lookupQuery{types: []string{"Server Error"}, search: "timeout"}, []int{504}},
+		{lookupQuery{types: []string{"client error"}, codes: "404,500,418"}, []int{404, 418}},
+		{lookupQuery{types: []string{"REDIRECTION"}}, []int{300, 301, 302, 303, 304, 305, 306, 307, 308}},
+		{lookupQuery{types: []string{"informational,success"}, args: []string{"1", "20"}}, []int{100, 101, 102, 103, 200, 201, 202, 203, 204, 205, 206, 207, 208}},
+	}
+	for _, tt := range tests {
+		results, err := lookup(tt.q)
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.q, err)
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.q, tt.want, got)
+		}
+	}
+
+	_, err := lookup(lookupQuery{types: []string{"Success"}, search: "timeout"})
+	if e, ok := err.(*cliError); !ok || e.Kind != errNotFound {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// Test an unknown type lists the valid ones
+func TestLookupTypeUnknown(t *testing.T) {
+	_, err := lookup(lookupQuery{types: []string{"client"}})
+	want := "invalid type: 'client' - must be one of Informational, Success, Redirection, Client Error, Server Error"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+}
+
+// Test several search terms must all match
+func TestLookupSearchAll(t *testing.T) {
+	tests := []struct {
+		q    lookupQuery
+		want []int
+	}{
+		{lookupQuery{searchAll: []string{"gateway", "timeout"}}, []int{504}},
+		{lookupQuery{search: "gateway", searchAll: []string{"Timeout"}}, []int{504}},
+		{lookupQuery{searchAll: []string{"request", "large"}, classes: []string{"4"}}, []int{413, 431}},
+		{lookupQuery{searchAll: splitTerms("gateway, bad")}, []int{502}},
+	}
+	for _, tt := range tests {
+		results, err := lookup(tt.q)
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.q, err)
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.q, tt.want, got)
+		}
+	}
+
+	_, err := lookup(lookupQuery{searchAll: []string{"gateway", "teapot"}})
+	if e, ok := err.(*cliError); !ok || e.Kind != errNotFound || e.Input != "gateway,teapot" {
+		t.Errorf("Expected a not found error for gateway,teapot, got %v", err)
+	}
+}
+
+// Test --search-not drops codes mentioning any of its terms
+func TestLookupSearchNot(t *testing.T) {
+	results, err := lookup(lookupQuery{codes: "4", searchNot: []string{"nginx"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := codesOf(results)
+	for _, code := range []int{444, 499} {
+		if slices.Contains(got, code) {
+			t.Errorf("Expected %d to be dropped, got %v", code, got)
+		}
+	}
+	if !slices.Contains(got, 404) || !slices.Contains(got, 418) {
+		t.Errorf("Expected the other 4xx codes to remain, got %v", got)
+	}
+
+	all, err := lookup(lookupQuery{searchNot: []string{"WebDAV", "nginx"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sc := range all {
+		if matchesAnyTerm(sc, []string{"webdav", "nginx"}) {
+			t.Errorf("Expected %d to be dropped from the full list", sc.Code)
+		}
+	}
+	if len(all) == 0 || len(all) >= len(statusCodes) {
+		t.Errorf("Expected some but not all codes, got %d of %d", len(all), len(statusCodes))
+	}
+}
+
+// Test positional arguments can be reason phrases
+func TestLookupReasonPhrase(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []int
+	}{
+		{[]string{"Not Found"}, []int{404}},
+		{[]string{"teapot"}, []int{418}},
+		{[]string{"gateway"}, []int{502, 504}},
+		{[]string{"found,200"}, []int{302, 200}},
+		{[]string{"Multi-Status"}, []int{207}},
+		{[]string{"40"}, []int{400, 401, 402, 403, 404, 405, 406, 407, 408, 409}},
+	}
+	for _, tt := range tests {
+		results, err := lookup(lookupQuery{args: tt.args})
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if got := codesOf(results); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.args, tt.want, got)
+		}
+	}
+
+	for _, arg := range []string{"no such phrase", "4xx"} {
+		_, err := lookup(lookupQuery{args: []string{arg}})
+		if e, ok := err.(*cliError); !ok || e.Kind != errNotFound {
+			t.Errorf("%s: expected a not found error, got %v", arg, err)
+		}
+	}
+}
+
+// Test --bom starts only the CSV file with a byte order mark
+func TestWriteOutputToFilesBOM(t *testing.T) {
+	*bomFlag = true
+	defer func() { *bomFlag = false }()
+
+	basePath := t.TempDir() + "/output"
+	formats := []struct {
+		name    string
+		enabled bool
+	}{
+		{"json", true},
+		{"csv", true},
+	}
+	codes := []StatusCode{{Code: 200, Type: "Success", Short: strPtr("OK")}, {Code: 404, Type: "Client Error", Short: strPtr("Not Found")}}
+	writeOutputToFiles(formats, codes, basePath, nil)
+
+	csvData, err := os.ReadFile(basePath + ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(csvData, []byte{0xEF, 0xBB, 0xBF}) {
+		t.Errorf("Expected the CSV file to start with a BOM, got % x", csvData[:3])
+	}
+	if bytes.Count(csvData, []byte("\uFEFF")) != 1 {
+		t.Error("Expected exactly one BOM in the CSV file")
+	}
+
+	jsonData, err := os.ReadFile(basePath + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.HasPrefix(jsonData, []byte{0xEF, 0xBB, 0xBF}) {
+		t.Error("Expected no BOM in the JSON file")
+	}
+}
+
+// Test --single prints the only code as a bare object
+func TestSinglePayload(t *testing.T) {
+	codes := []StatusCode{{Code: 404, Type: "Client Error", Short: strPtr("Not Found")}}
+
+	var compact, pretty, list bytes.Buffer
+	printJSONValue(&compact, outputPayload(codes, true, nil), false)
+	printJSONValue(&pretty, outputPayload(codes, true, nil), true)
+	printJSONValue(&list, outputPayload(codes, false, nil), false)
+
+	if want := `{"code":404,"type":"Client Error","short":"Not Found"}` + "\n"; compact.String() != want {
+		t.Errorf("Expected %s, got %s", want, compact.String())
+	}
+	if want := "{\n  \"code\": 404,\n  \"type\": \"Client Error\",\n  \"short\": \"Not Found\"\n}\n"; pretty.String() != want {
+		t.Errorf("Expected %s, got %s", want, pretty.String())
+	}
+	if !strings.HasPrefix(list.String(), "[") {
+		t.Errorf("Expected an array without --single, got %s", list.String())
+	}
+
+	var doc bytes.Buffer
+	printXMLValue(&doc, xmlPayload(codes, true), false)
+	if want := xml.Header + "<http_status><code>404</code><type>Client Error</type><short>Not Found</short></http_status>"; doc.String() != want {
+		t.Errorf("Expected %s, got %s", want, doc.String())
+	}
+
+	// Several codes stay a list whatever the flag says
+	two := append(codes, StatusCode{Code: 410, Type: "Client Error"})
+	if _, ok := outputPayload(two, true, nil).([]StatusCode); !ok {
+		t.Error("Expected a list for several codes")
+	}
+}
+
+// Test the TOML output parses back into the codes it was made from
+func TestTOMLRoundTrip(t *testing.T) {
+	codes := []StatusCode{
+		{Code: 200, Type: "Success", Short: strPtr("OK")},
+		{Code: 418, Type: "Client Error", Short: strPtr(`I'm a "teapot"`), Long: strPtr("C:\\kettle\\spout\nsecond line\t# not a comment")},
+		{Code: 599, Type: `Custom "Type"`, Framework: strPtr(`raise Http599("\d")`)},
+	}
+
+	var buf bytes.Buffer
+	printTOML(&buf, codes)
+
+	var decoded tomlCollection
+	if _, err := toml.Decode(buf.String(), &decoded); err != nil {
+		t.Fatalf("Invalid TOML: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(decoded.Status, codes) {
+		t.Errorf("Expected %+v, got %+v\n%s", codes, decoded.Status, buf.String())
+	}
+}
+
+// Test Markdown cells can't break the table
+func TestPrintMarkdownEscaping(t *testing.T) {
+	codes := []StatusCode{
+		{Code: 599, Type: "Custom", Short: strPtr("a|b"), Long: strPtr("first line\nsecond | line\r\nthird")},
+		{Code: 598, Type: "Custom", Short: strPtr("  padded  "), Long: strPtr("plain")},
+	}
+	var buf bytes.Buffer
+	printMarkdown(&buf, codes)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected a header, a rule and 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		// Four columns have five unescaped pipes
+		if n := strings.Count(line, "|") - strings.Count(line, `\|`); n != 5 {
+			t.Errorf("Expected 4 columns, got %d pipes in %q", n, line)
+		}
+	}
+	if want := `| 599 | Custom | a\|b | first line<br>second \| line<br>third |`; lines[2] != want {
+		t.Errorf("Expected %s, got %s", want, lines[2])
+	}
+	if want := `| 598 | Custom | &nbsp;&nbsp;padded&nbsp;&nbsp; | plain |`; lines[3] != want {
+		t.Errorf("Expected %s, got %s", want, lines[3])
+	}
+}
+
+// Test the GitHub Markdown table has anchors, inline codes and alignment
+func TestPrintMarkdownGFM(t *testing.T) {
+	codes := []StatusCode{{Code: 404, Type: "Client Error", Short: strPtr("Not|Found")}}
+	var buf bytes.Buffer
+	printMarkdownGFM(&buf, codes)
+
+	want := "| Code | Type | Short | Long |\n" +
+		"|-----:|------|-------|------|\n" +
+		"| <a id=\"status-404\"></a>`404` | Client Error | Not\\|Found |  |\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// classIcons are the glyphs --icons puts before each code, by class
+var classIcons = map[int]string{
+	1: "ℹ️",
+	2: "✅",
+	3: "↪",
+	4: "⚠️",
+	5: "💥",
+}
+
+// classIconsASCII stand in for classIcons with --ascii or when the locale
+// isn't UTF-8
+var classIconsASCII = map[int]string{
+	1: "[INF]",
+	2: "[OK]",
+	3: "[RDR]",
+	4: "[CLI]",
+	5: "[SRV]",
+}
+
+// iconSet picks the icons for --icons: ASCII ones when asked for or when
+// the terminal can't be expected to show UTF-8
+func iconSet(ascii bool) map[int]string {
+	if ascii || !utf8Locale() {
+		return classIconsASCII
+	}
+	return classIcons
+}
+
+// flagIcons returns the icons --icons and --ascii ask for, or nil
+func flagIcons() map[int]string {
+	if !*iconsFlag {
+		return nil
+	}
+	return iconSet(*asciiFlag)
+}
+
+// classIcon returns the icon for code's class from icons, or "" for none
+func classIcon(icons map[int]string, code int) string {
+	return icons[code/100]
+}
+
+// utf8Locale reports whether the locale, from the first of LC_ALL,
+// LC_CTYPE and LANG that is set, uses UTF-8. With none set the terminal
+// is assumed to be UTF-8, but the C and POSIX locales are not
+func utf8Locale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		locale = strings.ToLower(locale)
+		return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test the locale decides between emoji and ASCII icons
+func TestIconSet(t *testing.T) {
+	tests := []struct {
+		lcAll, lang string
+		ascii       bool
+		want        string
+	}{
+		{"", "en_GB.UTF-8", false, "⚠️"},
+		{"", "en_US.utf8", false, "⚠️"},
+		{"", "", false, "⚠️"},
+		{"", "C", false, "[CLI]"},
+		{"POSIX", "en_GB.UTF-8", false, "[CLI]"},
+		{"", "en_GB.UTF-8", true, "[CLI]"},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_CTYPE", "")
+		t.Setenv("LANG", tt.lang)
+		if got := classIcon(iconSet(tt.ascii), 404); got != tt.want {
+			t.Errorf("LC_ALL=%q LANG=%q ascii=%v: expected %s, got %s", tt.lcAll, tt.lang, tt.ascii, tt.want, got)
+		}
+	}
+}
+
+// Test icons are double width where terminals draw them so
+func TestIconWidths(t *testing.T) {
+	for class, want := range map[int]int{1: 2, 2: 2, 3: 1, 4: 2, 5: 2} {
+		if got := displayWidth(classIcons[class]); got != want {
+			t.Errorf("Icon for %dxx: expected width %d, got %d", class, want, got)
+		}
+	}
+}
+
+// Test the icon column keeps bordered tables lined up
+func TestTableIcons(t *testing.T) {
+	codes := goldenCodes(t)
+	var buf bytes.Buffer
+	printTableOptions(&buf, codes, baseFields, tableOptions{style: "unicode", icons: classIcons})
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if displayWidth(line) != displayWidth(lines[0]) {
+			t.Errorf("Misaligned table:\n%s", buf.String())
+			break
+		}
+	}
+	if !strings.Contains(buf.String(), "│ 💥 │  503 │") {
+		t.Errorf("Expected an icon before 503:\n%s", buf.String())
+	}
+}
+
+// Test text output starts each code with its icon and nothing else changes
+func TestTextIcons(t *testing.T) {
+	codes := goldenCodes(t)
+	var plain, iconed bytes.Buffer
+	printText(&plain, codes)
+	printTextOptions(&iconed, codes, textOptions{icons: classIconsASCII})
+
+	want := strings.NewReplacer("Code: 1", "[INF] Code: 1", "Code: 4", "[CLI] Code: 4", "Code: 5", "[SRV] Code: 5").Replace(plain.String())
+	if iconed.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, iconed.String())
+	}
+}
@@ -0,0 +1,242 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mergeStrategies lists how a code defined by more than one data source
+// is resolved; the first is the default
+var mergeStrategies = []string{"override", "error", "keep-builtin"}
+
+// validMergeStrategy reports whether s is a merge strategy
+func validMergeStrategy(s string) bool {
+	for _, m := range mergeStrategies {
+		if s == m {
+			return true
+		}
+	}
+	return false
+}
+
+// appConfig holds the settings read from config.yaml in the config directory
+type appConfig struct {
+	MergeStrategy string `yaml:"merge-strategy"`
+
+	path string // where the file is, or would be
+}
+
+// loadConfig reads the config file; a missing file leaves the defaults
+func loadConfig() (appConfig, error) {
+	var cfg appConfig
+	dir, err := configDir()
+	if err != nil {
+		return cfg, nil
+	}
+	path := filepath.Join(dir, "config.yaml")
+	cfg.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	if cfg.MergeStrategy != "" && !validMergeStrategy(cfg.MergeStrategy) {
+		return cfg, fmt.Errorf("invalid merge strategy in %s: '%s' - must be one of %s", path, cfg.MergeStrategy, strings.Join(mergeStrategies, ", "))
+	}
+	return cfg, nil
+}
+
+// dataOptions selects the data sources for a command
+type dataOptions struct {
+	data     string   // --data file replacing the built-in codes
+	custom   []string // --custom files, applied after the custom file in the config directory
+	strategy string   // --merge-strategy, taking precedence over the config file
+}
+
+// dataLayer is one source of status codes, in load order
+type dataLayer struct {
+	source string
+	codes  []StatusCode
+}
+
+// codeOrigin records a code whose definition did not come from the
+// built-in dataset
+type codeOrigin struct {
+	code     int
+	source   string
+	replaces string // the source it replaced, or "" if it added the code
+}
+
+// mergeLayers merges data layers in order. The first layer is the base:
+// the built-in codes or a --data file. A code already defined is replaced
+// under override, fails under error, and under keep-builtin keeps the base
+// definition while later layers still replace each other
+func mergeLayers(layers []dataLayer, strategy string) ([]StatusCode, []codeOrigin, error) {
+	byCode := make(map[int]StatusCode)
+	source := make(map[int]string)
+	origins := make(map[int]codeOrigin)
+
+	for i, layer := range layers {
+		for _, sc := range layer.codes {
+			prev, defined := source[sc.Code]
+			if defined && i > 0 {
+				switch {
+				case strategy == "error":
+					return nil, nil, &cliError{
+						Kind:    errInvalidInput,
+						Message: fmt.Sprintf("code %d in %s is already defined by %s - the merge strategy is error", sc.Code, layer.source, prev),
+						Input:   layer.source,
+					}
+				case strategy == "keep-builtin" && prev == layers[0].source:
+					continue
+				}
+			}
+
+			byCode[sc.Code] = sc
+			source[sc.Code] = layer.source
+			if layer.source != builtinDataset {
+				origin := codeOrigin{code: sc.Code, source: layer.source}
+				if defined {
+					origin.replaces = prev
+				}
+				origins[sc.Code] = origin
+			}
+		}
+	}
+
+	merged := make([]StatusCode, 0, len(byCode))
+	for _, sc := range byCode {
+		merged = append(merged, sc)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Code < merged[j].Code })
+
+	list := make([]codeOrigin, 0, len(origins))
+	for _, o := range origins {
+		list = append(list, o)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].code < list[j].code })
+	return merged, list, nil
+}
+
+// loadLayer validates and reads a data file; a file with errors fails with
+// a one-line summary pointing at custom validate
+func loadLayer(path string) (dataLayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dataLayer{}, err
+	}
+
+	findings, codes := validateDataFile(data)
+	if errs, _ := countFindings(findings); errs > 0 {
+		for _, f := range findings {
+			if f.severity == "error" {
+				return dataLayer{}, &cliError{
+					Kind:    errInvalidInput,
+					Message: fmt.Sprintf("data file %s has %s, first on line %d: %s - run 'httpstatus custom validate %s' for details", path, plural(errs, "error"), f.line, f.message, path),
+					Input:   path,
+				}
+			}
+		}
+	}
+	return dataLayer{source: path, codes: codes}, nil
+}
+
+// loadData builds the dataset for a command in precedence order: the
+// built-in codes or a --data file, then the custom file in the config
+// directory, then each --custom file
+func loadData(opts dataOptions) ([]codeOrigin, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	strategy := mergeStrategySetting(cfg, opts.strategy).Value
+
+	layers := []dataLayer{{source: builtinDataset, codes: builtinCodes}}
+	if opts.data != "" {
+		base, err := loadLayer(opts.data)
+		if err != nil {
+			return nil, err
+		}
+		layers[0] = base
+	}
+
+	if path, err := customDataPath(); err == nil {
+		layer, err := loadLayer(path)
+		if err == nil {
+			layers = append(layers, layer)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	for _, path := range opts.custom {
+		layer, err := loadLayer(path)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	merged, origins, err := mergeLayers(layers, strategy)
+	if err != nil {
+		return nil, err
+	}
+	statusCodes = merged
+	return origins, nil
+}
+
+// printOverrides lists the codes whose definition came from a data file
+// rather than the built-in dataset
+func printOverrides(w io.Writer, origins []codeOrigin) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tSHORT\tSOURCE\tREPLACES")
+	for _, o := range origins {
+		short := ""
+		if sc, found := findStatusCode(o.code); found && sc.Short != nil {
+			short = *sc.Short
+		}
+		replaces := o.replaces
+		if replaces == "" {
+			replaces = "(added)"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", o.code, short, o.source, replaces)
+	}
+	tw.Flush()
+}
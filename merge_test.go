@@ -0,0 +1,234 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useTempConfig points the user config directory at a temporary directory
+// and returns the httpstatus directory inside it
+func useTempConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	cfg, err := configDir()
+	if err != nil {
+		t.Skip("no user config directory")
+	}
+	os.MkdirAll(cfg, 0755)
+	t.Cleanup(func() { statusCodes = builtinCodes })
+	return cfg
+}
+
+// mergeTestLayers returns a base layer and two overlays that both touch 404
+func mergeTestLayers() []dataLayer {
+	return []dataLayer{
+		{source: builtinDataset, codes: []StatusCode{
+			{Code: 200, Type: "Success", Short: strPtr("OK")},
+			{Code: 404, Type: "Client Error", Short: strPtr("Not Found")},
+		}},
+		{source: "a.yaml", codes: []StatusCode{
+			{Code: 404, Type: "Client Error", Short: strPtr("Missing")},
+			{Code: 209, Type: "Success", Short: strPtr("Custom Thing")},
+		}},
+		{source: "b.yaml", codes: []StatusCode{
+			{Code: 209, Type: "Success", Short: strPtr("Later Thing")},
+		}},
+	}
+}
+
+// shortOf returns the short description of code in codes
+func shortOf(codes []StatusCode, code int) string {
+	for _, sc := range codes {
+		if sc.Code == code {
+			return *sc.Short
+		}
+	}
+	return ""
+}
+
+// Test later layers win under the override strategy
+func TestMergeOverride(t *testing.T) {
+	merged, origins, err := mergeLayers(mergeTestLayers(), "override")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(merged) != 3 || merged[0].Code != 200 || merged[1].Code != 209 || merged[2].Code != 404 {
+		t.Fatalf("Expected codes 200, 209 and 404 in order, got %+v", merged)
+	}
+	if got := shortOf(merged, 404); got != "Missing" {
+		t.Errorf("Expected 404 to be overridden, got %s", got)
+	}
+	if got := shortOf(merged, 209); got != "Later Thing" {
+		t.Errorf("Expected the last overlay to win for 209, got %s", got)
+	}
+
+	want := []codeOrigin{
+		{code: 209, source: "b.yaml", replaces: "a.yaml"},
+		{code: 404, source: "a.yaml", replaces: builtinDataset},
+	}
+	if len(origins) != len(want) {
+		t.Fatalf("Expected %d origins, got %+v", len(want), origins)
+	}
+	for i := range want {
+		if origins[i] != want[i] {
+			t.Errorf("Origin %d: expected %+v, got %+v", i, want[i], origins[i])
+		}
+	}
+}
+
+// Test any redefinition fails under the error strategy
+func TestMergeError(t *testing.T) {
+	_, _, err := mergeLayers(mergeTestLayers(), "error")
+	if err == nil || !strings.Contains(err.Error(), "code 404 in a.yaml is already defined by builtin") {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+
+	layers := mergeTestLayers()[:2]
+	layers[1].codes = layers[1].codes[1:]
+	if _, _, err := mergeLayers(layers, "error"); err != nil {
+		t.Errorf("Expected new codes to be accepted, got %v", err)
+	}
+}
+
+// Test base definitions survive under keep-builtin while overlays still
+// add codes and replace each other
+func TestMergeKeepBuiltin(t *testing.T) {
+	merged, origins, err := mergeLayers(mergeTestLayers(), "keep-builtin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := shortOf(merged, 404); got != "Not Found" {
+		t.Errorf("Expected the built-in 404 to be kept, got %s", got)
+	}
+	if got := shortOf(merged, 209); got != "Later Thing" {
+		t.Errorf("Expected the last overlay to win for 209, got %s", got)
+	}
+	if len(origins) != 1 || origins[0].code != 209 {
+		t.Errorf("Expected only 209 to come from a data file, got %+v", origins)
+	}
+}
+
+// Test a --data file replaces the built-in codes and counts as the base
+func TestLoadDataPrecedence(t *testing.T) {
+	cfg := useTempConfig(t)
+	dir := t.TempDir()
+	data := filepath.Join(dir, "data.yaml")
+	custom := filepath.Join(dir, "extra.yaml")
+	os.WriteFile(data, []byte("- code: 200\n  type: Success\n  short: Fine\n- code: 404\n  type: Client Error\n  short: Gone Away\n"), 0644)
+	os.WriteFile(filepath.Join(cfg, "custom.yaml"), []byte("- code: 404\n  type: Client Error\n  short: Config Missing\n"), 0644)
+	os.WriteFile(custom, []byte("- code: 404\n  type: Client Error\n  short: Flag Missing\n"), 0644)
+
+	origins, err := loadData(dataOptions{data: data, custom: []string{custom}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(statusCodes) != 2 {
+		t.Errorf("Expected --data to replace the built-in codes, got %d codes", len(statusCodes))
+	}
+	if got := shortOf(statusCodes, 404); got != "Flag Missing" {
+		t.Errorf("Expected the --custom file to win, got %s", got)
+	}
+	if len(origins) != 2 || origins[1].source != custom || origins[1].replaces != filepath.Join(cfg, "custom.yaml") {
+		t.Errorf("Unexpected origins: %+v", origins)
+	}
+
+	if _, err := loadData(dataOptions{data: data, custom: []string{custom}, strategy: "keep-builtin"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := shortOf(statusCodes, 404); got != "Gone Away" {
+		t.Errorf("Expected keep-builtin to keep the --data definition, got %s", got)
+	}
+
+	if _, err := loadData(dataOptions{custom: []string{filepath.Join(dir, "missing.yaml")}}); err == nil {
+		t.Error("Expected a missing --custom file to fail")
+	}
+}
+
+// Test the custom file in the config directory is loaded implicitly, and
+// an invalid one stops with a single-line summary
+func TestLoadDataCustomFile(t *testing.T) {
+	useTempConfig(t)
+	path, _ := customDataPath()
+
+	if _, err := loadData(dataOptions{}); err != nil {
+		t.Fatalf("Expected a missing custom file to be ignored, got %v", err)
+	}
+
+	os.WriteFile(path, []byte("- code: 209\n  type: Success\n  short: Custom Thing\n"), 0644)
+	if _, err := loadData(dataOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sc, found := findStatusCode(209); !found || *sc.Short != "Custom Thing" {
+		t.Errorf("Expected the custom code to be found, got %+v", sc)
+	}
+
+	os.WriteFile(path, []byte("- code: 209\n- code: 700\n  type: Odd\n"), 0644)
+	_, err := loadData(dataOptions{})
+	if err == nil || strings.Contains(err.Error(), "\n") || !strings.Contains(err.Error(), "2 errors, first on line 1") {
+		t.Errorf("Expected a single-line summary, got %v", err)
+	}
+}
+
+// Test the merge strategy is read from the config file and the flag wins
+func TestMergeStrategyConfig(t *testing.T) {
+	cfg := useTempConfig(t)
+	os.WriteFile(filepath.Join(cfg, "custom.yaml"), []byte("- code: 404\n  type: Client Error\n  short: Missing\n"), 0644)
+
+	os.WriteFile(filepath.Join(cfg, "config.yaml"), []byte("merge-strategy: error\n"), 0644)
+	if _, err := loadData(dataOptions{}); err == nil {
+		t.Error("Expected the config file strategy to reject the override")
+	}
+	if _, err := loadData(dataOptions{strategy: "override"}); err != nil {
+		t.Errorf("Expected the flag to take precedence, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(cfg, "config.yaml"), []byte("merge-strategy: newest\n"), 0644)
+	if _, err := loadData(dataOptions{}); err == nil || !strings.Contains(err.Error(), "invalid merge strategy") {
+		t.Errorf("Expected an invalid strategy error, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(cfg, "config.yaml"), []byte("merge_strategy: error\n"), 0644)
+	if _, err := loadConfig(); err == nil {
+		t.Error("Expected an unknown config key to be rejected")
+	}
+}
+
+// Test the overrides listing marks added codes
+func TestPrintOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	printOverrides(&buf, []codeOrigin{
+		{code: 209, source: "a.yaml"},
+		{code: 404, source: "a.yaml", replaces: builtinDataset},
+	})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "CODE") || !strings.Contains(lines[1], "(added)") || !strings.Contains(lines[2], "builtin") {
+		t.Errorf("Unexpected overrides listing:\n%s", buf.String())
+	}
+}
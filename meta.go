@@ -0,0 +1,100 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// metaQuery echoes the filters of a lookup in a --with-meta envelope
+type metaQuery struct {
+	Codes     []string `json:"codes,omitempty" yaml:"codes,omitempty"`
+	Search    []string `json:"search,omitempty" yaml:"search,omitempty"`
+	SearchNot []string `json:"search_not,omitempty" yaml:"search_not,omitempty"`
+	Classes   []string `json:"classes,omitempty" yaml:"classes,omitempty"`
+	Types     []string `json:"types,omitempty" yaml:"types,omitempty"`
+	Exclude   []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+// metaEnvelope wraps JSON and YAML results with --with-meta so saved
+// exports describe how they were made
+type metaEnvelope struct {
+	Count       int          `json:"count" yaml:"count"`
+	Query       metaQuery    `json:"query" yaml:"query"`
+	GeneratedAt string       `json:"generated_at" yaml:"generated_at"`
+	Version     string       `json:"version" yaml:"version"`
+	Results     []StatusCode `json:"results" yaml:"results"`
+}
+
+// newMetaEnvelope builds the envelope for the results of a query
+func newMetaEnvelope(q lookupQuery, results []StatusCode, now time.Time) *metaEnvelope {
+	var codes []string
+	for _, part := range strings.Split(q.codes, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			codes = append(codes, part)
+		}
+	}
+	for _, arg := range q.args {
+		for _, part := range strings.Split(arg, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				codes = append(codes, part)
+			}
+		}
+	}
+
+	if results == nil {
+		results = []StatusCode{}
+	}
+	return &metaEnvelope{
+		Count: len(results),
+		Query: metaQuery{
+			Codes:     codes,
+			Search:    q.searchTerms(),
+			SearchNot: q.searchNot,
+			Classes:   q.classes,
+			Types:     q.types,
+			Exclude:   q.exclude,
+		},
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Version:     AppVersion,
+		Results:     results,
+	}
+}
+
+// metaFormats are the formats --with-meta wraps; the others ignore it
+var metaFormats = map[string]bool{"json": true, "json-pretty": true, "yaml": true, "yaml-pretty": true}
+
+// warnMetaIgnored warns about each selected format --with-meta doesn't
+// apply to, including the default text output
+func warnMetaIgnored(w io.Writer, formats []string) {
+	if len(formats) == 0 {
+		formats = []string{"text"}
+	}
+	for _, name := range formats {
+		if !metaFormats[name] {
+			fmt.Fprintf(w, "warning: --with-meta only applies to JSON and YAML, ignoring it for %s output\n", name)
+		}
+	}
+}
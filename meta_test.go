@@ -0,0 +1,92 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test the envelope echoes the query and counts the results
+func TestNewMetaEnvelope(t *testing.T) {
+	q := lookupQuery{codes: "404, 5", args: []string{"418,30"}, search: "gateway", searchAll: []string{"timeout"}, types: []string{"Server Error"}}
+	results := []StatusCode{{Code: 504, Type: "Server Error", Short: strPtr("Gateway Timeout")}}
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	var buf bytes.Buffer
+	printJSONValue(&buf, outputPayload(results, false, newMetaEnvelope(q, results, now)), false)
+
+	var got struct {
+		Count       int                 `json:"count"`
+		Query       map[string][]string `json:"query"`
+		GeneratedAt string              `json:"generated_at"`
+		Version     string              `json:"version"`
+		Results     []StatusCode        `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if got.Count != 1 || len(got.Results) != 1 || got.Results[0].Code != 504 {
+		t.Errorf("Expected one result, got %+v", got)
+	}
+	if got.GeneratedAt != "2026-03-01T11:30:00Z" || got.Version != AppVersion {
+		t.Errorf("Expected the UTC time and app version, got %s %s", got.GeneratedAt, got.Version)
+	}
+	wantQuery := map[string][]string{
+		"codes":  {"404", "5", "418", "30"},
+		"search": {"gateway", "timeout"},
+		"types":  {"Server Error"},
+	}
+	if !reflect.DeepEqual(got.Query, wantQuery) {
+		t.Errorf("Expected query %v, got %v", wantQuery, got.Query)
+	}
+}
+
+// Test an empty envelope still has a results list
+func TestMetaEnvelopeEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	printYAMLCodes(&buf, nil, false, false, newMetaEnvelope(lookupQuery{}, nil, time.Now()))
+	if !strings.Contains(buf.String(), "count: 0") || !strings.Contains(buf.String(), "results: []") {
+		t.Errorf("Expected an empty envelope, got:\n%s", buf.String())
+	}
+}
+
+// Test other formats are warned about
+func TestWarnMetaIgnored(t *testing.T) {
+	var buf bytes.Buffer
+	warnMetaIgnored(&buf, []string{"json", "csv", "yaml-pretty", "table"})
+	want := "warning: --with-meta only applies to JSON and YAML, ignoring it for csv output\n" +
+		"warning: --with-meta only applies to JSON and YAML, ignoring it for table output\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	warnMetaIgnored(&buf, nil)
+	if !strings.Contains(buf.String(), "text output") {
+		t.Errorf("Expected a warning for the text output, got %q", buf.String())
+	}
+}
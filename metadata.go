@@ -0,0 +1,71 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"strconv"
+)
+
+// metadataField describes one field of a status code for the text, table
+// and CSV printers
+type metadataField struct {
+	name  string // JSON/YAML/XML name
+	label string // label in text output and CSV headers
+	value func(sc StatusCode) (string, bool)
+}
+
+// optionalValue reads an optional string field
+func optionalValue(s *string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+// metadataFields lists every status code field in output order; new fields
+// are appended here so --full-metadata picks them up
+var metadataFields = []metadataField{
+	{"code", "Code", func(sc StatusCode) (string, bool) { return strconv.Itoa(sc.Code), true }},
+	{"type", "Type", func(sc StatusCode) (string, bool) { return sc.Type, true }},
+	{"short", "Short", func(sc StatusCode) (string, bool) { return optionalValue(sc.Short) }},
+	{"long", "Long", func(sc StatusCode) (string, bool) { return optionalValue(sc.Long) }},
+	{"framework", "Framework", func(sc StatusCode) (string, bool) { return optionalValue(sc.Framework) }},
+	{"registration", "Registration", func(sc StatusCode) (string, bool) { return optionalValue(sc.Registration) }},
+}
+
+// baseFields are the columns of the default table and CSV output
+var baseFields = metadataFields[:4]
+
+// populatedFields returns the fields present in at least one code, in
+// metadataFields order
+func populatedFields(codes []StatusCode) []metadataField {
+	var fields []metadataField
+	for _, f := range metadataFields {
+		for _, sc := range codes {
+			if _, ok := f.value(sc); ok {
+				fields = append(fields, f)
+				break
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,105 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test the full field list and order; update deliberately when adding fields
+func TestMetadataFieldsLocked(t *testing.T) {
+	expected := []string{"code", "type", "short", "long", "framework", "registration"}
+	var names []string
+	for _, f := range metadataFields {
+		names = append(names, f.name)
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected metadata fields %v, got %v", expected, names)
+	}
+
+	// Every StatusCode field must be listed so --full-metadata can show it
+	st := reflect.TypeOf(StatusCode{})
+	for i := 0; i < st.NumField(); i++ {
+		name, _, _ := strings.Cut(st.Field(i).Tag.Get("json"), ",")
+		found := false
+		for _, f := range metadataFields {
+			found = found || f.name == name
+		}
+		if !found {
+			t.Errorf("StatusCode field %s is missing from metadataFields", name)
+		}
+	}
+}
+
+// Test the table and CSV columns grow with the populated fields
+func TestPopulatedFields(t *testing.T) {
+	results, err := processInputs("404", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(fields []metadataField) string {
+		var s []string
+		for _, f := range fields {
+			s = append(s, f.name)
+		}
+		return strings.Join(s, ",")
+	}
+
+	if got := names(populatedFields(prepareOutputs(results, false, false))); got != "code,type,short" {
+		t.Errorf("Unexpected fields for short output: %s", got)
+	}
+	full := applyFramework(prepareOutputs(results, false, true), "express")
+	if got := names(populatedFields(full)); got != "code,type,short,long,framework" {
+		t.Errorf("Unexpected fields for full output: %s", got)
+	}
+
+	var buf bytes.Buffer
+	printCSVWith(&buf, full, csvOptions{fields: populatedFields(full)})
+	expected := "Code,Type,Short,Long,Framework\n404,Client Error,Not Found,Requested resource could not be found,res.sendStatus(404)\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	printTableFields(&buf, full, "compact", populatedFields(full))
+	if !strings.HasPrefix(buf.String(), "CODE  TYPE          SHORT      LONG                                   FRAMEWORK\n") {
+		t.Errorf("Unexpected table:\n%s", buf.String())
+	}
+}
+
+// Test text output labels every present field
+func TestPrintTextFields(t *testing.T) {
+	results, err := processInputs("404", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printText(&buf, applyFramework(prepareOutputs(results, false, true), "rails"))
+	expected := "Code: 404\nType: Client Error\nShort: Not Found\nLong: Requested resource could not be found\nFramework: head :not_found\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
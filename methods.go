@@ -0,0 +1,207 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// httpMethod describes a request method and its properties
+type httpMethod struct {
+	Method      string `json:"method" xml:"method" yaml:"method" toml:"method"`
+	Safe        bool   `json:"safe" xml:"safe" yaml:"safe" toml:"safe"`
+	Idempotent  bool   `json:"idempotent" xml:"idempotent" yaml:"idempotent" toml:"idempotent"`
+	Cacheable   bool   `json:"cacheable" xml:"cacheable" yaml:"cacheable" toml:"cacheable"`
+	Description string `json:"description" xml:"description" yaml:"description" toml:"description"`
+	Reference   string `json:"reference" xml:"reference" yaml:"reference" toml:"reference"`
+}
+
+// httpMethodCollection wraps methods for XML output
+type httpMethodCollection struct {
+	XMLName xml.Name     `xml:"http_methods"`
+	Methods []httpMethod `xml:"http_method"`
+}
+
+// httpMethods are the methods defined by RFC 9110 and RFC 5789. POST and
+// PATCH responses can be cached only with explicit freshness information,
+// so they are not marked cacheable
+var httpMethods = []httpMethod{
+	{"GET", true, true, true, "Transfer a current representation of the target resource", "RFC 9110, Section 9.3.1"},
+	{"HEAD", true, true, true, "Same as GET, but only transfer the status line and header section", "RFC 9110, Section 9.3.2"},
+	{"POST", false, false, false, "Perform resource-specific processing on the request content", "RFC 9110, Section 9.3.3"},
+	{"PUT", false, true, false, "Replace all current representations of the target resource with the request content", "RFC 9110, Section 9.3.4"},
+	{"PATCH", false, false, false, "Apply a set of partial modifications to the target resource", "RFC 5789"},
+	{"DELETE", false, true, false, "Remove all current representations of the target resource", "RFC 9110, Section 9.3.5"},
+	{"OPTIONS", true, true, false, "Describe the communication options for the target resource", "RFC 9110, Section 9.3.7"},
+	{"TRACE", true, true, false, "Perform a message loop-back test along the path to the target resource", "RFC 9110, Section 9.3.8"},
+	{"CONNECT", false, false, false, "Establish a tunnel to the server identified by the target resource", "RFC 9110, Section 9.3.6"},
+}
+
+// methodSeeAlso maps status codes about request methods to a pointer at
+// the methods reference, shown in text output
+var methodSeeAlso = map[int]string{
+	405: "httpstatus methods",
+	501: "httpstatus methods",
+}
+
+// runMethods implements "httpstatus methods [flags] [method...]"
+func runMethods(args []string, w io.Writer) error {
+	fs := newFlagSet("methods")
+	rf := newReferenceFlags(fs, "Search the method names and descriptions")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := rf.validate(); err != nil {
+		return err
+	}
+
+	methods, err := findMethods(positional)
+	if err != nil {
+		return err
+	}
+	if *rf.search != "" {
+		methods = searchMethods(methods, *rf.search)
+		if len(methods) == 0 {
+			return &cliError{Kind: errNotFound, Message: "No HTTP methods found matching your criteria", Input: *rf.search}
+		}
+	}
+
+	docs := make([]interface{}, len(methods))
+	for i, m := range methods {
+		docs[i] = m
+	}
+	rf.render(w, referenceSet{
+		records: methods,
+		xml:     httpMethodCollection{Methods: methods},
+		docs:    docs,
+		columns: methodColumns,
+		rows:    methodRows(methods),
+		text:    func(w io.Writer) { printMethodsText(w, methods) },
+		toml:    func(w io.Writer) { printMethodsTOML(w, methods) },
+	})
+	return nil
+}
+
+// findMethods returns the named methods, case-insensitively and in the
+// order given, or every method when none are named
+func findMethods(names []string) ([]httpMethod, error) {
+	var tokens []string
+	for _, name := range names {
+		for _, token := range strings.Split(name, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return httpMethods, nil
+	}
+
+	var methods []httpMethod
+	for _, token := range tokens {
+		m, found := findMethod(token)
+		if !found {
+			return nil, &cliError{Kind: errNotFound, Message: fmt.Sprintf("unknown HTTP method: '%s'", token), Input: token}
+		}
+		methods = append(methods, m)
+	}
+	return methods, nil
+}
+
+// findMethod looks up a method by name, ignoring case
+func findMethod(name string) (httpMethod, bool) {
+	for _, m := range httpMethods {
+		if strings.EqualFold(m.Method, name) {
+			return m, true
+		}
+	}
+	return httpMethod{}, false
+}
+
+// searchMethods returns the methods whose name or description contains
+// the term, ignoring case
+func searchMethods(methods []httpMethod, term string) []httpMethod {
+	lowerTerm := strings.ToLower(term)
+	var results []httpMethod
+	for _, m := range methods {
+		if strings.Contains(strings.ToLower(m.Method), lowerTerm) ||
+			strings.Contains(strings.ToLower(m.Description), lowerTerm) {
+			results = append(results, m)
+		}
+	}
+	return results
+}
+
+// methodColumns are the table and CSV columns for methods
+var methodColumns = []string{"method", "safe", "idempotent", "cacheable", "description", "reference"}
+
+// methodRows returns a row of cells per method
+func methodRows(methods []httpMethod) [][]string {
+	rows := make([][]string, len(methods))
+	for i, m := range methods {
+		rows[i] = []string{m.Method, strconv.FormatBool(m.Safe), strconv.FormatBool(m.Idempotent), strconv.FormatBool(m.Cacheable), m.Description, m.Reference}
+	}
+	return rows
+}
+
+// yesNo formats a property for text output
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// printMethodsText outputs one labelled line per property, like printText
+func printMethodsText(w io.Writer, methods []httpMethod) {
+	for i, m := range methods {
+		if i > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprintf(w, "Method: %s\n", m.Method)
+		fmt.Fprintf(w, "Safe: %s\n", yesNo(m.Safe))
+		fmt.Fprintf(w, "Idempotent: %s\n", yesNo(m.Idempotent))
+		fmt.Fprintf(w, "Cacheable: %s\n", yesNo(m.Cacheable))
+		fmt.Fprintf(w, "Description: %s\n", m.Description)
+		fmt.Fprintf(w, "Reference: %s\n", m.Reference)
+	}
+}
+
+// printMethodsTOML outputs a [[method]] table per method, in order
+func printMethodsTOML(w io.Writer, methods []httpMethod) {
+	enc := toml.NewEncoder(w)
+	enc.Indent = ""
+	if err := enc.Encode(struct {
+		Methods []httpMethod `toml:"method"`
+	}{methods}); err != nil {
+		fatalf("TOML error: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Test every method has a description and a reference, and the properties
+// agree with RFC 9110: safe methods are idempotent
+func TestHTTPMethods(t *testing.T) {
+	if len(httpMethods) != 9 {
+		t.Errorf("Expected 9 methods, got %d", len(httpMethods))
+	}
+	for _, m := range httpMethods {
+		if m.Description == "" || m.Reference == "" {
+			t.Errorf("Incomplete method %+v", m)
+		}
+		if m.Safe && !m.Idempotent {
+			t.Errorf("%s is safe but not idempotent", m.Method)
+		}
+	}
+}
+
+// Test a named method is returned on its own, in any case
+func TestMethodsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runMethods([]string{"patch", "--json"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var methods []httpMethod
+	if err := json.Unmarshal(buf.Bytes(), &methods); err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 1 || methods[0].Method != "PATCH" || methods[0].Idempotent {
+		t.Errorf("Expected just PATCH, got %+v", methods)
+	}
+
+	if err := runMethods([]string{"FETCH"}, &buf); err == nil {
+		t.Error("Expected an unknown method to fail")
+	}
+}
+
+// Test --search matches names and descriptions
+func TestMethodsSearch(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runMethods([]string{"--search", "loop-back", "--csv"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "method,safe,idempotent,cacheable,description,reference\n" +
+		"TRACE,true,true,false,Perform a message loop-back test along the path to the target resource,\"RFC 9110, Section 9.3.8\"\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+
+	if err := runMethods([]string{"-s", "teapot"}, &buf); err == nil {
+		t.Error("Expected a search with no matches to fail")
+	}
+}
+
+// Test every format renders each method
+func TestMethodsFormats(t *testing.T) {
+	for _, format := range referenceFormats {
+		var buf bytes.Buffer
+		if err := runMethods([]string{"get,delete", "--" + format}, &buf); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "GET") || !strings.Contains(out, "DELETE") || strings.Contains(out, "POST") {
+			t.Errorf("%s: expected GET and DELETE only:\n%s", format, out)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := runMethods([]string{"HEAD"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "Method: HEAD\nSafe: yes\nIdempotent: yes\nCacheable: yes\n") {
+		t.Errorf("Unexpected text output:\n%s", buf.String())
+	}
+}
+
+// Test the methods and headers TOML parses back into the tables it was
+// made from, quotes, backslashes and control characters included
+func TestReferenceTOMLRoundTrip(t *testing.T) {
+	methods := []httpMethod{{"BREW", false, false, false, `Brew "coffee"` + "\nwith\ttabs\x07", `C:\pot`}}
+	var buf bytes.Buffer
+	printMethodsTOML(&buf, methods)
+	var decodedMethods struct {
+		Methods []httpMethod `toml:"method"`
+	}
+	if _, err := toml.Decode(buf.String(), &decodedMethods); err != nil {
+		t.Fatalf("Invalid TOML: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(decodedMethods.Methods, methods) {
+		t.Errorf("Expected %+v, got %+v", methods, decodedMethods.Methods)
+	}
+
+	headers := []httpHeader{{Name: "X-Pot", Direction: "response", Description: `Say "when"`, Example: `a\b`, Statuses: []int{418}}}
+	buf.Reset()
+	printHeadersTOML(&buf, headers)
+	var decodedHeaders struct {
+		Headers []httpHeader `toml:"header"`
+	}
+	if _, err := toml.Decode(buf.String(), &decodedHeaders); err != nil {
+		t.Fatalf("Invalid TOML: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(decodedHeaders.Headers, headers) {
+		t.Errorf("Expected %+v, got %+v", headers, decodedHeaders.Headers)
+	}
+}
+
+// Test codes about methods point at the methods reference in text output
+func TestMethodSeeAlso(t *testing.T) {
+	sc, _ := findStatusCode(405)
+	var buf bytes.Buffer
+	printTextOptions(&buf, []StatusCode{sc}, textOptions{related: true})
+	if !strings.HasSuffix(buf.String(), "See: httpstatus methods\n") {
+		t.Errorf("Expected a pointer to the methods reference:\n%s", buf.String())
+	}
+
+	// Without --related the text output keeps its original lines
+	buf.Reset()
+	printText(&buf, []StatusCode{sc})
+	if strings.Contains(buf.String(), "See:") || strings.Contains(buf.String(), "Headers:") {
+		t.Errorf("Expected no related lines without --related:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,164 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the histogram upper bounds in seconds
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestKey identifies a request counter series
+type requestKey struct {
+	path string
+	code int
+}
+
+// latencyHistogram accumulates request durations for one route
+type latencyHistogram struct {
+	counts []uint64
+	sum    float64
+	total  uint64
+}
+
+// serverMetrics collects request metrics for the serve subcommand
+type serverMetrics struct {
+	datasetSize int
+
+	mu       sync.Mutex
+	requests map[requestKey]uint64
+	latency  map[string]*latencyHistogram
+}
+
+// newServerMetrics creates an empty metrics collector
+func newServerMetrics(datasetSize int) *serverMetrics {
+	return &serverMetrics{
+		datasetSize: datasetSize,
+		requests:    make(map[requestKey]uint64),
+		latency:     make(map[string]*latencyHistogram),
+	}
+}
+
+// observe records a completed request
+func (m *serverMetrics) observe(path string, code int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{path, code}]++
+
+	h, ok := m.latency[path]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latency[path] = h
+	}
+	seconds := d.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.total++
+}
+
+// middleware records every request, labelled by the matched route pattern
+// rather than the raw path to keep the number of series bounded
+func (m *serverMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// ServeMux records the matched pattern, e.g. "GET /status/{code}"
+		path := "other"
+		if _, pattern, ok := strings.Cut(r.Pattern, " "); ok {
+			path = pattern
+		} else if r.Pattern != "" {
+			path = r.Pattern
+		}
+		m.observe(path, rec.status, time.Since(start))
+	})
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *serverMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.write(w)
+}
+
+// write outputs all metrics in a stable order
+func (m *serverMetrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s_http_requests_total Total HTTP requests by route and response code.\n", AppName)
+	fmt.Fprintf(w, "# TYPE %s_http_requests_total counter\n", AppName)
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s_http_requests_total{path=\"%s\",code=\"%d\"} %d\n", AppName, escapeLabel(k.path), k.code, m.requests[k])
+	}
+
+	fmt.Fprintf(w, "# HELP %s_http_request_duration_seconds HTTP request latency by route.\n", AppName)
+	fmt.Fprintf(w, "# TYPE %s_http_request_duration_seconds histogram\n", AppName)
+	paths := make([]string, 0, len(m.latency))
+	for p := range m.latency {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		h := m.latency[p]
+		label := escapeLabel(p)
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(w, "%s_http_request_duration_seconds_bucket{path=\"%s\",le=\"%s\"} %d\n",
+				AppName, label, strconv.FormatFloat(bound, 'f', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_http_request_duration_seconds_bucket{path=\"%s\",le=\"+Inf\"} %d\n", AppName, label, h.total)
+		fmt.Fprintf(w, "%s_http_request_duration_seconds_sum{path=\"%s\"} %s\n", AppName, label, strconv.FormatFloat(h.sum, 'f', -1, 64))
+		fmt.Fprintf(w, "%s_http_request_duration_seconds_count{path=\"%s\"} %d\n", AppName, label, h.total)
+	}
+
+	fmt.Fprintf(w, "# HELP %s_dataset_codes Number of status codes in the loaded dataset.\n", AppName)
+	fmt.Fprintf(w, "# TYPE %s_dataset_codes gauge\n", AppName)
+	fmt.Fprintf(w, "%s_dataset_codes %d\n", AppName, m.datasetSize)
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
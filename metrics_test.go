@@ -0,0 +1,137 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test scraping /metrics after a few requests
+func TestMetricsEndpoint(t *testing.T) {
+	metrics := newServerMetrics(len(statusCodes))
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics)
+	mux.Handle("/", metrics.middleware(newAPIServer(statusCodes).routes()))
+
+	for _, target := range []string{"/status/200", "/status/404", "/status/999", "/types", "/no-such-page"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type: %s", ct)
+	}
+
+	body := rec.Body.String()
+	expected := []string{
+		"# TYPE httpstatus_http_requests_total counter",
+		`httpstatus_http_requests_total{path="/status/{code}",code="200"} 2`,
+		`httpstatus_http_requests_total{path="/status/{code}",code="404"} 1`,
+		`httpstatus_http_requests_total{path="/types",code="200"} 1`,
+		`httpstatus_http_requests_total{path="other",code="404"} 1`,
+		"# TYPE httpstatus_http_request_duration_seconds histogram",
+		`httpstatus_http_request_duration_seconds_bucket{path="/status/{code}",le="+Inf"} 3`,
+		`httpstatus_http_request_duration_seconds_count{path="/status/{code}"} 3`,
+		"# TYPE httpstatus_dataset_codes gauge",
+		fmt.Sprintf("httpstatus_dataset_codes %d", len(statusCodes)),
+	}
+	for _, exp := range expected {
+		if !strings.Contains(body, exp) {
+			t.Errorf("Expected metrics to contain: %s\nGot:\n%s", exp, body)
+		}
+	}
+
+	// Raw paths must not become labels
+	if strings.Contains(body, "/status/999") || strings.Contains(body, "/no-such-page") {
+		t.Errorf("Raw request paths leaked into labels:\n%s", body)
+	}
+}
+
+// Test histogram buckets are cumulative
+func TestMetricsHistogram(t *testing.T) {
+	m := newServerMetrics(0)
+	m.observe("/types", 200, 3*time.Millisecond)
+	m.observe("/types", 200, 80*time.Millisecond)
+	m.observe("/types", 200, 20*time.Second)
+
+	var buf bytes.Buffer
+	m.write(&buf)
+	output := buf.String()
+
+	expected := []string{
+		`httpstatus_http_request_duration_seconds_bucket{path="/types",le="0.005"} 1`,
+		`httpstatus_http_request_duration_seconds_bucket{path="/types",le="0.1"} 2`,
+		`httpstatus_http_request_duration_seconds_bucket{path="/types",le="10"} 2`,
+		`httpstatus_http_request_duration_seconds_bucket{path="/types",le="+Inf"} 3`,
+		`httpstatus_http_request_duration_seconds_sum{path="/types"} 20.083`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected histogram line: %s\nGot:\n%s", exp, output)
+		}
+	}
+}
+
+// Test label values are escaped
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("Unexpected escaping: %s", got)
+	}
+}
+
+// Test a rate-limited request is labelled with its route whether metrics
+// share the main listener or have their own
+func TestMetricsRateLimitedLabel(t *testing.T) {
+	for _, separate := range []bool{false, true} {
+		api := newAPIServer(statusCodes)
+		api.limiter = newRateLimiter(1, time.Minute, nil)
+		handler, metricsHandler := withMetrics(api.routes(), newServerMetrics(len(statusCodes)), separate)
+		if !separate {
+			metricsHandler = handler
+		}
+
+		for i := 0; i < 2; i++ {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/status/404", nil))
+		}
+		rec := httptest.NewRecorder()
+		metricsHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+		body := rec.Body.String()
+		for _, exp := range []string{
+			`httpstatus_http_requests_total{path="/status/{code}",code="200"} 1`,
+			`httpstatus_http_requests_total{path="/status/{code}",code="429"} 1`,
+		} {
+			if !strings.Contains(body, exp) {
+				t.Errorf("separate %t: expected metrics to contain: %s\nGot:\n%s", separate, exp, body)
+			}
+		}
+	}
+}
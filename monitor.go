@@ -0,0 +1,278 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// monitorConfig holds the settings for the monitor subcommand
+type monitorConfig struct {
+	URL         string
+	Interval    time.Duration
+	Count       int
+	UntilStatus int
+	MaxDuration time.Duration
+	Timeout     time.Duration
+	NDJSON      bool
+}
+
+// probeResult is the outcome of a single monitor probe
+type probeResult struct {
+	Time      time.Time `json:"time"`
+	URL       string    `json:"url"`
+	Code      int       `json:"code,omitempty"`
+	Short     string    `json:"short,omitempty"`
+	LatencyMS int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// codeCount is one entry of the monitor summary's code distribution
+type codeCount struct {
+	Code  int    `json:"code"`
+	Short string `json:"short,omitempty"`
+	Count int    `json:"count"`
+}
+
+// monitorSummary aggregates all probes of a monitor run
+type monitorSummary struct {
+	Probes       int         `json:"probes"`
+	Up           int         `json:"up"`
+	Errors       int         `json:"errors"`
+	UptimePct    float64     `json:"uptime_pct"`
+	Distribution []codeCount `json:"distribution"`
+	StatusSeen   bool        `json:"status_seen,omitempty"`
+}
+
+// runMonitor implements "httpstatus monitor URL [flags]"
+func runMonitor(args []string, w io.Writer) error {
+	fs := newFlagSet("monitor")
+	cfg := monitorConfig{}
+	fs.DurationVar(&cfg.Interval, "interval", 30*time.Second, "Time between probes")
+	fs.IntVar(&cfg.Count, "count", 0, "Number of probes to run (0 for unlimited)")
+	fs.IntVar(&cfg.UntilStatus, "until-status", 0, "Exit as soon as this status code is observed")
+	fs.DurationVar(&cfg.MaxDuration, "max-duration", 0, "Maximum time to keep monitoring (0 for unlimited)")
+	fs.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "Timeout for each probe")
+	fs.BoolVar(&cfg.NDJSON, "ndjson", false, "Output one JSON object per probe")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("monitor requires exactly one URL")
+	}
+	cfg.URL = positional[0]
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("invalid interval: %s - must be positive", cfg.Interval)
+	}
+	if cfg.Count < 0 {
+		return fmt.Errorf("invalid count: %d - must be 0 or more", cfg.Count)
+	}
+	if cfg.MaxDuration < 0 {
+		return fmt.Errorf("invalid max duration: %s - must be 0 or more", cfg.MaxDuration)
+	}
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("invalid timeout: %s - must be 0 or more", cfg.Timeout)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	summary, err := monitor(ctx, cfg, w)
+	if err != nil {
+		return err
+	}
+	printMonitorSummary(w, summary, cfg.NDJSON)
+	if cfg.UntilStatus != 0 && !summary.StatusSeen {
+		return &cliError{
+			Kind:    errNotFound,
+			Message: fmt.Sprintf("status %d not observed", cfg.UntilStatus),
+			Input:   strconv.Itoa(cfg.UntilStatus),
+		}
+	}
+	return nil
+}
+
+// checkMonitorURL rejects a URL that no probe could ever reach, so
+// monitor fails at once instead of logging the same error forever
+func checkMonitorURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid URL: %v", err), Input: raw}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid URL: '%s' - must start with http:// or https://", raw), Input: raw}
+	}
+	if u.Host == "" {
+		return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid URL: '%s' - has no host", raw), Input: raw}
+	}
+	return nil
+}
+
+// monitor probes the configured URL until the count, deadline or
+// expected status is reached, or the context is cancelled. It fails
+// before probing when the URL can never work
+func monitor(ctx context.Context, cfg monitorConfig, w io.Writer) (monitorSummary, error) {
+	if err := checkMonitorURL(cfg.URL); err != nil {
+		return monitorSummary{}, err
+	}
+	if cfg.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.MaxDuration)
+		defer cancel()
+	}
+
+	client := &http.Client{
+		Timeout: cfg.Timeout,
+		// Report the status the URL itself returns rather than following redirects
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	var summary monitorSummary
+	counts := make(map[int]int)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		result := probe(ctx, client, cfg.URL)
+		// A probe cut short by cancellation is not a real observation
+		if ctx.Err() != nil {
+			break
+		}
+		printProbe(w, result, cfg.NDJSON)
+
+		summary.Probes++
+		if result.Error != "" {
+			summary.Errors++
+		} else {
+			counts[result.Code]++
+			if result.Code < 400 {
+				summary.Up++
+			}
+		}
+
+		if cfg.UntilStatus != 0 && result.Code == cfg.UntilStatus {
+			summary.StatusSeen = true
+			break
+		}
+		if cfg.Count > 0 && summary.Probes >= cfg.Count {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if summary.Probes > 0 {
+		summary.UptimePct = float64(summary.Up) * 100 / float64(summary.Probes)
+	}
+	for code, count := range counts {
+		cc := codeCount{Code: code, Count: count}
+		if sc, found := findStatusCode(code); found && sc.Short != nil {
+			cc.Short = *sc.Short
+		}
+		summary.Distribution = append(summary.Distribution, cc)
+	}
+	sort.Slice(summary.Distribution, func(i, j int) bool {
+		return summary.Distribution[i].Code < summary.Distribution[j].Code
+	})
+
+	return summary, nil
+}
+
+// probe performs a single GET request and records the outcome
+func probe(ctx context.Context, client *http.Client, url string) probeResult {
+	result := probeResult{Time: time.Now(), URL: url}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	resp, err := client.Do(req)
+	result.LatencyMS = time.Since(result.Time).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	result.Code = resp.StatusCode
+	if sc, found := findStatusCode(resp.StatusCode); found && sc.Short != nil {
+		result.Short = *sc.Short
+	}
+	return result
+}
+
+// printProbe outputs a single probe result as text or NDJSON
+func printProbe(w io.Writer, r probeResult, ndjson bool) {
+	if ndjson {
+		data, _ := json.Marshal(r)
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	timestamp := r.Time.Format(time.RFC3339)
+	if r.Error != "" {
+		fmt.Fprintf(w, "%s  ERROR  %s\n", timestamp, r.Error)
+		return
+	}
+	fmt.Fprintf(w, "%s  %d %s  %dms\n", timestamp, r.Code, r.Short, r.LatencyMS)
+}
+
+// printMonitorSummary outputs the summary of a monitor run
+func printMonitorSummary(w io.Writer, s monitorSummary, ndjson bool) {
+	if ndjson {
+		data, _ := json.Marshal(struct {
+			Summary monitorSummary `json:"summary"`
+		}{s})
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Probes: %d  Errors: %d  Uptime: %.1f%%\n", s.Probes, s.Errors, s.UptimePct)
+	for _, cc := range s.Distribution {
+		fmt.Fprintf(w, "  %d %s: %d\n", cc.Code, cc.Short, cc.Count)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Test monitor stops after the requested number of probes
+func TestMonitorCount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	cfg := monitorConfig{URL: srv.URL, Interval: time.Millisecond, Count: 3, Timeout: time.Second}
+	summary, err := monitor(context.Background(), cfg, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if summary.Probes != 3 || summary.Up != 3 || summary.UptimePct != 100 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if len(summary.Distribution) != 1 || summary.Distribution[0].Code != 200 || summary.Distribution[0].Short != "OK" {
+		t.Errorf("Unexpected distribution: %+v", summary.Distribution)
+	}
+	if got := strings.Count(buf.String(), "200 OK"); got != 3 {
+		t.Errorf("Expected 3 probe lines, got %d:\n%s", got, buf.String())
+	}
+}
+
+// Test monitor exits as soon as the expected status is observed
+func TestMonitorUntilStatus(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	cfg := monitorConfig{URL: srv.URL, Interval: time.Millisecond, UntilStatus: 200, Timeout: time.Second}
+	summary, err := monitor(context.Background(), cfg, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !summary.StatusSeen || summary.Probes != 3 {
+		t.Errorf("Expected status seen on third probe, got %+v", summary)
+	}
+	if summary.Up != 1 {
+		t.Errorf("Expected 1 successful probe, got %d", summary.Up)
+	}
+}
+
+// Test monitor respects the maximum duration
+func TestMonitorMaxDuration(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	cfg := monitorConfig{URL: srv.URL, Interval: 10 * time.Millisecond, UntilStatus: 200, MaxDuration: 50 * time.Millisecond, Timeout: time.Second}
+	start := time.Now()
+	summary, err := monitor(context.Background(), cfg, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("Monitor ran past its maximum duration")
+	}
+	if summary.StatusSeen || summary.Up != 0 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+// Test monitor NDJSON output is one valid object per line
+func TestMonitorNDJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	err := runMonitor([]string{srv.URL, "--interval", "1ms", "--count", "2", "--ndjson"}, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Expected 2 probes and a summary, got %d lines:\n%s", len(lines), buf.String())
+	}
+
+	var result probeResult
+	if err := json.Unmarshal([]byte(lines[0]), &result); err != nil {
+		t.Fatalf("Invalid NDJSON probe: %v\n%s", err, lines[0])
+	}
+	if result.Code != 418 || result.Short != "I'm a teapot" {
+		t.Errorf("Unexpected probe result: %+v", result)
+	}
+	if !strings.Contains(lines[2], "\"summary\"") {
+		t.Errorf("Expected summary object, got: %s", lines[2])
+	}
+}
+
+// Test monitor reports connection errors without aborting
+func TestMonitorConnectionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	var buf bytes.Buffer
+	cfg := monitorConfig{URL: url, Interval: time.Millisecond, Count: 2, Timeout: time.Second}
+	summary, err := monitor(context.Background(), cfg, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if summary.Errors != 2 || summary.UptimePct != 0 {
+		t.Errorf("Expected 2 errors, got %+v", summary)
+	}
+	if !strings.Contains(buf.String(), "ERROR") {
+		t.Errorf("Expected error lines in output:\n%s", buf.String())
+	}
+}
+
+// Test monitor argument validation
+func TestRunMonitorArgs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runMonitor(nil, &buf); err == nil {
+		t.Error("Expected error when no URL given")
+	}
+	if err := runMonitor([]string{"http://a", "http://b"}, &buf); err == nil {
+		t.Error("Expected error when two URLs given")
+	}
+	if err := runMonitor([]string{"http://a", "--interval", "0s"}, &buf); err == nil {
+		t.Error("Expected error for zero interval")
+	}
+	for _, bad := range [][]string{{"--count", "-1"}, {"--max-duration", "-1s"}, {"--timeout", "-1s"}} {
+		if err := runMonitor(append([]string{"http://a"}, bad...), &buf); err == nil || !strings.Contains(err.Error(), "must be 0 or more") {
+			t.Errorf("%v: expected a negative value to be rejected, got %v", bad, err)
+		}
+	}
+}
+
+// Test a URL that can never work fails at once, even with no probe limit
+func TestMonitorBadURL(t *testing.T) {
+	for _, url := range []string{"ftp://example.com/", "example.com", "http://", "http://%zz"} {
+		var buf bytes.Buffer
+		cfg := monitorConfig{URL: url, Interval: time.Millisecond, Timeout: time.Second}
+		summary, err := monitor(context.Background(), cfg, &buf)
+		var ce *cliError
+		if !errors.As(err, &ce) || ce.Kind != errInvalidInput {
+			t.Errorf("%s: expected an invalid input error, got %v", url, err)
+		}
+		if summary.Probes != 0 || buf.Len() != 0 {
+			t.Errorf("%s: expected no probes, got %+v:\n%s", url, summary, buf.String())
+		}
+	}
+}
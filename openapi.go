@@ -0,0 +1,183 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonObject is a generic JSON object used to build schema documents
+type jsonObject = map[string]interface{}
+
+// structSchema builds a JSON Schema for a struct from its json tags;
+// pointer and omitempty fields are optional, everything else is required
+func structSchema(t reflect.Type) jsonObject {
+	properties := jsonObject{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		ft := f.Type
+		optional := strings.Contains(opts, "omitempty")
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+			optional = true
+		}
+
+		properties[name] = typeSchema(ft)
+		if !optional {
+			required = append(required, name)
+		}
+	}
+
+	return jsonObject{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema maps a Go type to its JSON Schema
+func typeSchema(t reflect.Type) jsonObject {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonObject{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return jsonObject{"type": "number"}
+	case reflect.Bool:
+		return jsonObject{"type": "boolean"}
+	case reflect.Slice, reflect.Array:
+		return jsonObject{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return jsonObject{"type": "string"}
+	}
+}
+
+// schemaRef returns a reference to a component schema
+func schemaRef(name string) jsonObject {
+	return jsonObject{"$ref": "#/components/schemas/" + name}
+}
+
+// openAPIDocument builds an OpenAPI 3.1 description of the API routes;
+// rateLimited documents the 429 --rate-limit adds to every route
+func openAPIDocument(routes []apiRoute, rateLimited bool) jsonObject {
+	paths := jsonObject{}
+	for _, route := range routes {
+		parameters := []jsonObject{}
+		for _, p := range route.params {
+			parameters = append(parameters, jsonObject{
+				"name":        p.name,
+				"in":          p.in,
+				"description": p.description,
+				"required":    p.required,
+				"schema":      jsonObject{"type": p.schemaType},
+			})
+		}
+
+		content := jsonObject{"application/json": jsonObject{"schema": schemaRef(route.response)}}
+		if route.response == "StatusCodeList" {
+			for _, f := range responseFormats[1:] {
+				content[f.mediaType] = jsonObject{"schema": jsonObject{"type": "string"}}
+			}
+		}
+
+		responses := jsonObject{
+			"200": jsonObject{"description": "OK", "content": content},
+		}
+		if route.cacheable {
+			responses["304"] = jsonObject{"description": "Not Modified"}
+		}
+		errors := route.errors
+		if rateLimited {
+			errors = append(slices.Clone(errors), http.StatusTooManyRequests)
+		}
+		for _, code := range errors {
+			responses[strconv.Itoa(code)] = jsonObject{
+				"description": http.StatusText(code),
+				"content": jsonObject{
+					"application/json": jsonObject{"schema": schemaRef("Error")},
+				},
+			}
+		}
+
+		paths[route.path] = jsonObject{
+			"get": jsonObject{
+				"operationId": route.operationID,
+				"summary":     route.summary,
+				"parameters":  parameters,
+				"responses":   responses,
+			},
+		}
+	}
+
+	statusCodeSchema := structSchema(reflect.TypeOf(StatusCode{}))
+	return jsonObject{
+		"openapi": "3.1.0",
+		"info": jsonObject{
+			"title":       AppName + " API",
+			"description": "Look up HTTP status codes",
+			"version":     AppVersion,
+			"license": jsonObject{
+				"name":       "GPL-3.0-or-later",
+				"identifier": "GPL-3.0-or-later",
+			},
+		},
+		"externalDocs": jsonObject{"url": GitHubURL},
+		"paths":        paths,
+		"components": jsonObject{
+			"schemas": jsonObject{
+				"StatusCode":     statusCodeSchema,
+				"StatusCodeList": jsonObject{"type": "array", "items": schemaRef("StatusCode")},
+				"TypeList":       jsonObject{"type": "array", "items": jsonObject{"type": "string"}},
+				"Error":          structSchema(reflect.TypeOf(apiError{})),
+				"Health":         structSchema(reflect.TypeOf(healthStatus{})),
+				"OpenAPI":        jsonObject{"type": "object"},
+			},
+		},
+	}
+}
+
+// handleOpenAPI serves the OpenAPI description of the API
+func (s *apiServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	rateLimited := s.limiter != nil
+	if s.notModified(w, r, fmt.Sprintf("openapi %s;rate-limit=%t", AppVersion, rateLimited)) {
+		return
+	}
+	writeJSON(w, http.StatusOK, openAPIDocument(s.apiRoutes(), rateLimited))
+}
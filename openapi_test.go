@@ -0,0 +1,271 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fetchOpenAPI retrieves and decodes the served OpenAPI document
+func fetchOpenAPI(t *testing.T) map[string]interface{} {
+	t.Helper()
+	rec := serveRequest(t, "GET", "/openapi.json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON document: %v", err)
+	}
+	return doc
+}
+
+// collectRefs gathers every $ref value in a decoded JSON document
+func collectRefs(v interface{}, refs *[]string) {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		for k, child := range node {
+			if k == "$ref" {
+				*refs = append(*refs, child.(string))
+				continue
+			}
+			collectRefs(child, refs)
+		}
+	case []interface{}:
+		for _, child := range node {
+			collectRefs(child, refs)
+		}
+	}
+}
+
+// resolveRef follows a local JSON pointer reference within the document
+func resolveRef(doc interface{}, ref string) (interface{}, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+	node := doc
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if node, ok = n[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, node != nil
+}
+
+// Test the document's structure: every operation is well formed and has
+// responses, and every $ref resolves. This is not a full schema validation.
+func TestOpenAPIDocumentStructure(t *testing.T) {
+	doc := fetchOpenAPI(t)
+
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("Expected openapi 3.1.0, got %v", doc["openapi"])
+	}
+	info, ok := doc["info"].(map[string]interface{})
+	if !ok || info["title"] == "" || info["version"] != AppVersion {
+		t.Errorf("Invalid info object: %v", doc["info"])
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok || len(paths) == 0 {
+		t.Fatal("Document has no paths")
+	}
+
+	pathParam := regexp.MustCompile(`\{([^}]+)\}`)
+	operationIDs := make(map[string]bool)
+	for path, item := range paths {
+		if !strings.HasPrefix(path, "/") {
+			t.Errorf("Path %s must start with /", path)
+		}
+		var ops []map[string]interface{}
+		for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+			if op, ok := item.(map[string]interface{})[method].(map[string]interface{}); ok {
+				ops = append(ops, op)
+			}
+		}
+		if len(ops) == 0 {
+			t.Errorf("%s: no operations", path)
+		}
+		for _, op := range ops {
+			checkOperation(t, path, op, pathParam, operationIDs)
+		}
+	}
+
+	// Every reference must resolve within the document
+	var refs []string
+	collectRefs(doc, &refs)
+	if len(refs) == 0 {
+		t.Error("Expected schema references in the document")
+	}
+	for _, ref := range refs {
+		if _, ok := resolveRef(doc, ref); !ok {
+			t.Errorf("Unresolved reference: %s", ref)
+		}
+	}
+	for _, ref := range []string{"#/components/schemas/Missing", "#/paths/~1nope", "other.json#/x"} {
+		if _, ok := resolveRef(doc, ref); ok {
+			t.Errorf("Expected %s not to resolve", ref)
+		}
+	}
+}
+
+// checkOperation validates one path operation's id, parameters and responses
+func checkOperation(t *testing.T, path string, op map[string]interface{}, pathParam *regexp.Regexp, operationIDs map[string]bool) {
+	t.Helper()
+	id, _ := op["operationId"].(string)
+	if id == "" || operationIDs[id] {
+		t.Errorf("%s: operationId %q missing or duplicated", path, id)
+	}
+	operationIDs[id] = true
+
+	// Every templated path segment needs a required path parameter
+	declared := make(map[string]bool)
+	params, _ := op["parameters"].([]interface{})
+	for _, p := range params {
+		param := p.(map[string]interface{})
+		in := param["in"].(string)
+		if in != "query" && in != "header" && in != "path" && in != "cookie" {
+			t.Errorf("%s: invalid parameter location %q", path, in)
+		}
+		if param["schema"] == nil {
+			t.Errorf("%s: parameter %v has no schema", path, param["name"])
+		}
+		if in == "path" {
+			if param["required"] != true {
+				t.Errorf("%s: path parameter %v must be required", path, param["name"])
+			}
+			declared[param["name"].(string)] = true
+		}
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		if !declared[m[1]] {
+			t.Errorf("%s: path parameter %s is not declared", path, m[1])
+		}
+	}
+
+	responses, ok := op["responses"].(map[string]interface{})
+	if !ok || len(responses) == 0 {
+		t.Errorf("%s: missing responses", path)
+	}
+	for code, resp := range responses {
+		if n, err := strconv.Atoi(code); err != nil || n < 100 || n > 599 {
+			t.Errorf("%s: invalid response code %s", path, code)
+		}
+		if desc, _ := resp.(map[string]interface{})["description"].(string); desc == "" {
+			t.Errorf("%s: response %s has no description", path, code)
+		}
+	}
+}
+
+// Test the document is generated from the route table
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	doc := fetchOpenAPI(t)
+	paths := doc["paths"].(map[string]interface{})
+
+	routes := newAPIServer(statusCodes).apiRoutes()
+	if len(paths) != len(routes) {
+		t.Errorf("Expected %d paths, got %d", len(routes), len(paths))
+	}
+	for _, route := range routes {
+		if paths[route.path] == nil {
+			t.Errorf("Route %s missing from document", route.path)
+		}
+		// Every documented route is actually served
+		target := strings.Replace(route.path, "{code}", "200", 1)
+		rec := serveRequest(t, "GET", target)
+		if rec.Code != http.StatusOK {
+			t.Errorf("Route %s returned %d", target, rec.Code)
+		}
+
+		// 304 is documented exactly where a matching ETag gets one
+		responses := paths[route.path].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+		_, documented := responses["304"]
+		etag := rec.Header().Get("ETag")
+		if documented != route.cacheable || (etag != "") != route.cacheable {
+			t.Errorf("Route %s: cacheable %t, but 304 documented %t and ETag %q", route.path, route.cacheable, documented, etag)
+		}
+		if etag != "" {
+			req := httptest.NewRequest("GET", target, nil)
+			req.Header.Set("If-None-Match", etag)
+			rec := httptest.NewRecorder()
+			newAPIServer(statusCodes).routes().ServeHTTP(rec, req)
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("Route %s: expected 304 for a matching ETag, got %d", route.path, rec.Code)
+			}
+		}
+		if _, ok := responses["429"]; ok {
+			t.Errorf("Route %s: 429 documented without --rate-limit", route.path)
+		}
+	}
+}
+
+// Test --rate-limit documents 429 on every route
+func TestOpenAPIRateLimit(t *testing.T) {
+	routes := newAPIServer(statusCodes).apiRoutes()
+	doc := openAPIDocument(routes, true)
+	for _, route := range routes {
+		op := doc["paths"].(jsonObject)[route.path].(jsonObject)["get"].(jsonObject)
+		if _, ok := op["responses"].(jsonObject)["429"]; !ok {
+			t.Errorf("Route %s: expected 429 to be documented with --rate-limit", route.path)
+		}
+	}
+}
+
+// Test the StatusCode schema is derived from the struct tags
+func TestStructSchema(t *testing.T) {
+	schema := structSchema(reflect.TypeOf(StatusCode{}))
+	props := schema["properties"].(jsonObject)
+
+	expected := map[string]string{"code": "integer", "type": "string", "short": "string", "long": "string", "framework": "string", "registration": "string"}
+	if len(props) != len(expected) {
+		t.Errorf("Expected %d properties, got %v", len(expected), props)
+	}
+	for name, typ := range expected {
+		prop, ok := props[name].(jsonObject)
+		if !ok || prop["type"] != typ {
+			t.Errorf("Expected property %s of type %s, got %v", name, typ, props[name])
+		}
+	}
+
+	required := schema["required"].([]string)
+	if strings.Join(required, ",") != "code,type" {
+		t.Errorf("Expected code and type to be required, got %v", required)
+	}
+}
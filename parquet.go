@@ -0,0 +1,72 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+)
+
+// parquetCodecs maps the --parquet-compression names to codecs
+var parquetCodecs = map[string]compress.Codec{
+	"snappy": &parquet.Snappy,
+	"zstd":   &parquet.Zstd,
+	"none":   &parquet.Uncompressed,
+}
+
+// parquetCodecNames returns the supported compression names in order
+func parquetCodecNames() []string {
+	names := make([]string, 0, len(parquetCodecs))
+	for name := range parquetCodecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parquetRow is the Parquet schema for a status code; nil descriptions are
+// written as nulls
+type parquetRow struct {
+	Code  int32   `parquet:"code"`
+	Type  string  `parquet:"type"`
+	Short *string `parquet:"short,optional"`
+	Long  *string `parquet:"long,optional"`
+}
+
+// printParquet writes the codes as a Parquet file
+func printParquet(w io.Writer, codes []StatusCode, compression string) error {
+	codec, ok := parquetCodecs[compression]
+	if !ok {
+		return fmt.Errorf("invalid parquet compression: '%s' - must be one of %s", compression, strings.Join(parquetCodecNames(), ", "))
+	}
+
+	rows := make([]parquetRow, len(codes))
+	for i, sc := range codes {
+		rows[i] = parquetRow{Code: int32(sc.Code), Type: sc.Type, Short: sc.Short, Long: sc.Long}
+	}
+	return parquet.Write(w, rows, parquet.Compression(codec))
+}
@@ -0,0 +1,107 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Test Parquet output reads back with nulls for missing descriptions
+func TestParquetRoundTrip(t *testing.T) {
+	results, err := processInputs("200,404,503", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, codes := range [][]StatusCode{
+		prepareOutputs(results, false, true),
+		prepareOutputs(results, false, false),
+		prepareOutputs(results, true, false),
+	} {
+		var buf bytes.Buffer
+		if err := printParquet(&buf, codes, "snappy"); err != nil {
+			t.Fatal(err)
+		}
+
+		rows, err := parquet.Read[parquetRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != len(codes) {
+			t.Fatalf("Expected %d rows, got %d", len(codes), len(rows))
+		}
+		for i, row := range rows {
+			got := StatusCode{Code: int(row.Code), Type: row.Type, Short: row.Short, Long: row.Long}
+			if !reflect.DeepEqual(got, codes[i]) {
+				t.Errorf("Row %d: expected %+v, got %+v", i, codes[i], got)
+			}
+		}
+	}
+}
+
+// Test the schema types and the selected compression codec
+func TestParquetSchemaAndCompression(t *testing.T) {
+	codes := prepareOutputs(statusCodes, false, true)
+	expected := map[string]format.CompressionCodec{
+		"snappy": format.Snappy,
+		"zstd":   format.Zstd,
+		"none":   format.Uncompressed,
+	}
+
+	for name, codec := range expected {
+		var buf bytes.Buffer
+		if err := printParquet(&buf, codes, name); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		schema := f.Schema()
+		if code, ok := schema.Lookup("code"); !ok || code.Node.Type().Kind() != parquet.Int32 || code.Node.Optional() {
+			t.Errorf("%s: expected a required int32 code column", name)
+		}
+		for _, column := range []string{"short", "long"} {
+			leaf, ok := schema.Lookup(column)
+			if !ok || !leaf.Node.Optional() {
+				t.Errorf("%s: expected an optional %s column", name, column)
+			}
+		}
+
+		for _, column := range f.Metadata().RowGroups[0].Columns {
+			if column.MetaData.Codec != codec {
+				t.Errorf("%s: column %v uses codec %v", name, column.MetaData.PathInSchema, column.MetaData.Codec)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := printParquet(&buf, codes, "gzip2"); err == nil {
+		t.Error("Expected an error for an unknown codec")
+	}
+}
@@ -0,0 +1,270 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// errPickCancelled is returned when the user leaves the picker without choosing
+var errPickCancelled = errors.New("selection cancelled")
+
+// pickKey is a decoded key press in the picker
+type pickKey int
+
+const (
+	keyNone pickKey = iota
+	keyRune
+	keyUp
+	keyDown
+	keyTab
+	keyEnter
+	keyBackspace
+	keyEscape
+)
+
+// picker holds the state of the interactive selector
+type picker struct {
+	items    []StatusCode
+	query    []rune
+	matches  []int
+	cursor   int
+	offset   int
+	selected map[int]bool
+}
+
+// newPicker creates a picker showing all items
+func newPicker(items []StatusCode) *picker {
+	p := &picker{items: items, selected: make(map[int]bool)}
+	p.filter()
+	return p
+}
+
+// pickLabel is the text shown and matched for an item
+func pickLabel(sc StatusCode) string {
+	label := strconv.Itoa(sc.Code)
+	if sc.Short != nil {
+		label += " " + *sc.Short
+	}
+	return label
+}
+
+// fuzzyMatch reports whether the query characters appear in order in text,
+// ignoring case
+func fuzzyMatch(text, query string) bool {
+	text = strings.ToLower(text)
+	for _, q := range strings.ToLower(query) {
+		i := strings.IndexRune(text, q)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(string(q)):]
+	}
+	return true
+}
+
+// filter recomputes the matching items for the current query
+func (p *picker) filter() {
+	p.matches = p.matches[:0]
+	for i, sc := range p.items {
+		if fuzzyMatch(pickLabel(sc), string(p.query)) {
+			p.matches = append(p.matches, i)
+		}
+	}
+	p.cursor, p.offset = 0, 0
+}
+
+// handle applies a key press, returning true once a choice has been made
+func (p *picker) handle(key pickKey, r rune) (bool, error) {
+	switch key {
+	case keyEscape:
+		return false, errPickCancelled
+	case keyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case keyDown:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case keyTab:
+		if len(p.matches) > 0 {
+			i := p.matches[p.cursor]
+			p.selected[i] = !p.selected[i]
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+			}
+		}
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	case keyRune:
+		p.query = append(p.query, r)
+		p.filter()
+	case keyEnter:
+		if len(p.chosen()) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// chosen returns the selected items in their original order, or the item
+// under the cursor when nothing has been selected with tab
+func (p *picker) chosen() []StatusCode {
+	var out []StatusCode
+	for i, sc := range p.items {
+		if p.selected[i] {
+			out = append(out, sc)
+		}
+	}
+	if len(out) == 0 && len(p.matches) > 0 {
+		out = append(out, p.items[p.matches[p.cursor]])
+	}
+	return out
+}
+
+// render draws the prompt and the visible part of the list
+func (p *picker) render(w io.Writer, height int) {
+	rows := height - 2
+	if rows < 1 {
+		rows = 1
+	}
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+rows {
+		p.offset = p.cursor - rows + 1
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	fmt.Fprintf(&b, "> %s\r\n", string(p.query))
+	fmt.Fprintf(&b, "  %d/%d (tab select, enter accept, esc cancel)\r\n", len(p.matches), len(p.items))
+	for n := p.offset; n < len(p.matches) && n < p.offset+rows; n++ {
+		i := p.matches[n]
+		cursor, mark := " ", " "
+		if n == p.cursor {
+			cursor = ">"
+		}
+		if p.selected[i] {
+			mark = "*"
+		}
+		fmt.Fprintf(&b, "%s%s %s\r\n", cursor, mark, pickLabel(p.items[i]))
+	}
+	io.WriteString(w, b.String())
+}
+
+// readPickKey decodes one key press from raw terminal input
+func readPickKey(r *bufio.Reader) (pickKey, rune, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return keyNone, 0, err
+	}
+	switch c {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case '\t':
+		return keyTab, 0, nil
+	case 0x7f, 0x08:
+		return keyBackspace, 0, nil
+	case 0x03:
+		// Ctrl-C arrives as a byte in raw mode
+		return keyEscape, 0, nil
+	case 0x1b:
+		// A lone escape has nothing following it in the buffer
+		if r.Buffered() == 0 {
+			return keyEscape, 0, nil
+		}
+		if next, _ := r.ReadByte(); next != '[' && next != 'O' {
+			return keyNone, 0, nil
+		}
+		switch code, _ := r.ReadByte(); code {
+		case 'A':
+			return keyUp, 0, nil
+		case 'B':
+			return keyDown, 0, nil
+		}
+		return keyNone, 0, nil
+	}
+	if unicode.IsPrint(c) {
+		return keyRune, c, nil
+	}
+	return keyNone, 0, nil
+}
+
+// runPicker drives a picker from key input until a choice is made
+func runPicker(in io.Reader, out io.Writer, items []StatusCode, height int) ([]StatusCode, error) {
+	p := newPicker(items)
+	r := bufio.NewReader(in)
+	for {
+		p.render(out, height)
+		key, c, err := readPickKey(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil, errPickCancelled
+			}
+			return nil, err
+		}
+		done, err := p.handle(key, c)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return p.chosen(), nil
+		}
+	}
+}
+
+// pickInteractive runs the picker on the terminal
+func pickInteractive(items []StatusCode) ([]StatusCode, error) {
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(outFd) || !term.IsTerminal(inFd) {
+		return nil, fmt.Errorf("--pick requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return nil, fmt.Errorf("switching terminal to raw mode: %v", err)
+	}
+	defer term.Restore(inFd, state)
+
+	height := 24
+	if _, h, err := term.GetSize(outFd); err == nil {
+		height = h
+	}
+
+	// Draw on the alternate screen so the picker leaves no trace
+	fmt.Fprint(os.Stdout, "\x1b[?1049h")
+	defer fmt.Fprint(os.Stdout, "\x1b[?1049l")
+	return runPicker(os.Stdin, os.Stdout, items, height)
+}
@@ -0,0 +1,111 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// pickCodes runs the picker over the 4xx codes with scripted key input
+func pickCodes(t *testing.T, input string) ([]StatusCode, error) {
+	t.Helper()
+	items, err := processInputs("", "", []string{"4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	return runPicker(strings.NewReader(input), &out, items, 10)
+}
+
+// Test fuzzy matching follows character order and ignores case
+func TestFuzzyMatch(t *testing.T) {
+	testCases := []struct {
+		text, query string
+		expected    bool
+	}{
+		{"404 Not Found", "nf", true},
+		{"404 Not Found", "NOTFND", true},
+		{"404 Not Found", "dn", false},
+		{"404 Not Found", "44", true},
+		{"404 Not Found", "", true},
+	}
+	for _, tc := range testCases {
+		if got := fuzzyMatch(tc.text, tc.query); got != tc.expected {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, expected %v", tc.text, tc.query, got, tc.expected)
+		}
+	}
+}
+
+// Test picking with the filter, tab selection and enter
+func TestPickerSelection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []int
+	}{
+		{"enter picks cursor", "\r", []int{400}},
+		{"arrow keys move", "\x1b[B\x1b[B\x1b[A\r", []int{401}},
+		{"filter", "teapot\r", []int{418}},
+		{"backspace widens filter", "4044\x7f\r", []int{404}},
+		{"tab multi-select", "timeout\t\x7f\x7f\x7f\x7f\x7f\x7f\x7fnot found\t\r", []int{404, 408}},
+		{"tab toggles off", "\t\x1b[A\t\r", []int{401}},
+	}
+
+	for _, tc := range testCases {
+		got, err := pickCodes(t, tc.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		codes := []int{}
+		for _, sc := range got {
+			codes = append(codes, sc.Code)
+		}
+		if len(codes) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, codes)
+			continue
+		}
+		for i := range codes {
+			if codes[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, codes)
+				break
+			}
+		}
+	}
+}
+
+// Test escape, Ctrl-C and end of input cancel the picker
+func TestPickerCancel(t *testing.T) {
+	for _, input := range []string{"40\x1b", "\x03", "4"} {
+		if _, err := pickCodes(t, input); !errors.Is(err, errPickCancelled) {
+			t.Errorf("Input %q: expected errPickCancelled, got %v", input, err)
+		}
+	}
+
+	// Enter with nothing matching is ignored rather than returning nothing
+	if _, err := pickCodes(t, "zzz\r"); !errors.Is(err, errPickCancelled) {
+		t.Errorf("Expected enter with no matches to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ignoreSIGPIPE stops a closed stdout, as in httpstatus | head, from
+// killing the process with SIGPIPE, so the write fails with EPIPE instead
+// and quitOnBrokenPipe can end the program cleanly
+func ignoreSIGPIPE() {
+	signal.Ignore(syscall.SIGPIPE)
+}
+
+// isBrokenPipe reports whether err means the reader has gone away
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrClosedPipe) || isPlatformBrokenPipe(err)
+}
+
+// pipeWriter ends the program with exit, quietly and successfully, the
+// first time a write finds the reader gone, like cat and grep do
+type pipeWriter struct {
+	w    io.Writer
+	exit func(int)
+}
+
+// quitOnBrokenPipe wraps w so a closed pipe exits with status 0
+func quitOnBrokenPipe(w io.Writer) io.Writer {
+	return &pipeWriter{w: w, exit: os.Exit}
+}
+
+func (p *pipeWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	if err != nil && isBrokenPipe(err) {
+		p.exit(0)
+	}
+	return n, err
+}
+
+// isStdout reports whether w is stdout, directly or through quitOnBrokenPipe
+func isStdout(w io.Writer) bool {
+	if p, ok := w.(*pipeWriter); ok {
+		w = p.w
+	}
+	return w == os.Stdout
+}
@@ -0,0 +1,96 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+)
+
+// failingWriter accepts limit bytes, then fails every write with err
+type failingWriter struct {
+	limit int
+	err   error
+	buf   bytes.Buffer
+}
+
+func (f *failingWriter) Write(b []byte) (int, error) {
+	if f.buf.Len()+len(b) > f.limit {
+		n := f.limit - f.buf.Len()
+		f.buf.Write(b[:n])
+		return n, f.err
+	}
+	return f.buf.Write(b)
+}
+
+// Test a broken pipe part way through each printer exits with status 0
+func TestPipeWriterBrokenPipe(t *testing.T) {
+	codes := goldenCodes(t)
+	printers := map[string]func(io.Writer){
+		"text":  func(w io.Writer) { printText(w, codes) },
+		"table": func(w io.Writer) { printTableStyle(w, codes, "unicode") },
+		"csv":   func(w io.Writer) { printCSV(w, codes) },
+		"json":  func(w io.Writer) { printJSON(w, codes, true) },
+	}
+	for _, brokenErr := range []error{syscall.EPIPE, io.ErrClosedPipe, fmt.Errorf("write /dev/stdout: %w", syscall.EPIPE)} {
+		for name, print := range printers {
+			var exits []int
+			fw := &failingWriter{limit: 40, err: brokenErr}
+			w := &pipeWriter{w: fw, exit: func(code int) { exits = append(exits, code) }}
+			print(w)
+			if len(exits) == 0 || exits[0] != 0 {
+				t.Errorf("%s (%v): expected exit status 0, got %v", name, brokenErr, exits)
+			}
+			if fw.buf.Len() != 40 {
+				t.Errorf("%s (%v): expected 40 bytes before the pipe broke, got %d", name, brokenErr, fw.buf.Len())
+			}
+		}
+	}
+}
+
+// Test other write errors are returned rather than exiting
+func TestPipeWriterOtherErrors(t *testing.T) {
+	diskFull := errors.New("no space left on device")
+	exited := false
+	w := &pipeWriter{w: &failingWriter{limit: 2, err: diskFull}, exit: func(int) { exited = true }}
+	if _, err := w.Write([]byte("404\n")); !errors.Is(err, diskFull) {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+	if exited {
+		t.Error("Exited on an error that is not a broken pipe")
+	}
+}
+
+// Test wrapping stdout still counts as stdout for colour decisions
+func TestIsStdout(t *testing.T) {
+	if !isStdout(os.Stdout) || !isStdout(quitOnBrokenPipe(os.Stdout)) {
+		t.Error("Expected stdout to be recognised, wrapped or not")
+	}
+	if isStdout(&bytes.Buffer{}) || isStdout(quitOnBrokenPipe(os.Stderr)) {
+		t.Error("Expected other writers not to be stdout")
+	}
+}
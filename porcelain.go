@@ -0,0 +1,107 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// porcelainVersions lists the --porcelain formats. A released version
+// never changes; a new layout gets a new version alongside it
+var porcelainVersions = []string{"v1"}
+
+// porcelainFlag is --porcelain, which takes an optional =version and
+// means the first version when given bare. An unknown version is kept in
+// err rather than returned from Set, which would print the usage and
+// lose the error kind, and is reported by main after parsing
+type porcelainFlag struct {
+	version string
+	err     error
+}
+
+func (p *porcelainFlag) String() string {
+	if p == nil {
+		return ""
+	}
+	return p.version
+}
+
+func (p *porcelainFlag) Set(value string) error {
+	p.version, p.err = "", nil
+	switch value {
+	case "true":
+		value = porcelainVersions[0]
+	case "false":
+		return nil
+	}
+	for _, v := range porcelainVersions {
+		if value == v {
+			p.version = value
+			return nil
+		}
+	}
+	p.err = &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid porcelain version: '%s' - must be one of %s", value, strings.Join(porcelainVersions, ", ")), Input: value}
+	return nil
+}
+
+// IsBoolFlag lets --porcelain be given without a version
+func (p *porcelainFlag) IsBoolFlag() bool {
+	return true
+}
+
+// porcelainField flattens a field onto its line: tabs and line breaks
+// become spaces so every record stays one line of four columns
+var porcelainField = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// printPorcelain writes codes in the given --porcelain format
+func printPorcelain(w io.Writer, codes []StatusCode, version string) error {
+	switch version {
+	case "v1":
+		return printPorcelainV1(w, codes)
+	}
+	return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid porcelain version: '%s' - must be one of %s", version, strings.Join(porcelainVersions, ", ")), Input: version}
+}
+
+// printPorcelainV1 writes one line per code of code, type, short and long
+// separated by tabs, with missing fields empty and nothing else at all
+func printPorcelainV1(w io.Writer, codes []StatusCode) error {
+	for _, sc := range codes {
+		short, long := "", ""
+		if sc.Short != nil {
+			short = *sc.Short
+		}
+		if sc.Long != nil {
+			long = *sc.Long
+		}
+		fields := []string{strconv.Itoa(sc.Code), sc.Type, short, long}
+		for i, f := range fields {
+			fields[i] = porcelainField.Replace(f)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// Test the v1 format byte for byte; it must never change
+func TestPorcelainV1(t *testing.T) {
+	results, err := lookup(lookupQuery{args: []string{"404", "100"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := append(results, StatusCode{Code: 299, Type: "Success", Short: strPtr("Tab\there"), Long: strPtr("Two\nlines")}, StatusCode{Code: 599})
+
+	var buf bytes.Buffer
+	if err := printPorcelain(&buf, codes, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	want := "404\tClient Error\tNot Found\tRequested resource could not be found\n" +
+		"100\tInformational\tContinue\tServer received request headers; client should proceed with body\n" +
+		"299\tSuccess\tTab here\tTwo lines\n" +
+		"599\t\t\t\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+}
+
+// Test --porcelain defaults to v1 and rejects unknown versions
+func TestPorcelainFlag(t *testing.T) {
+	tests := []struct {
+		value, want string
+		ok          bool
+	}{
+		{"true", "v1", true},
+		{"v1", "v1", true},
+		{"false", "", true},
+		{"v2", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		var p porcelainFlag
+		if err := p.Set(tt.value); err != nil {
+			t.Errorf("Set(%q) returned %v; an unknown version should be kept for main to report", tt.value, err)
+		}
+		if (p.err == nil) != tt.ok || p.version != tt.want {
+			t.Errorf("Set(%q) = %q, %v; want %q, ok %v", tt.value, p.version, p.err, tt.want, tt.ok)
+		}
+		var ce *cliError
+		if !tt.ok && (!errors.As(p.err, &ce) || ce.Kind != errInvalidInput) {
+			t.Errorf("Set(%q): expected an invalid input error, got %v", tt.value, p.err)
+		}
+	}
+
+	// A later valid version replaces an earlier bad one
+	var p porcelainFlag
+	p.Set("v2")
+	p.Set("v1")
+	if p.err != nil || p.version != "v1" {
+		t.Errorf("Expected the last --porcelain to win, got %q, %v", p.version, p.err)
+	}
+
+	if err := printPorcelain(&bytes.Buffer{}, nil, "v0"); err == nil {
+		t.Error("Expected an error for an unknown version")
+	}
+}
@@ -0,0 +1,162 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// h2ErrorCodes are the HTTP/2 error codes from RFC 9113 section 7, sent in
+// RST_STREAM and GOAWAY frames
+var h2ErrorCodes = []StatusCode{
+	{Code: 0x0, Type: "HTTP/2 Error", Short: strPtr("NO_ERROR"), Long: strPtr("Not the result of an error, e.g. a graceful shutdown with GOAWAY")},
+	{Code: 0x1, Type: "HTTP/2 Error", Short: strPtr("PROTOCOL_ERROR"), Long: strPtr("Unspecific protocol error detected by the endpoint")},
+	{Code: 0x2, Type: "HTTP/2 Error", Short: strPtr("INTERNAL_ERROR"), Long: strPtr("Endpoint encountered an unexpected internal error")},
+	{Code: 0x3, Type: "HTTP/2 Error", Short: strPtr("FLOW_CONTROL_ERROR"), Long: strPtr("Peer violated the flow-control protocol")},
+	{Code: 0x4, Type: "HTTP/2 Error", Short: strPtr("SETTINGS_TIMEOUT"), Long: strPtr("SETTINGS frame was not acknowledged in time")},
+	{Code: 0x5, Type: "HTTP/2 Error", Short: strPtr("STREAM_CLOSED"), Long: strPtr("Frame received after the stream was half-closed")},
+	{Code: 0x6, Type: "HTTP/2 Error", Short: strPtr("FRAME_SIZE_ERROR"), Long: strPtr("Frame received with an invalid size")},
+	{Code: 0x7, Type: "HTTP/2 Error", Short: strPtr("REFUSED_STREAM"), Long: strPtr("Stream refused before any processing; safe to retry")},
+	{Code: 0x8, Type: "HTTP/2 Error", Short: strPtr("CANCEL"), Long: strPtr("Stream is no longer needed")},
+	{Code: 0x9, Type: "HTTP/2 Error", Short: strPtr("COMPRESSION_ERROR"), Long: strPtr("Unable to maintain the HPACK field compression context")},
+	{Code: 0xa, Type: "HTTP/2 Error", Short: strPtr("CONNECT_ERROR"), Long: strPtr("Connection for a CONNECT request was reset or abnormally closed")},
+	{Code: 0xb, Type: "HTTP/2 Error", Short: strPtr("ENHANCE_YOUR_CALM"), Long: strPtr("Peer is generating excessive load")},
+	{Code: 0xc, Type: "HTTP/2 Error", Short: strPtr("INADEQUATE_SECURITY"), Long: strPtr("Transport does not meet minimum security requirements")},
+	{Code: 0xd, Type: "HTTP/2 Error", Short: strPtr("HTTP_1_1_REQUIRED"), Long: strPtr("Endpoint requires HTTP/1.1 instead of HTTP/2")},
+}
+
+// h3ErrorCodes are the HTTP/3 error codes from RFC 9114 section 8.1 and the
+// QPACK error codes from RFC 9204, sent in QUIC CONNECTION_CLOSE and
+// RESET_STREAM frames
+var h3ErrorCodes = []StatusCode{
+	{Code: 0x100, Type: "HTTP/3 Error", Short: strPtr("H3_NO_ERROR"), Long: strPtr("No error; used when closing a connection or stream without a problem")},
+	{Code: 0x101, Type: "HTTP/3 Error", Short: strPtr("H3_GENERAL_PROTOCOL_ERROR"), Long: strPtr("Protocol violation without a more specific error code")},
+	{Code: 0x102, Type: "HTTP/3 Error", Short: strPtr("H3_INTERNAL_ERROR"), Long: strPtr("Internal error in the HTTP stack")},
+	{Code: 0x103, Type: "HTTP/3 Error", Short: strPtr("H3_STREAM_CREATION_ERROR"), Long: strPtr("Peer created a stream that will not be accepted")},
+	{Code: 0x104, Type: "HTTP/3 Error", Short: strPtr("H3_CLOSED_CRITICAL_STREAM"), Long: strPtr("A stream required by the connection was closed or reset")},
+	{Code: 0x105, Type: "HTTP/3 Error", Short: strPtr("H3_FRAME_UNEXPECTED"), Long: strPtr("Frame not permitted in the current state or on the current stream")},
+	{Code: 0x106, Type: "HTTP/3 Error", Short: strPtr("H3_FRAME_ERROR"), Long: strPtr("Frame fails layout requirements or has an invalid size")},
+	{Code: 0x107, Type: "HTTP/3 Error", Short: strPtr("H3_EXCESSIVE_LOAD"), Long: strPtr("Peer is generating excessive load")},
+	{Code: 0x108, Type: "HTTP/3 Error", Short: strPtr("H3_ID_ERROR"), Long: strPtr("Stream ID or push ID was used incorrectly")},
+	{Code: 0x109, Type: "HTTP/3 Error", Short: strPtr("H3_SETTINGS_ERROR"), Long: strPtr("Error in the payload of a SETTINGS frame")},
+	{Code: 0x10a, Type: "HTTP/3 Error", Short: strPtr("H3_MISSING_SETTINGS"), Long: strPtr("No SETTINGS frame was received at the start of the control stream")},
+	{Code: 0x10b, Type: "HTTP/3 Error", Short: strPtr("H3_REQUEST_REJECTED"), Long: strPtr("Request rejected without any processing; safe to retry")},
+	{Code: 0x10c, Type: "HTTP/3 Error", Short: strPtr("H3_REQUEST_CANCELLED"), Long: strPtr("Request or its response is cancelled")},
+	{Code: 0x10d, Type: "HTTP/3 Error", Short: strPtr("H3_REQUEST_INCOMPLETE"), Long: strPtr("Stream terminated without containing a fully formed request")},
+	{Code: 0x10e, Type: "HTTP/3 Error", Short: strPtr("H3_MESSAGE_ERROR"), Long: strPtr("HTTP message was malformed and cannot be processed")},
+	{Code: 0x10f, Type: "HTTP/3 Error", Short: strPtr("H3_CONNECT_ERROR"), Long: strPtr("Connection for a CONNECT request was reset or abnormally closed")},
+	{Code: 0x110, Type: "HTTP/3 Error", Short: strPtr("H3_VERSION_FALLBACK"), Long: strPtr("Request not served over HTTP/3; the client should retry over HTTP/1.1")},
+	{Code: 0x200, Type: "QPACK Error", Short: strPtr("QPACK_DECOMPRESSION_FAILED"), Long: strPtr("Decoder failed to interpret an encoded field section")},
+	{Code: 0x201, Type: "QPACK Error", Short: strPtr("QPACK_ENCODER_STREAM_ERROR"), Long: strPtr("Decoder failed to interpret an encoder instruction")},
+	{Code: 0x202, Type: "QPACK Error", Short: strPtr("QPACK_DECODER_STREAM_ERROR"), Long: strPtr("Encoder failed to interpret a decoder instruction")},
+}
+
+// protocolDatasets maps the --protocol values to their error code datasets;
+// plain HTTP status codes are the default and are not listed
+var protocolDatasets = map[string][]StatusCode{
+	"h2": h2ErrorCodes,
+	"h3": h3ErrorCodes,
+}
+
+// protocolNames returns the protocols accepted by --protocol
+func protocolNames() []string {
+	names := []string{"http"}
+	var others []string
+	for name := range protocolDatasets {
+		others = append(others, name)
+	}
+	sort.Strings(others)
+	return append(names, others...)
+}
+
+// parseErrorCode parses a protocol error code in hex (0x7) or decimal
+func parseErrorCode(s string) (int, error) {
+	var (
+		code int64
+		err  error
+	)
+	if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		code, err = strconv.ParseInt(hex, 16, 64)
+	} else {
+		code, err = strconv.ParseInt(s, 10, 64)
+	}
+	if err != nil || code < 0 {
+		return 0, fmt.Errorf("invalid error code: '%s' - must be hex (0x7) or decimal", s)
+	}
+	return int(code), nil
+}
+
+// lookupProtocol resolves a query against a protocol error code dataset;
+// codes match exactly and the search covers names and descriptions
+func lookupProtocol(dataset []StatusCode, q lookupQuery) ([]StatusCode, error) {
+	var results []StatusCode
+	seen := make(map[int]bool)
+	add := func(sc StatusCode) {
+		if q.allowDuplicates || !seen[sc.Code] {
+			seen[sc.Code] = true
+			results = append(results, sc)
+		}
+	}
+
+	tokens := append(strings.Split(q.codes, ","), q.args...)
+	for _, token := range tokens {
+		for _, part := range strings.Split(token, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			code, err := parseErrorCode(part)
+			if err != nil {
+				return nil, err
+			}
+			found := false
+			for _, sc := range dataset {
+				if sc.Code == code {
+					add(sc)
+					found = true
+				}
+			}
+			if !found {
+				return nil, &cliError{Kind: errNotFound, Message: fmt.Sprintf("no error codes found matching: '%s'", part), Input: part}
+			}
+		}
+	}
+
+	terms := q.searchTerms()
+	if len(terms) > 0 {
+		for _, sc := range searchAllTerms(dataset, terms) {
+			add(sc)
+		}
+	}
+
+	if len(results) == 0 {
+		if len(terms) > 0 {
+			search := strings.Join(terms, ",")
+			return nil, &cliError{Kind: errNotFound, Message: fmt.Sprintf("no error codes found matching: '%s'", search), Input: search}
+		}
+		results = dataset
+	}
+	return results, nil
+}
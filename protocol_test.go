@@ -0,0 +1,106 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"testing"
+)
+
+// Test hex and decimal error code parsing
+func TestParseErrorCode(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int
+		valid    bool
+	}{
+		{"0x7", 7, true},
+		{"0XD", 13, true},
+		{"7", 7, true},
+		{"010", 10, true},
+		{"0x10c", 0x10c, true},
+		{"268", 268, true},
+		{"0xg", 0, false},
+		{"-1", 0, false},
+		{"seven", 0, false},
+	}
+	for _, tc := range testCases {
+		code, err := parseErrorCode(tc.input)
+		if (err == nil) != tc.valid || code != tc.expected {
+			t.Errorf("%q: expected %d (valid %v), got %d, %v", tc.input, tc.expected, tc.valid, code, err)
+		}
+	}
+}
+
+// Test lookups against the protocol datasets
+func TestLookupProtocol(t *testing.T) {
+	results, err := lookupProtocol(h2ErrorCodes, lookupQuery{args: []string{"0x7,11"}, codes: "0x0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[0].Code != 0 || *results[1].Short != "REFUSED_STREAM" || *results[2].Short != "ENHANCE_YOUR_CALM" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	results, err = lookupProtocol(h3ErrorCodes, lookupQuery{search: "cancel"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || *results[0].Short != "H3_REQUEST_CANCELLED" {
+		t.Errorf("Unexpected search results: %+v", results)
+	}
+
+	results, err = lookupProtocol(h2ErrorCodes, lookupQuery{})
+	if err != nil || len(results) != 14 {
+		t.Errorf("Expected all 14 HTTP/2 codes, got %d, %v", len(results), err)
+	}
+
+	for _, q := range []lookupQuery{
+		{args: []string{"404"}},
+		{args: []string{"0xzz"}},
+		{search: "teapot"},
+	} {
+		if _, err := lookupProtocol(h2ErrorCodes, q); err == nil {
+			t.Errorf("Expected error for %+v", q)
+		}
+	}
+}
+
+// Test the datasets are complete and kept apart from the status codes
+func TestProtocolDatasets(t *testing.T) {
+	for i, sc := range h2ErrorCodes {
+		if sc.Code != i {
+			t.Errorf("HTTP/2 codes should run 0x0-0xd in order, got %#x at %d", sc.Code, i)
+		}
+	}
+	for name, dataset := range protocolDatasets {
+		seen := map[int]bool{}
+		for _, sc := range dataset {
+			if seen[sc.Code] || sc.Short == nil || sc.Long == nil {
+				t.Errorf("%s: duplicate or incomplete entry %#x", name, sc.Code)
+			}
+			seen[sc.Code] = true
+		}
+	}
+	if _, found := findStatusCode(0x7); found {
+		t.Error("Protocol error codes leaked into the status code dataset")
+	}
+}
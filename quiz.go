@@ -0,0 +1,196 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quizChoices is the number of options offered per question
+const quizChoices = 4
+
+// quizQuestion is one multiple-choice question
+type quizQuestion struct {
+	prompt  string
+	options []string
+	answer  int
+	subject StatusCode
+}
+
+// runQuiz implements "httpstatus quiz [flags]"
+func runQuiz(args []string, in io.Reader, w io.Writer) error {
+	fs := newFlagSet("quiz")
+	class := fs.String("class", "", "Restrict questions to a status class, e.g. 4")
+	count := fs.Int("count", 10, "Number of questions")
+	seed := fs.Int64("seed", 0, "Random seed for a repeatable quiz (default random)")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("quiz takes no arguments, got: '%s'", strings.Join(positional, " "))
+	}
+	if *count <= 0 {
+		return fmt.Errorf("invalid count: %d - must be positive", *count)
+	}
+	if *class != "" {
+		if _, err := strconv.Atoi(*class); err != nil {
+			return fmt.Errorf("invalid class: '%s' - must be numeric", *class)
+		}
+	}
+
+	pool := quizzable(filterStatusCodes(statusCodes, *class, ""))
+	if len(pool) == 0 {
+		return notFoundError(*class)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+
+	correct, asked := quiz(rng, pool, *count, in, w)
+	if asked > 0 {
+		fmt.Fprintf(w, "\nScore: %d/%d (%d%%)\n", correct, asked, correct*100/asked)
+	}
+	return nil
+}
+
+// quiz asks count questions and returns the number answered correctly and
+// the number asked; it stops early when the input ends
+func quiz(rng *rand.Rand, pool []StatusCode, count int, in io.Reader, w io.Writer) (int, int) {
+	scanner := bufio.NewScanner(in)
+	var order []int
+	correct := 0
+
+	for n := 0; n < count; n++ {
+		// Reshuffle whenever the pool is used up so short pools still fill the session
+		if n%len(pool) == 0 {
+			order = rng.Perm(len(pool))
+		}
+		subject := pool[order[n%len(pool)]]
+		q := newQuizQuestion(rng, pool, subject, rng.Intn(2) == 0)
+
+		fmt.Fprintf(w, "\nQuestion %d/%d: %s\n", n+1, count, q.prompt)
+		for i, opt := range q.options {
+			fmt.Fprintf(w, "  %c) %s\n", 'A'+i, opt)
+		}
+
+		choice := -1
+		for choice < 0 {
+			fmt.Fprint(w, "> ")
+			if !scanner.Scan() {
+				fmt.Fprintln(w)
+				return correct, n
+			}
+			choice = parseQuizAnswer(scanner.Text(), q.options)
+			if choice < 0 {
+				fmt.Fprintf(w, "Answer with a letter A-%c or type the answer\n", 'A'+len(q.options)-1)
+			}
+		}
+
+		if choice == q.answer {
+			correct++
+			fmt.Fprintln(w, "Correct!")
+		} else {
+			fmt.Fprintf(w, "Wrong - the answer is %c) %s\n", 'A'+q.answer, q.options[q.answer])
+		}
+		fmt.Fprintf(w, "%d %s: %s\n", subject.Code, *subject.Short, strValue(subject.Long))
+	}
+	return correct, count
+}
+
+// newQuizQuestion builds a question about subject, asking either for its
+// reason phrase or, when byPhrase is set, for the code matching its phrase
+func newQuizQuestion(rng *rand.Rand, pool []StatusCode, subject StatusCode, byPhrase bool) quizQuestion {
+	// Distractors come from the pool when it is big enough, otherwise from all codes
+	candidates := pool
+	if len(candidates) < quizChoices {
+		candidates = quizzable(statusCodes)
+	}
+	choices := []StatusCode{subject}
+	for _, i := range rng.Perm(len(candidates)) {
+		if len(choices) == quizChoices {
+			break
+		}
+		if candidates[i].Code != subject.Code {
+			choices = append(choices, candidates[i])
+		}
+	}
+	rng.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+
+	q := quizQuestion{subject: subject}
+	if byPhrase {
+		q.prompt = fmt.Sprintf("Which code means %s?", *subject.Short)
+	} else {
+		q.prompt = fmt.Sprintf("What does %d mean?", subject.Code)
+	}
+	for i, sc := range choices {
+		if sc.Code == subject.Code {
+			q.answer = i
+		}
+		if byPhrase {
+			q.options = append(q.options, strconv.Itoa(sc.Code))
+		} else {
+			q.options = append(q.options, *sc.Short)
+		}
+	}
+	return q
+}
+
+// quizzable keeps the codes that have a reason phrase to ask about; a
+// custom code may not have one
+func quizzable(codes []StatusCode) []StatusCode {
+	var kept []StatusCode
+	for _, sc := range codes {
+		if strValue(sc.Short) != "" {
+			kept = append(kept, sc)
+		}
+	}
+	return kept
+}
+
+// parseQuizAnswer maps an option letter or the typed option text to an
+// option index, returning -1 when the input matches neither
+func parseQuizAnswer(input string, options []string) int {
+	input = strings.TrimSpace(input)
+	if len(input) == 1 {
+		if i := int(strings.ToUpper(input)[0] - 'A'); i >= 0 && i < len(options) {
+			return i
+		}
+	}
+	for i, opt := range options {
+		if strings.EqualFold(input, opt) {
+			return i
+		}
+	}
+	return -1
+}
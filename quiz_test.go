@@ -0,0 +1,144 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test questions contain the subject exactly once among distinct options
+func TestNewQuizQuestion(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pool := filterStatusCodes(statusCodes, "4", "")
+	subject, _ := findStatusCode(409)
+
+	for _, byPhrase := range []bool{false, true} {
+		q := newQuizQuestion(rng, pool, subject, byPhrase)
+		if len(q.options) != quizChoices {
+			t.Fatalf("Expected %d options, got %v", quizChoices, q.options)
+		}
+		want := *subject.Short
+		if byPhrase {
+			want = "409"
+		}
+		if q.options[q.answer] != want {
+			t.Errorf("Expected answer %q, got %q", want, q.options[q.answer])
+		}
+		seen := map[string]bool{}
+		for _, opt := range q.options {
+			if seen[opt] {
+				t.Errorf("Duplicate option %q in %v", opt, q.options)
+			}
+			seen[opt] = true
+			if byPhrase && !strings.HasPrefix(opt, "4") {
+				t.Errorf("Option %q is outside the pool", opt)
+			}
+		}
+	}
+
+	// A pool smaller than the number of choices borrows distractors
+	q := newQuizQuestion(rng, []StatusCode{subject}, subject, false)
+	if len(q.options) != quizChoices {
+		t.Errorf("Expected %d options for a tiny pool, got %v", quizChoices, q.options)
+	}
+}
+
+// Test answers are accepted as letters or typed text
+func TestParseQuizAnswer(t *testing.T) {
+	options := []string{"Conflict", "Gone", "I'm a teapot", "Locked"}
+	testCases := []struct {
+		input    string
+		expected int
+	}{
+		{"a", 0},
+		{" D ", 3},
+		{"gone", 1},
+		{"I'M A TEAPOT", 2},
+		{"e", -1},
+		{"409", -1},
+		{"", -1},
+	}
+	for _, tc := range testCases {
+		if got := parseQuizAnswer(tc.input, options); got != tc.expected {
+			t.Errorf("parseQuizAnswer(%q) = %d, expected %d", tc.input, got, tc.expected)
+		}
+	}
+}
+
+// Test scoring, the reinforcement text and stopping at end of input
+func TestQuizSession(t *testing.T) {
+	teapot, _ := findStatusCode(418)
+	for seed := int64(1); seed <= 4; seed++ {
+		// Either question direction accepts one of these lines; the other is re-prompted
+		var out bytes.Buffer
+		correct, asked := quiz(rand.New(rand.NewSource(seed)), []StatusCode{teapot}, 1,
+			strings.NewReader("418\nI'm a teapot\n"), &out)
+		if correct != 1 || asked != 1 {
+			t.Errorf("Seed %d: expected 1/1, got %d/%d\n%s", seed, correct, asked, out.String())
+		}
+		if !strings.Contains(out.String(), *teapot.Long) {
+			t.Errorf("Seed %d: expected long description in output", seed)
+		}
+	}
+
+	var out bytes.Buffer
+	correct, asked := quiz(rand.New(rand.NewSource(1)), []StatusCode{teapot}, 5, strings.NewReader("nonsense\n"), &out)
+	if correct != 0 || asked != 0 {
+		t.Errorf("Expected session to stop at end of input, got %d/%d", correct, asked)
+	}
+}
+
+// Test the same seed produces the same quiz
+func TestRunQuizSeeded(t *testing.T) {
+	run := func() string {
+		var out bytes.Buffer
+		if err := runQuiz([]string{"--seed", "42", "--count", "5", "--class", "5"}, strings.NewReader("a\nb\nc\nd\na\n"), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+	first := run()
+	if first != run() {
+		t.Error("Expected identical quizzes for the same seed")
+	}
+	if !strings.Contains(first, "Score: ") || !strings.Contains(first, "/5 (") {
+		t.Errorf("Expected a score line, got:\n%s", first)
+	}
+	for _, line := range strings.Split(first, "\n") {
+		if strings.HasPrefix(line, "Question") && strings.Contains(line, "What does") {
+			code := strings.Fields(line)[4]
+			if n, _ := strconv.Atoi(code); n < 500 || n > 599 {
+				t.Errorf("Question outside --class 5: %s", line)
+			}
+		}
+	}
+
+	for _, args := range [][]string{{"--class", "x"}, {"--class", "9"}, {"--count", "0"}, {"extra"}} {
+		if err := runQuiz(args, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a per-client-IP token bucket limiter
+type rateLimiter struct {
+	limit   int
+	period  time.Duration
+	trusted []*net.IPNet
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket tracks the tokens left for one client
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateUnits maps the unit names accepted by --rate-limit to durations
+var rateUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute,
+	"h": time.Hour, "hour": time.Hour,
+}
+
+// parseRateLimit parses a limit such as "100/min" or "10/30s";
+// a bare "0" disables rate limiting
+func parseRateLimit(spec string) (int, time.Duration, error) {
+	if spec == "" || spec == "0" {
+		return 0, 0, nil
+	}
+
+	countStr, unit, ok := strings.Cut(spec, "/")
+	count, err := strconv.Atoi(countStr)
+	if !ok || err != nil || count < 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit: '%s' - expected <count>/<unit>, e.g. 100/min", spec)
+	}
+
+	period, known := rateUnits[strings.ToLower(unit)]
+	if !known {
+		period, err = time.ParseDuration(unit)
+		if err != nil || period <= 0 {
+			return 0, 0, fmt.Errorf("invalid rate limit period: '%s' - use s, min, hour or a duration", unit)
+		}
+	}
+	return count, period, nil
+}
+
+// parseTrustedProxies parses a comma-separated list of IPs and CIDRs
+func parseTrustedProxies(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: '%s' - must be an IP or CIDR", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: '%s' - must be an IP or CIDR", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// newRateLimiter creates a limiter allowing limit requests per period per client
+func newRateLimiter(limit int, period time.Duration, trusted []*net.IPNet) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		period:  period,
+		trusted: trusted,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow takes a token for the client, returning how long to wait if none is left
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	rate := float64(l.limit) / float64(l.period)
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.limit), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(float64(l.limit), b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / rate)
+}
+
+// sweep drops buckets that have refilled completely, at most once per period
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.period {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= l.period {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// isTrustedProxy reports whether an IP belongs to a trusted proxy
+func isTrustedProxy(ip net.IP, trusted []*net.IPNet) bool {
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP determines the client address, honouring X-Forwarded-For only
+// when the request arrives through a trusted proxy
+func clientIP(r *http.Request, trusted []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isTrustedProxy(ip, trusted) {
+		return host
+	}
+
+	// Walk the chain from the nearest hop back to the first untrusted address
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		if !isTrustedProxy(hop, trusted) {
+			return hop.String()
+		}
+	}
+	return host
+}
+
+// middleware rejects requests over the limit with 429 and Retry-After
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := l.allow(clientIP(r, l.trusted))
+		if ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		body := apiError{Error: "rate limit exceeded"}
+		if sc, found := findStatusCode(http.StatusTooManyRequests); found {
+			body.Status = &sc
+		}
+		writeJSON(w, http.StatusTooManyRequests, body)
+	})
+}
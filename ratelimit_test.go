@@ -0,0 +1,182 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Test parsing of --rate-limit values
+func TestParseRateLimit(t *testing.T) {
+	testCases := []struct {
+		spec   string
+		limit  int
+		period time.Duration
+		valid  bool
+	}{
+		{"100/min", 100, time.Minute, true},
+		{"5/s", 5, time.Second, true},
+		{"1000/hour", 1000, time.Hour, true},
+		{"10/30s", 10, 30 * time.Second, true},
+		{"0", 0, 0, true},
+		{"", 0, 0, true},
+		{"100", 0, 0, false},
+		{"abc/min", 0, 0, false},
+		{"10/fortnight", 0, 0, false},
+		{"-1/min", 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		limit, period, err := parseRateLimit(tc.spec)
+		if (err == nil) != tc.valid {
+			t.Errorf("%q: expected valid=%v, got error %v", tc.spec, tc.valid, err)
+			continue
+		}
+		if limit != tc.limit || period != tc.period {
+			t.Errorf("%q: expected %d/%s, got %d/%s", tc.spec, tc.limit, tc.period, limit, period)
+		}
+	}
+}
+
+// Test token bucket refill
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(2, time.Minute, nil)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("a"); !ok {
+			t.Fatalf("Request %d should be allowed", i+1)
+		}
+	}
+	ok, wait := l.allow("a")
+	if ok {
+		t.Fatal("Third request should be limited")
+	}
+	if wait != 30*time.Second {
+		t.Errorf("Expected 30s wait, got %s", wait)
+	}
+
+	// Other clients have their own bucket
+	if ok, _ := l.allow("b"); !ok {
+		t.Error("A different client should be allowed")
+	}
+
+	// Half a period refills one token
+	now = now.Add(30 * time.Second)
+	if ok, _ := l.allow("a"); !ok {
+		t.Error("Request should be allowed after refill")
+	}
+	if ok, _ := l.allow("a"); ok {
+		t.Error("Only one token should have been refilled")
+	}
+}
+
+// Test driving requests past the limit returns the 429 payload
+func TestRateLimiterMiddleware(t *testing.T) {
+	handler := newRateLimiter(3, time.Minute, nil).middleware(newAPIServer(statusCodes).routes())
+
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 4; i++ {
+		rec = httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/status/200", nil)
+		req.RemoteAddr = "192.0.2.10:5000"
+		handler.ServeHTTP(rec, req)
+		if i < 3 && rec.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, rec.Code)
+		}
+	}
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "20" {
+		t.Errorf("Expected Retry-After 20, got %q", got)
+	}
+
+	var body apiError
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON error body: %v", err)
+	}
+	if body.Status == nil || body.Status.Code != 429 || *body.Status.Short != "Too Many Requests" || *body.Status.Long != "Exceeded rate limit for requests" {
+		t.Errorf("Expected 429 description in body, got %+v", body)
+	}
+}
+
+// Test X-Forwarded-For is only honoured from trusted proxies
+func TestRateLimiterClientIP(t *testing.T) {
+	trusted, err := parseTrustedProxies("10.0.0.0/8, 192.0.2.1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	l := newRateLimiter(1, time.Minute, trusted)
+
+	testCases := []struct {
+		remote   string
+		xff      string
+		expected string
+	}{
+		{"203.0.113.5:1234", "198.51.100.7", "203.0.113.5"},
+		{"10.1.2.3:1234", "198.51.100.7", "198.51.100.7"},
+		{"10.1.2.3:1234", "198.51.100.7, 10.9.9.9", "198.51.100.7"},
+		{"192.0.2.1:1234", "198.51.100.1, 198.51.100.7", "198.51.100.7"},
+		{"10.1.2.3:1234", "", "10.1.2.3"},
+		{"10.1.2.3:1234", "garbage", "10.1.2.3"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tc.remote
+		if tc.xff != "" {
+			req.Header.Set("X-Forwarded-For", tc.xff)
+		}
+		if got := clientIP(req, l.trusted); got != tc.expected {
+			t.Errorf("%s via %q: expected %s, got %s", tc.remote, tc.xff, tc.expected, got)
+		}
+	}
+
+	if _, err := parseTrustedProxies("not-an-ip"); err == nil {
+		t.Error("Expected error for invalid trusted proxy")
+	}
+}
+
+// Test spoofed X-Forwarded-For from an untrusted client shares its bucket
+func TestRateLimiterSpoofedHeader(t *testing.T) {
+	handler := newRateLimiter(1, time.Minute, nil).middleware(newAPIServer(statusCodes).routes())
+
+	codes := []int{}
+	for _, xff := range []string{"198.51.100.1", "198.51.100.2"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/types", nil)
+		req.RemoteAddr = "203.0.113.5:1234"
+		req.Header.Set("X-Forwarded-For", xff)
+		handler.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	if codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Spoofed header should not bypass the limit, got %v", codes)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// referenceFormats are the output formats of the reference subcommands,
+// such as methods and header
+var referenceFormats = []string{"json", "json-pretty", "xml", "xml-pretty", "yaml", "yaml-pretty", "toml", "table", "markdown", "csv"}
+
+// referenceFlags are the search and output flags shared by the reference
+// subcommands
+type referenceFlags struct {
+	search     *string
+	tableStyle *string
+	color      *string
+	formats    map[string]*bool
+}
+
+// newReferenceFlags defines the shared flags on fs
+func newReferenceFlags(fs *flag.FlagSet, searchUsage string) *referenceFlags {
+	rf := &referenceFlags{formats: make(map[string]*bool)}
+	rf.search = fs.String("search", "", searchUsage)
+	fs.StringVar(rf.search, "s", "", searchUsage+" (shorthand)")
+	rf.tableStyle = fs.String("table-style", "compact", "Table style: "+strings.Join(tableStyles, ", "))
+	rf.color = fs.String("color", "auto", "Colour output: auto, always or never")
+	for _, name := range referenceFormats {
+		rf.formats[name] = fs.Bool(name, false, "Output as "+name)
+	}
+	return rf
+}
+
+// validate checks the flag values once they are parsed
+func (rf *referenceFlags) validate() error {
+	if !validTableStyle(*rf.tableStyle) {
+		return fmt.Errorf("invalid table style: '%s' - must be one of %s", *rf.tableStyle, strings.Join(tableStyles, ", "))
+	}
+	if !validColorMode(*rf.color) {
+		return fmt.Errorf("invalid color mode: '%s' - must be one of %s", *rf.color, strings.Join(colorModes, ", "))
+	}
+	return nil
+}
+
+// referenceSet is a list of reference entries in every shape the output
+// formats need
+type referenceSet struct {
+	records interface{}   // the entries as a slice, for JSON
+	xml     interface{}   // the entries wrapped in a root element
+	docs    []interface{} // one YAML document per entry
+	columns []string      // lowercase column names for tables and CSV
+	rows    [][]string
+	text    func(io.Writer) // the default labelled text output
+	toml    func(io.Writer)
+}
+
+// render writes the set in each selected format, in referenceFormats
+// order, or as text when none is selected
+func (rf *referenceFlags) render(w io.Writer, set referenceSet) {
+	color := isStdout(w) && useColor(*rf.color, os.Stdout)
+	anyOutput := false
+	for _, name := range referenceFormats {
+		if !*rf.formats[name] {
+			continue
+		}
+		anyOutput = true
+		switch name {
+		case "json":
+			data, _ := json.Marshal(set.records)
+			fmt.Fprintln(w, string(data))
+		case "json-pretty":
+			highlighted(w, color, highlightJSON, func(w io.Writer) {
+				data, _ := json.MarshalIndent(set.records, "", "  ")
+				fmt.Fprintln(w, string(data))
+			})
+		case "xml", "xml-pretty":
+			var data []byte
+			if name == "xml-pretty" {
+				data, _ = xml.MarshalIndent(set.xml, "", "  ")
+			} else {
+				data, _ = xml.Marshal(set.xml)
+			}
+			fmt.Fprint(w, xml.Header+string(data))
+		case "yaml", "yaml-pretty":
+			pretty := name == "yaml-pretty"
+			highlighted(w, color && pretty, highlightYAML, func(w io.Writer) {
+				for i, doc := range set.docs {
+					if pretty && i > 0 {
+						fmt.Fprintln(w, "---")
+					}
+					data, _ := yaml.Marshal(doc)
+					fmt.Fprintln(w, string(data))
+				}
+			})
+		case "toml":
+			set.toml(w)
+		case "table", "markdown":
+			headers := make([]string, len(set.columns))
+			for i, c := range set.columns {
+				headers[i] = strings.ToUpper(c)
+			}
+			style := *rf.tableStyle
+			if name == "markdown" {
+				style = "github"
+			}
+			newTextTable(headers, set.rows, make([]bool, len(headers))).write(w, style)
+		case "csv":
+			cw := newCSVRecordWriter(w, csvOptions{})
+			cw.Write(set.columns)
+			for _, row := range set.rows {
+				cw.Write(row)
+			}
+			cw.Flush()
+		}
+	}
+	if !anyOutput {
+		set.text(w)
+	}
+}
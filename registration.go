@@ -0,0 +1,142 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// registrations lists the values of the registration field; codes the
+// IANA registry does not list are unofficial
+var registrations = []string{"permanent", "provisional", "unofficial"}
+
+// unassigned marks the gap entries added by --show-gaps, in both the
+// type and the registration field
+const unassigned = "Unassigned"
+
+// ianaRegistrations holds the registration status of the codes in the IANA
+// HTTP Status Code Registry, https://www.iana.org/assignments/http-status-codes;
+// reserved entries such as 306 and 418 count as registered
+var ianaRegistrations = map[int]string{
+	100: "permanent", 101: "permanent", 102: "permanent", 103: "permanent",
+	200: "permanent", 201: "permanent", 202: "permanent", 203: "permanent", 204: "permanent",
+	205: "permanent", 206: "permanent", 207: "permanent", 208: "permanent", 226: "permanent",
+	300: "permanent", 301: "permanent", 302: "permanent", 303: "permanent", 304: "permanent",
+	305: "permanent", 306: "permanent", 307: "permanent", 308: "permanent",
+	400: "permanent", 401: "permanent", 402: "permanent", 403: "permanent", 404: "permanent",
+	405: "permanent", 406: "permanent", 407: "permanent", 408: "permanent", 409: "permanent",
+	410: "permanent", 411: "permanent", 412: "permanent", 413: "permanent", 414: "permanent",
+	415: "permanent", 416: "permanent", 417: "permanent", 418: "permanent", 421: "permanent",
+	422: "permanent", 423: "permanent", 424: "permanent", 425: "permanent", 426: "permanent",
+	428: "permanent", 429: "permanent", 431: "permanent", 451: "permanent",
+	500: "permanent", 501: "permanent", 502: "permanent", 503: "permanent", 504: "permanent",
+	505: "permanent", 506: "permanent", 507: "permanent", 508: "permanent", 510: "permanent",
+	511: "permanent",
+}
+
+// validRegistration reports whether s is a registration status
+func validRegistration(s string) bool {
+	for _, r := range registrations {
+		if s == r {
+			return true
+		}
+	}
+	return false
+}
+
+// registrationOf returns the registration status of a code
+func registrationOf(code int) string {
+	if r, ok := ianaRegistrations[code]; ok {
+		return r
+	}
+	return "unofficial"
+}
+
+// applyRegistration sets the registration field, leaving gap entries marked
+// as unassigned
+func applyRegistration(codes []StatusCode) []StatusCode {
+	out := make([]StatusCode, len(codes))
+	for i, sc := range codes {
+		if sc.Registration == nil {
+			sc.Registration = strPtr(registrationOf(sc.Code))
+		}
+		out[i] = sc
+	}
+	return out
+}
+
+// filterRegistration keeps the codes with the given registration status
+func filterRegistration(codes []StatusCode, registration string) []StatusCode {
+	var kept []StatusCode
+	for _, sc := range codes {
+		if registrationOf(sc.Code) == registration {
+			kept = append(kept, sc)
+		}
+	}
+	return kept
+}
+
+// gapPrefixes returns the class and partial-code prefixes of a query, the
+// ranges --show-gaps fills in; exact codes have no gaps
+func gapPrefixes(q lookupQuery) []string {
+	var prefixes []string
+	tokens := append(strings.Split(q.codes, ","), q.args...)
+	for _, token := range tokens {
+		for _, part := range strings.Split(token, ",") {
+			part = strings.TrimSpace(part)
+			if _, err := strconv.Atoi(part); err == nil && len(part) < 3 {
+				prefixes = append(prefixes, part)
+			}
+		}
+	}
+	return append(prefixes, q.classes...)
+}
+
+// unassignedCodes returns an entry for every code point from 100 to 599
+// under the prefixes that has no status code, marked as unassigned
+func unassignedCodes(prefixes []string) []StatusCode {
+	var gaps []StatusCode
+	for code := 100; code <= 599; code++ {
+		if _, found := findStatusCode(code); found {
+			continue
+		}
+		s := strconv.Itoa(code)
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(s, prefix) {
+				gaps = append(gaps, StatusCode{Code: code, Type: unassigned, Registration: strPtr(strings.ToLower(unassigned))})
+				break
+			}
+		}
+	}
+	return gaps
+}
+
+// withGaps merges the unassigned entries into the results in code order
+func withGaps(results, gaps []StatusCode) []StatusCode {
+	merged := append(append([]StatusCode{}, results...), gaps...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Code < merged[j].Code
+	})
+	return merged
+}
@@ -0,0 +1,105 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// Test every built-in code has a valid registration status
+func TestRegistrationCoverage(t *testing.T) {
+	for _, sc := range statusCodes {
+		if r := registrationOf(sc.Code); !validRegistration(r) {
+			t.Errorf("%d: invalid registration %q", sc.Code, r)
+		}
+	}
+	for code := range ianaRegistrations {
+		if _, found := findStatusCode(code); !found {
+			t.Errorf("%d is registered but missing from the dataset", code)
+		}
+	}
+}
+
+// Test filtering by registration status
+func TestFilterRegistration(t *testing.T) {
+	var codes []int
+	for _, sc := range filterRegistration(statusCodes, "unofficial") {
+		codes = append(codes, sc.Code)
+	}
+	if expected := []int{420, 444, 449, 450, 499}; !reflect.DeepEqual(codes, expected) {
+		t.Errorf("Expected unofficial codes %v, got %v", expected, codes)
+	}
+	if got := filterRegistration(statusCodes, "permanent"); len(got)+len(codes) != len(statusCodes) {
+		t.Errorf("Expected every other code to be permanent, got %d", len(got))
+	}
+}
+
+// Test --full-metadata adds the registration without relabelling gaps
+func TestApplyRegistration(t *testing.T) {
+	results, err := processInputs("404,420", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results = applyRegistration(withGaps(results, unassignedCodes([]string{"41"})[:1]))
+
+	var buf bytes.Buffer
+	printCSVWith(&buf, results, csvOptions{fields: populatedFields(results)})
+	expected := "Code,Type,Short,Long,Registration\n" +
+		"404,Client Error,Not Found,Requested resource could not be found,permanent\n" +
+		"419,Unassigned,,,unassigned\n" +
+		"420,Client Error,Enhance Your Calm,Client is being rate-limited (Twitter),unofficial\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+// Test the prefixes --show-gaps fills in
+func TestGapPrefixes(t *testing.T) {
+	q := lookupQuery{codes: "41, 404", args: []string{"5,200", "abc"}, classes: []string{"3"}}
+	if got, expected := gapPrefixes(q), []string{"41", "5", "3"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := gapPrefixes(lookupQuery{codes: "404"}); len(got) != 0 {
+		t.Errorf("Expected no prefixes for an exact code, got %v", got)
+	}
+}
+
+// Test unassigned code points are marked and skip assigned codes
+func TestUnassignedCodes(t *testing.T) {
+	var codes []int
+	for _, sc := range unassignedCodes([]string{"42", "43"}) {
+		if sc.Type != "Unassigned" || sc.Registration == nil || *sc.Registration != "unassigned" || sc.Short != nil {
+			t.Errorf("%d: gap entry not marked as unassigned: %+v", sc.Code, sc)
+		}
+		codes = append(codes, sc.Code)
+	}
+	expected := []int{427, 430, 432, 433, 434, 435, 436, 437, 438, 439}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("Expected gaps %v, got %v", expected, codes)
+	}
+
+	if gaps := unassignedCodes([]string{"1"}); len(gaps) != 96 {
+		t.Errorf("Expected 96 unassigned 1xx codes, got %d", len(gaps))
+	}
+}
@@ -0,0 +1,118 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaFormats are the encodings --schema accepts; xml prints the XML
+// Schema for the XML output, as --xsd does
+var schemaFormats = []string{"json", "yaml", "xml"}
+
+// schemaExtensions are the --to-file extensions of each schema format
+var schemaExtensions = map[string]string{
+	"json": ".schema.json",
+	"yaml": ".schema.yaml",
+	"xml":  ".xsd",
+}
+
+// outputSchema builds a JSON Schema for the JSON output, an array of
+// status codes; the properties come from the StatusCode struct tags so
+// new fields are picked up, with the code range and known types added
+func outputSchema() jsonObject {
+	item := structSchema(reflect.TypeOf(StatusCode{}))
+	props := item["properties"].(jsonObject)
+	props["code"].(jsonObject)["minimum"] = 100
+	props["code"].(jsonObject)["maximum"] = 599
+	props["type"].(jsonObject)["enum"] = statusTypes()
+
+	return jsonObject{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "HTTP status codes",
+		"description": "The JSON output of " + AppName,
+		"type":        "array",
+		"items":       item,
+	}
+}
+
+// printSchema outputs the schema of the JSON output as JSON or YAML, or
+// of the XML output as XSD
+func printSchema(w io.Writer, format string) error {
+	switch format {
+	case "xml":
+		printXSD(w)
+		return nil
+	case "json":
+		data, err := json.MarshalIndent(outputSchema(), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(outputSchema())
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	return schemaFormatError(format)
+}
+
+// schemaFormatError reports an unknown --schema format
+func schemaFormatError(format string) error {
+	return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid schema format: '%s' - must be one of %s", format, strings.Join(schemaFormats, ", ")), Input: format}
+}
+
+// writeSchema prints the schema, or saves it to basePath with the
+// extension of its format when --to-file is given
+func writeSchema(format, basePath string) error {
+	if basePath == "" {
+		return printSchema(quitOnBrokenPipe(os.Stdout), format)
+	}
+	ext, ok := schemaExtensions[format]
+	if !ok {
+		return schemaFormatError(format)
+	}
+
+	filename := basePath + ext
+	file, err := os.Create(filename)
+	if err != nil {
+		return &cliError{Kind: errIO, Message: fmt.Sprintf("Error creating %s: %v", filename, err)}
+	}
+	defer file.Close()
+	if err := printSchema(file, format); err != nil {
+		return &cliError{Kind: errIO, Message: fmt.Sprintf("Error writing %s: %v", filename, err)}
+	}
+	log.Printf("Output saved to %s", filename)
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Test the JSON output of every code satisfies the schema
+func TestOutputSchemaMatchesOutput(t *testing.T) {
+	var schemaBuf bytes.Buffer
+	if err := printSchema(&schemaBuf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Type  string `json:"type"`
+		Items struct {
+			Properties map[string]struct {
+				Type    string   `json:"type"`
+				Minimum int      `json:"minimum"`
+				Maximum int      `json:"maximum"`
+				Enum    []string `json:"enum"`
+			} `json:"properties"`
+			Required []string `json:"required"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(schemaBuf.Bytes(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Type != "array" {
+		t.Errorf("Expected an array schema, got %s", schema.Type)
+	}
+
+	var out bytes.Buffer
+	printJSON(&out, prepareOutputs(statusCodes, false, true), false)
+	var records []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &records); err != nil {
+		t.Fatal(err)
+	}
+
+	code := schema.Items.Properties["code"]
+	types := map[string]bool{}
+	for _, typ := range schema.Items.Properties["type"].Enum {
+		types[typ] = true
+	}
+	for _, record := range records {
+		for name := range record {
+			if _, ok := schema.Items.Properties[name]; !ok {
+				t.Errorf("Field %s of %v is not in the schema", name, record["code"])
+			}
+		}
+		for _, name := range schema.Items.Required {
+			if _, ok := record[name]; !ok {
+				t.Errorf("Required field %s missing from %v", name, record["code"])
+			}
+		}
+		if c := int(record["code"].(float64)); c < code.Minimum || c > code.Maximum {
+			t.Errorf("Code %d is outside %d-%d", c, code.Minimum, code.Maximum)
+		}
+		if !types[record["type"].(string)] {
+			t.Errorf("Type %v of %v is not in the enum", record["type"], record["code"])
+		}
+	}
+}
+
+// Test the YAML schema matches the JSON one
+func TestOutputSchemaYAML(t *testing.T) {
+	var jsonBuf, yamlBuf bytes.Buffer
+	if err := printSchema(&jsonBuf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := printSchema(&yamlBuf, "yaml"); err != nil {
+		t.Fatal(err)
+	}
+
+	var fromJSON, fromYAML interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(yamlBuf.Bytes(), &fromYAML); err != nil {
+		t.Fatal(err)
+	}
+	// Compare through JSON so number types line up
+	a, _ := json.Marshal(fromJSON)
+	b, _ := json.Marshal(fromYAML)
+	if !bytes.Equal(a, b) {
+		t.Errorf("YAML schema differs from JSON:\n%s\n%s", a, b)
+	}
+
+	if err := printSchema(&yamlBuf, "toml"); err == nil {
+		t.Error("Expected an error for an unknown schema format")
+	}
+}
+
+// Test --schema xml is the XSD and --to-file uses its extension
+func TestSchemaXML(t *testing.T) {
+	var schema, xsd bytes.Buffer
+	if err := printSchema(&schema, "xml"); err != nil {
+		t.Fatal(err)
+	}
+	printXSD(&xsd)
+	if schema.String() != xsd.String() {
+		t.Error("Expected --schema xml to print the XSD")
+	}
+
+	base := t.TempDir() + "/codes"
+	if err := writeSchema("xml", base); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(base + ".xsd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != xsd.String() {
+		t.Error("Expected the .xsd file to hold the XSD")
+	}
+	if err := writeSchema("toml", base); err == nil {
+		t.Error("Expected an error for an unknown schema format")
+	}
+}
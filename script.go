@@ -0,0 +1,78 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// printShortOnly prints the reason phrase of each code on a line of its
+// own for scripts. A code without one gets an empty line, or its number
+// when fallback is set
+func printShortOnly(w io.Writer, codes []StatusCode, fallback bool) error {
+	for _, sc := range codes {
+		short := ""
+		if sc.Short != nil {
+			short = *sc.Short
+		}
+		if short == "" && fallback {
+			short = strconv.Itoa(sc.Code)
+		}
+		if _, err := fmt.Fprintln(w, short); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// codeNumbers returns the number of each code, for --codes-only --json
+func codeNumbers(codes []StatusCode) []int {
+	numbers := make([]int, len(codes))
+	for i, sc := range codes {
+		numbers[i] = sc.Code
+	}
+	return numbers
+}
+
+// printCodesOnly prints just the code numbers, one per line or, with
+// oneLine, separated by single spaces on one line
+func printCodesOnly(w io.Writer, codes []StatusCode, oneLine bool) error {
+	sep := "\n"
+	if oneLine {
+		sep = " "
+	}
+	var b strings.Builder
+	for i, n := range codeNumbers(codes) {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(strconv.Itoa(n))
+	}
+	if len(codes) > 0 {
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
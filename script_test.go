@@ -0,0 +1,104 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Test --short-only prints one bare reason phrase per code, in input order
+func TestPrintShortOnly(t *testing.T) {
+	results, err := lookup(lookupQuery{args: []string{"503", "200", "404"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printShortOnly(&buf, results, false); err != nil {
+		t.Fatal(err)
+	}
+	want := "Service Unavailable\nOK\nNot Found\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+// Test a code without a reason phrase gets an empty line or its number
+func TestPrintShortOnlyFallback(t *testing.T) {
+	codes := []StatusCode{{Code: 299, Short: strPtr("")}, {Code: 404, Short: strPtr("Not Found")}, {Code: 599}}
+	tests := map[bool]string{
+		false: "\nNot Found\n\n",
+		true:  "299\nNot Found\n599\n",
+	}
+	for fallback, want := range tests {
+		var buf bytes.Buffer
+		if err := printShortOnly(&buf, codes, fallback); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != want {
+			t.Errorf("fallback %v: expected %q, got %q", fallback, want, buf.String())
+		}
+	}
+}
+
+// Test --codes-only prints bare numbers with no trailing whitespace
+func TestPrintCodesOnly(t *testing.T) {
+	results, err := lookup(lookupQuery{args: []string{"3"}, exclude: []string{"305-307"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err = sortResults(results, "code", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[bool]string{
+		false: "308\n304\n303\n302\n301\n300\n",
+		true:  "308 304 303 302 301 300\n",
+	}
+	for oneLine, want := range tests {
+		var buf bytes.Buffer
+		if err := printCodesOnly(&buf, results, oneLine); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != want {
+			t.Errorf("one line %v: expected %q, got %q", oneLine, want, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	printJSONValue(&buf, codeNumbers(results), false)
+	if want := "[308,304,303,302,301,300]\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+// Test nothing at all is printed for no codes
+func TestPrintCodesOnlyEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printCodesOnly(&buf, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,1003 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+//go:embed web/index.html
+var indexHTML string
+
+// indexTemplate renders the HTML lookup page
+var indexTemplate = template.Must(template.New("index").Parse(indexHTML))
+
+// apiServer serves the status code dataset over HTTP
+type apiServer struct {
+	codes []StatusCode
+
+	// corsOrigins lists the origins allowed to call the API from a
+	// browser; "*" allows any origin
+	corsOrigins     []string
+	corsCredentials bool
+
+	// datasetHash identifies the loaded dataset for ETags
+	datasetHash string
+	cacheMaxAge time.Duration
+
+	// defaultFormat is used when neither ?format= nor the Accept header
+	// asks for a specific representation
+	defaultFormat responseFormat
+
+	// limiter rejects clients over --rate-limit with 429; nil when unlimited
+	limiter *rateLimiter
+
+	// notReady holds the reason the server is not ready, or "" once ready
+	notReady atomic.Value
+}
+
+// healthStatus is the JSON body of the health endpoints
+type healthStatus struct {
+	Status string `json:"status"`
+}
+
+// apiError is the JSON body returned for failed API requests
+type apiError struct {
+	Error     string      `json:"error"`
+	Supported []string    `json:"supported,omitempty"`
+	Status    *StatusCode `json:"status,omitempty"`
+}
+
+// responseFormat is a representation the API can produce for status codes;
+// pretty is the ?pretty=1 variant, when the format has one
+type responseFormat struct {
+	name      string
+	mediaType string
+	print     func(w io.Writer, codes []StatusCode)
+	pretty    func(w io.Writer, codes []StatusCode)
+}
+
+// responseFormats lists the API representations in order of preference;
+// the first entry is the default
+var responseFormats = []responseFormat{
+	{"json", "application/json",
+		func(w io.Writer, codes []StatusCode) { printJSON(w, codes, false) },
+		func(w io.Writer, codes []StatusCode) { printJSON(w, codes, true) }},
+	{"csv", "text/csv", printCSV, nil},
+	{"xml", "application/xml",
+		func(w io.Writer, codes []StatusCode) { printXML(w, codes, false) },
+		func(w io.Writer, codes []StatusCode) { printXML(w, codes, true) }},
+	// YAML is a single sequence of codes, like the CLI's --yaml, so a
+	// client decodes any response as one document
+	{"yaml", "application/yaml", func(w io.Writer, codes []StatusCode) { printYAML(w, codes, false) }, nil},
+	{"markdown", "text/markdown", printMarkdown, nil},
+}
+
+// responseFormatNames lists the values accepted by ?format= and --default-format
+func responseFormatNames() []string {
+	var names []string
+	for _, f := range responseFormats {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+// findResponseFormat looks up a format by name, ignoring case
+func findResponseFormat(name string) (responseFormat, bool) {
+	for _, f := range responseFormats {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return responseFormat{}, false
+}
+
+// newAPIServer creates an API server over the given dataset
+func newAPIServer(codes []StatusCode) *apiServer {
+	s := &apiServer{
+		codes:         codes,
+		datasetHash:   hashDataset(codes),
+		cacheMaxAge:   time.Hour,
+		defaultFormat: responseFormats[0],
+	}
+	if len(codes) == 0 {
+		s.setNotReady("dataset is empty")
+	} else {
+		s.setNotReady("")
+	}
+	return s
+}
+
+// setNotReady marks the server as not ready for traffic; an empty reason marks it ready
+func (s *apiServer) setNotReady(reason string) {
+	s.notReady.Store(reason)
+}
+
+// hashDataset returns a digest of the dataset contents
+func hashDataset(codes []StatusCode) string {
+	data, err := json.Marshal(codes)
+	if err != nil {
+		log.Fatalf("JSON error: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// notModified sets the caching headers for a representation and reports
+// whether the client's cached copy is current, in which case a 304 has
+// already been written. The ETag covers the dataset, the path, the query
+// parameters and the representation variant.
+func (s *apiServer) notModified(w http.ResponseWriter, r *http.Request, variant string) bool {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s?%s\n%s", s.datasetHash, r.URL.Path, r.URL.Query().Encode(), variant)
+	etag := `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.cacheMaxAge.Seconds())))
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag,
+// using the weak comparison If-None-Match calls for
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// routes registers the API handlers
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", s.limited(http.HandlerFunc(s.handleIndex)))
+	for _, route := range s.apiRoutes() {
+		s.handleAPI(mux, route.path, route.handler)
+	}
+	return mux
+}
+
+// apiParam describes a path or query parameter of an API endpoint
+type apiParam struct {
+	name        string
+	in          string
+	description string
+	schemaType  string
+	required    bool
+}
+
+// apiRoute describes an API endpoint; the table drives both handler
+// registration and the OpenAPI document
+type apiRoute struct {
+	path        string
+	operationID string
+	summary     string
+	handler     http.HandlerFunc
+	params      []apiParam
+	// response names the component schema of the JSON response; endpoints
+	// returning status codes also offer every responseFormats media type
+	response string
+	errors   []int
+	// cacheable routes set an ETag and answer a matching If-None-Match
+	// with 304
+	cacheable bool
+}
+
+// formatParam is the ?format= override shared by the status code endpoints
+var formatParam = apiParam{"format", "query", "Response format, overriding the Accept header (json, csv, xml, yaml, markdown)", "string", false}
+
+// prettyParam selects the indented variant of the json and xml formats
+var prettyParam = apiParam{"pretty", "query", "Indent json and xml responses (1 or true)", "boolean", false}
+
+// apiRoutes returns the API endpoint table
+func (s *apiServer) apiRoutes() []apiRoute {
+	return []apiRoute{
+		{
+			path:        "/status/{code}",
+			operationID: "getStatus",
+			summary:     "Look up a single status code",
+			handler:     s.handleStatus,
+			params: []apiParam{
+				{"code", "path", "The HTTP status code", "integer", true},
+				formatParam,
+				prettyParam,
+			},
+			response:  "StatusCodeList",
+			errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable},
+			cacheable: true,
+		},
+		{
+			path:        "/status",
+			operationID: "listStatuses",
+			summary:     "List status codes filtered by code prefix and keyword",
+			handler:     s.handleStatusList,
+			params: []apiParam{
+				{"class", "query", "Code prefix, e.g. 4 for all 4xx codes or 41 for 410-419", "string", false},
+				{"search", "query", "Keyword to match in the short or long description", "string", false},
+				formatParam,
+				prettyParam,
+			},
+			response:  "StatusCodeList",
+			errors:    []int{http.StatusBadRequest, http.StatusNotAcceptable},
+			cacheable: true,
+		},
+		{
+			path:        "/types",
+			operationID: "listTypes",
+			summary:     "List the status types",
+			handler:     s.handleTypes,
+			response:    "TypeList",
+			cacheable:   true,
+		},
+		{
+			path:        "/healthz",
+			operationID: "getHealth",
+			summary:     "Liveness check, always OK once the process is up",
+			handler:     s.handleHealth,
+			response:    "Health",
+		},
+		{
+			path:        "/readyz",
+			operationID: "getReadiness",
+			summary:     "Readiness check, failing until the dataset is loaded and during shutdown",
+			handler:     s.handleReady,
+			response:    "Health",
+			errors:      []int{http.StatusServiceUnavailable},
+		},
+		{
+			path:        "/openapi.json",
+			operationID: "getOpenAPI",
+			summary:     "This OpenAPI description",
+			handler:     s.handleOpenAPI,
+			response:    "OpenAPI",
+			cacheable:   true,
+		},
+	}
+}
+
+// handleAPI registers an API endpoint for GET and for CORS preflight
+// requests. CORS wraps the rate limit, so a browser can read a 429 too
+func (s *apiServer) handleAPI(mux *http.ServeMux, path string, h http.HandlerFunc) {
+	mux.Handle("GET "+path, s.cors(s.limited(h)))
+	mux.Handle("OPTIONS "+path, s.cors(s.limited(h)))
+}
+
+// limited applies --rate-limit to a route. It runs inside the mux, so a
+// rejected request is still labelled with its route in the metrics
+func (s *apiServer) limited(h http.Handler) http.Handler {
+	if s.limiter == nil {
+		return h
+	}
+	return s.limiter.middleware(h)
+}
+
+// cors adds CORS headers for allowed origins and answers preflight requests
+func (s *apiServer) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(s.corsOrigins) > 0 {
+			w.Header().Add("Vary", "Origin")
+		}
+
+		allowed := origin != "" && s.originAllowed(origin)
+		if allowed {
+			// Credentialed requests may not use the wildcard
+			if s.corsCredentials || !slices.Contains(s.corsOrigins, "*") {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+			if s.corsCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if r.Method != http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if allowed && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+			if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.Header().Set("Access-Control-Max-Age", "600")
+		}
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// originAllowed reports whether a browser origin may call the API
+func (s *apiServer) originAllowed(origin string) bool {
+	for _, allowed := range s.corsOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleIndex renders the HTML lookup page; the table is rendered
+// server-side from the q parameter so the page works without JavaScript
+func (s *apiServer) handleIndex(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	var codes []StatusCode
+	if _, err := strconv.Atoi(query); err == nil {
+		codes = filterStatusCodes(s.codes, query, "")
+	} else {
+		codes = filterStatusCodes(s.codes, "", query)
+	}
+
+	data := struct {
+		Query      string
+		Codes      []StatusCode
+		AppName    string
+		AppVersion string
+		GitHubURL  string
+	}{query, codes, AppName, AppVersion, GitHubURL}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, data); err != nil {
+		log.Printf("Error rendering index: %v", err)
+	}
+}
+
+// handleHealth reports that the process is alive
+func (s *apiServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, healthStatus{Status: "ok"})
+}
+
+// handleReady reports whether the server should receive traffic
+func (s *apiServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if reason := s.notReady.Load().(string); reason != "" {
+		writeAPIError(w, http.StatusServiceUnavailable, "not ready: "+reason)
+		return
+	}
+	writeJSON(w, http.StatusOK, healthStatus{Status: "ready"})
+}
+
+// handleStatus returns a single status code
+func (s *apiServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	raw := r.PathValue("code")
+	code, err := strconv.Atoi(raw)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid status code: '%s' - must be numeric", raw))
+		return
+	}
+
+	for _, sc := range s.codes {
+		if sc.Code == code {
+			s.writeCodes(w, r, []StatusCode{sc})
+			return
+		}
+	}
+	writeAPIError(w, http.StatusNotFound, fmt.Sprintf("unknown status code: %d", code))
+}
+
+// handleStatusList returns the status codes matching the class and search filters
+func (s *apiServer) handleStatusList(w http.ResponseWriter, r *http.Request) {
+	class := strings.TrimSpace(r.URL.Query().Get("class"))
+	search := r.URL.Query().Get("search")
+
+	if class != "" {
+		if _, err := strconv.Atoi(class); err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid class: '%s' - must be numeric", class))
+			return
+		}
+	}
+
+	s.writeCodes(w, r, filterStatusCodes(s.codes, class, search))
+}
+
+// handleTypes returns the distinct status types in dataset order
+func (s *apiServer) handleTypes(w http.ResponseWriter, r *http.Request) {
+	types := []string{}
+	seen := make(map[string]bool)
+	for _, sc := range s.codes {
+		if !seen[sc.Type] {
+			seen[sc.Type] = true
+			types = append(types, sc.Type)
+		}
+	}
+	if s.notModified(w, r, "application/json") {
+		return
+	}
+	writeJSON(w, http.StatusOK, types)
+}
+
+// filterStatusCodes returns the codes starting with prefix and matching
+// search; an empty prefix or search term matches everything
+func filterStatusCodes(codes []StatusCode, prefix, search string) []StatusCode {
+	results := []StatusCode{}
+	lowerTerm := strings.ToLower(search)
+	for _, sc := range codes {
+		if prefix != "" && !strings.HasPrefix(strconv.Itoa(sc.Code), prefix) {
+			continue
+		}
+		if search != "" && !matchesSearch(sc, lowerTerm) {
+			continue
+		}
+		results = append(results, sc)
+	}
+	return results
+}
+
+// writeCodes writes status codes in the representation negotiated from the
+// format query parameter or the Accept header
+func (s *apiServer) writeCodes(w http.ResponseWriter, r *http.Request, codes []StatusCode) {
+	w.Header().Add("Vary", "Accept")
+
+	query := r.URL.Query()
+	if name := query.Get("format"); name != "" {
+		if _, ok := findResponseFormat(name); !ok {
+			writeJSON(w, http.StatusBadRequest, apiError{
+				Error:     fmt.Sprintf("invalid format: '%s' - must be one of %s", name, strings.Join(responseFormatNames(), ", ")),
+				Supported: responseFormatNames(),
+			})
+			return
+		}
+	}
+	pretty := false
+	if value := query.Get("pretty"); value != "" {
+		var err error
+		if pretty, err = strconv.ParseBool(value); err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid pretty value: '%s' - must be 1, 0, true or false", value))
+			return
+		}
+	}
+
+	format, ok := negotiateFormat(r, s.defaultFormat)
+	if !ok {
+		var supported []string
+		for _, f := range responseFormats {
+			supported = append(supported, f.mediaType)
+		}
+		writeJSON(w, http.StatusNotAcceptable, apiError{Error: "none of the requested formats are supported", Supported: supported})
+		return
+	}
+
+	// The representation, not just the media type, identifies the variant
+	render := format.print
+	if pretty && format.pretty != nil {
+		render = format.pretty
+	}
+	if s.notModified(w, r, fmt.Sprintf("%s;pretty=%t", format.mediaType, pretty && format.pretty != nil)) {
+		return
+	}
+	w.Header().Set("Content-Type", format.mediaType)
+	w.WriteHeader(http.StatusOK)
+	render(w, codes)
+}
+
+// negotiateFormat picks the response format, preferring an explicit
+// ?format= parameter over the Accept header; the default wins when the
+// client has no preference or a wildcard matches it
+func negotiateFormat(r *http.Request, def responseFormat) (responseFormat, bool) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		return findResponseFormat(name)
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return def, true
+	}
+
+	// Try each media range from highest to lowest quality
+	ranges := parseAccept(accept)
+	for _, mr := range ranges {
+		if mr.quality <= 0 {
+			continue
+		}
+		if mediaRangeMatches(mr.mediaRange, def.mediaType) {
+			return def, true
+		}
+		for _, f := range responseFormats {
+			if mediaRangeMatches(mr.mediaRange, f.mediaType) {
+				return f, true
+			}
+		}
+	}
+	return responseFormat{}, false
+}
+
+// acceptRange is a single media range from an Accept header
+type acceptRange struct {
+	mediaRange string
+	quality    float64
+}
+
+// parseAccept splits an Accept header into media ranges sorted by quality
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		mr := acceptRange{mediaRange: strings.ToLower(strings.TrimSpace(params[0])), quality: 1}
+		if mr.mediaRange == "" {
+			continue
+		}
+		for _, p := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					mr.quality = q
+				}
+			}
+		}
+		ranges = append(ranges, mr)
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges
+}
+
+// mediaRangeMatches reports whether a media range such as "text/*" covers a media type
+func mediaRangeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return false
+}
+
+// writeJSON writes v as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
+
+// writeAPIError writes a JSON error body with the given status
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Error: message})
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush passes flushes through so streaming responses are not buffered
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// runServe implements "httpstatus serve [flags]"
+func runServe(args []string) error {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "Address to listen on (host:port, port, or unix:///path/to.sock)")
+	drainTimeout := fs.Duration("drain-timeout", 10*time.Second, "Time allowed for in-flight requests to finish on shutdown")
+	shutdownDelay := fs.Duration("shutdown-delay", 5*time.Second, "Time to keep serving with /readyz failing before shutting down, so load balancers stop sending traffic")
+	socketMode := fs.String("socket-mode", "0660", "File permissions for a unix socket")
+	tlsCert := fs.String("tls-cert", "", "TLS certificate file (PEM)")
+	tlsKey := fs.String("tls-key", "", "TLS private key file (PEM)")
+	tlsSelfSigned := fs.Bool("tls-self-signed", false, "Serve HTTPS with a generated self-signed certificate")
+	var corsOrigins stringList
+	fs.Var(&corsOrigins, "cors-origin", "Origin allowed to call the API from a browser (repeatable, * for any)")
+	corsCredentials := fs.Bool("cors-credentials", false, "Allow credentialed cross-origin requests")
+	cacheMaxAge := fs.Duration("cache-max-age", time.Hour, "Cache-Control max-age for API responses")
+	rateLimit := fs.String("rate-limit", "0", "Requests allowed per client IP, e.g. 100/min (0 disables)")
+	trustedProxies := fs.String("trusted-proxies", "", "Comma-separated proxy IPs/CIDRs whose X-Forwarded-For is honoured")
+	metricsEnabled := fs.Bool("metrics", true, "Expose Prometheus metrics at /metrics")
+	metricsAddr := fs.String("metrics-addr", "", "Separate address to serve /metrics on instead of --addr")
+	defaultFormat := fs.String("default-format", "json", "Response format when the client has no preference: "+strings.Join(responseFormatNames(), ", "))
+	accessLog := fs.String("access-log", "", "File to append JSON access logs to (default stderr)")
+	noAccessLog := fs.Bool("no-access-log", false, "Disable access logging")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("serve takes no arguments, got: '%s'", strings.Join(positional, " "))
+	}
+
+	mode, err := strconv.ParseUint(*socketMode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("invalid socket mode: '%s' - must be octal", *socketMode)
+	}
+
+	format, ok := findResponseFormat(*defaultFormat)
+	if !ok {
+		return fmt.Errorf("invalid default format: '%s' - must be one of %s", *defaultFormat, strings.Join(responseFormatNames(), ", "))
+	}
+
+	limit, period, err := parseRateLimit(*rateLimit)
+	if err != nil {
+		return err
+	}
+	trusted, err := parseTrustedProxies(*trustedProxies)
+	if err != nil {
+		return err
+	}
+
+	if *noAccessLog && *accessLog != "" {
+		return fmt.Errorf("--access-log cannot be combined with --no-access-log")
+	}
+
+	tlsConfig, err := serverTLSConfig(*tlsCert, *tlsKey, *tlsSelfSigned)
+	if err != nil {
+		return err
+	}
+
+	var logOut io.Writer = os.Stderr
+	if *accessLog != "" {
+		f, err := os.OpenFile(*accessLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+		if err != nil {
+			return fmt.Errorf("opening access log: %v", err)
+		}
+		defer f.Close()
+		logOut = f
+	}
+
+	ln, err := listen(*addr, os.FileMode(mode))
+	if err != nil {
+		return err
+	}
+
+	api := newAPIServer(statusCodes)
+	api.corsOrigins = corsOrigins
+	api.corsCredentials = *corsCredentials
+	api.cacheMaxAge = *cacheMaxAge
+	api.defaultFormat = format
+
+	if limit > 0 {
+		api.limiter = newRateLimiter(limit, period, trusted)
+	}
+	handler := api.routes()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *metricsEnabled {
+		var metricsHandler http.Handler
+		handler, metricsHandler = withMetrics(handler, newServerMetrics(len(statusCodes)), *metricsAddr != "")
+		if *metricsAddr != "" {
+			mln, err := listen(*metricsAddr, os.FileMode(mode))
+			if err != nil {
+				ln.Close()
+				return err
+			}
+			msrv := &http.Server{Handler: metricsHandler, ReadHeaderTimeout: 10 * time.Second}
+			log.Printf("Serving metrics on %s", mln.Addr())
+			go func() {
+				if err := serveUntilDone(ctx, msrv, mln, *drainTimeout); err != nil {
+					log.Printf("Metrics server: %v", err)
+				}
+			}()
+		}
+	}
+
+	if !*noAccessLog {
+		handler = newAccessLogger(logOut, trusted).middleware(handler)
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
+	}
+
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	log.Printf("Listening on %s (%s)", ln.Addr(), scheme)
+
+	// Once the first signal arrives a second one skips --shutdown-delay,
+	// so an interactive Ctrl-C Ctrl-C quits at once
+	again := make(chan os.Signal, 1)
+	go func() {
+		<-ctx.Done()
+		signal.Notify(again, os.Interrupt, syscall.SIGTERM)
+		stop()
+	}()
+	return serveUntilDone(api.drainContext(ctx, *shutdownDelay, again), srv, ln, *drainTimeout)
+}
+
+// withMetrics records metrics for the API handler and serves them at
+// /metrics. With a separate listener the metrics handler is returned for
+// it; otherwise /metrics is served alongside the API and metricsHandler
+// is nil
+func withMetrics(api http.Handler, metrics *serverMetrics, separate bool) (handler, metricsHandler http.Handler) {
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics)
+	handler = metrics.middleware(api)
+	if separate {
+		return handler, metricsMux
+	}
+	metricsMux.Handle("/", handler)
+	return metricsMux, nil
+}
+
+// drainContext returns a context cancelled delay after ctx is. The server
+// is marked not ready as soon as ctx is cancelled and keeps serving in
+// the meantime, so load balancers see /readyz fail and stop sending
+// traffic before the listener closes. A signal on skip ends the delay
+// early
+func (s *apiServer) drainContext(ctx context.Context, delay time.Duration, skip <-chan os.Signal) context.Context {
+	drainCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-ctx.Done()
+		s.setNotReady("shutting down")
+		if delay > 0 {
+			log.Printf("Not ready, shutting down in %s (signal again to shut down now)", delay)
+			select {
+			case <-time.After(delay):
+			case <-skip:
+				log.Printf("Skipping the rest of the shutdown delay")
+			}
+		}
+		cancel()
+	}()
+	return drainCtx
+}
+
+// serverTLSConfig builds the TLS configuration for serve, or returns nil
+// when TLS is not enabled
+func serverTLSConfig(certFile, keyFile string, selfSigned bool) (*tls.Config, error) {
+	if selfSigned && (certFile != "" || keyFile != "") {
+		return nil, fmt.Errorf("--tls-self-signed cannot be combined with --tls-cert or --tls-key")
+	}
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+
+	if selfSigned {
+		cert, err := generateSelfSignedCert()
+		if err != nil {
+			return nil, fmt.Errorf("generating self-signed certificate: %v", err)
+		}
+		log.Printf("Using self-signed certificate with SHA-256 fingerprint %s", certFingerprint(cert))
+		return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+	}
+
+	if certFile == "" {
+		return nil, nil
+	}
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	reloader.watchSIGHUP()
+	return &tls.Config{GetCertificate: reloader.getCertificate, MinVersion: tls.VersionTLS12}, nil
+}
+
+// certReloader serves a certificate loaded from disk and reloads it on demand
+type certReloader struct {
+	certFile string
+	keyFile  string
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+}
+
+// newCertReloader loads the key pair, failing if it is missing or mismatched
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload reads the key pair from disk, keeping the old one on failure
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("invalid TLS certificate/key pair (%s, %s): %v", r.certFile, r.keyFile, err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// watchSIGHUP reloads the certificate whenever the process receives SIGHUP
+func (r *certReloader) watchSIGHUP() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		for range ch {
+			if err := r.reload(); err != nil {
+				log.Printf("Keeping previous certificate: %v", err)
+				continue
+			}
+			log.Printf("Reloaded TLS certificate from %s", r.certFile)
+		}
+	}()
+}
+
+// generateSelfSignedCert creates an in-memory certificate for localhost
+// and the machine's hostname, valid for one year
+func generateSelfSignedCert() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: AppName + " self-signed"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// certFingerprint returns the colon-separated SHA-256 fingerprint of a certificate's leaf
+func certFingerprint(cert tls.Certificate) string {
+	sum := sha256.Sum256(cert.Certificate[0])
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// listen opens a listener for a TCP host:port, a bare port, or a
+// unix:///path/to.sock address
+func listen(addr string, socketMode os.FileMode) (net.Listener, error) {
+	path, isUnix := strings.CutPrefix(addr, "unix://")
+	if !isUnix {
+		if _, err := strconv.Atoi(addr); err == nil {
+			addr = ":" + addr
+		}
+		return net.Listen("tcp", addr)
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("invalid address: '%s' - missing socket path", addr)
+	}
+	// Remove a socket left behind by a previous run, but never a regular file
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("cannot listen on %s: file exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, socketMode); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// serveUntilDone serves on ln until ctx is cancelled, then stops accepting
+// new connections and waits up to drainTimeout for in-flight requests
+func serveUntilDone(ctx context.Context, srv *http.Server, ln net.Listener, drainTimeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		// ServeTLS also enables HTTP/2
+		if srv.TLSConfig != nil {
+			errCh <- srv.ServeTLS(ln, "", "")
+			return
+		}
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down, waiting up to %s for in-flight requests", drainTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return fmt.Errorf("shutdown did not complete within %s: %v", drainTimeout, err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,1003 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serveRequest runs a request against the API handlers
+func serveRequest(t *testing.T, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	rec := httptest.NewRecorder()
+	newAPIServer(statusCodes).routes().ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeCodes decodes a JSON array of status codes from a response
+func decodeCodes(t *testing.T, rec *httptest.ResponseRecorder) []StatusCode {
+	t.Helper()
+	var codes []StatusCode
+	if err := json.Unmarshal(rec.Body.Bytes(), &codes); err != nil {
+		t.Fatalf("Invalid JSON response: %v\n%s", err, rec.Body.String())
+	}
+	return codes
+}
+
+// Test GET /status/{code} for a known code
+func TestServeStatus(t *testing.T) {
+	rec := serveRequest(t, "GET", "/status/404")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Unexpected content type: %s", ct)
+	}
+
+	codes := decodeCodes(t, rec)
+	if len(codes) != 1 || codes[0].Code != 404 || *codes[0].Short != "Not Found" || codes[0].Long == nil {
+		t.Errorf("Unexpected response: %+v", codes)
+	}
+}
+
+// Test GET /status/{code} error cases
+func TestServeStatusErrors(t *testing.T) {
+	testCases := []struct {
+		target string
+		status int
+		errMsg string
+	}{
+		{"/status/999", http.StatusNotFound, "unknown status code: 999"},
+		{"/status/abc", http.StatusBadRequest, "invalid status code: 'abc' - must be numeric"},
+		{"/status?class=x", http.StatusBadRequest, "invalid class: 'x' - must be numeric"},
+	}
+
+	for _, tc := range testCases {
+		rec := serveRequest(t, "GET", tc.target)
+		if rec.Code != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.target, tc.status, rec.Code)
+		}
+		var body apiError
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: invalid JSON error body: %v", tc.target, err)
+		}
+		if body.Error != tc.errMsg {
+			t.Errorf("%s: expected error '%s', got '%s'", tc.target, tc.errMsg, body.Error)
+		}
+	}
+}
+
+// Test GET /status filter combinations
+func TestServeStatusList(t *testing.T) {
+	testCases := []struct {
+		target   string
+		expected []int
+	}{
+		{"/status?class=4&search=timeout", []int{408}},
+		{"/status?search=timeout", []int{408, 504}},
+		{"/status?class=20", []int{200, 201, 202, 203, 204, 205, 206, 207, 208}},
+		{"/status?class=1&search=teapot", []int{}},
+	}
+
+	for _, tc := range testCases {
+		rec := serveRequest(t, "GET", tc.target)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", tc.target, rec.Code)
+		}
+		codes := decodeCodes(t, rec)
+		if len(codes) != len(tc.expected) {
+			t.Fatalf("%s: expected %v, got %+v", tc.target, tc.expected, codes)
+		}
+		for i, sc := range codes {
+			if sc.Code != tc.expected[i] {
+				t.Errorf("%s: expected code %d at %d, got %d", tc.target, tc.expected[i], i, sc.Code)
+			}
+		}
+	}
+
+	// No filters returns the full dataset
+	rec := serveRequest(t, "GET", "/status")
+	if codes := decodeCodes(t, rec); len(codes) != len(statusCodes) {
+		t.Errorf("Expected %d codes, got %d", len(statusCodes), len(codes))
+	}
+
+	// An empty result is an empty array, not null
+	rec = serveRequest(t, "GET", "/status?class=1&search=teapot")
+	if strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("Expected empty array, got %s", rec.Body.String())
+	}
+}
+
+// Test GET /types
+func TestServeTypes(t *testing.T) {
+	rec := serveRequest(t, "GET", "/types")
+	var types []string
+	if err := json.Unmarshal(rec.Body.Bytes(), &types); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+
+	expected := []string{"Informational", "Success", "Redirection", "Client Error", "Server Error"}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected types %v, got %v", expected, types)
+	}
+}
+
+// Test the liveness and readiness endpoints
+func TestServeHealth(t *testing.T) {
+	for _, path := range []string{"/healthz", "/readyz"} {
+		rec := serveRequest(t, "GET", path)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, rec.Code)
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != "no-store" {
+			t.Errorf("%s: expected Cache-Control no-store, got %q", path, cc)
+		}
+	}
+
+	api := newAPIServer(nil)
+	rec := httptest.NewRecorder()
+	api.routes().ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "dataset is empty") {
+		t.Errorf("Expected 503 for an empty dataset, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// Test /readyz fails over the network after the signal, while the
+// listener is still accepting, and the listener closes after the delay
+func TestServeReadinessDuringShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newAPIServer(statusCodes)
+	srv := &http.Server{Handler: api.routes()}
+	url := "http://" + ln.Addr().String()
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: time.Second}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serveUntilDone(api.drainContext(ctx, 300*time.Millisecond, nil), srv, ln, time.Second) }()
+
+	get := func(path string) (int, string, error) {
+		resp, err := client.Get(url + path)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), nil
+	}
+	if code, _, err := get("/readyz"); err != nil || code != http.StatusOK {
+		t.Fatalf("Expected 200 before shutdown, got %d, %v", code, err)
+	}
+
+	cancel()
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for {
+		code, body, err := get("/readyz")
+		if err != nil {
+			t.Fatalf("Listener closed before /readyz reported 503: %v", err)
+		}
+		if code == http.StatusServiceUnavailable {
+			if !strings.Contains(body, "shutting down") {
+				t.Errorf("Expected shutdown reason in body, got %s", body)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 503 during shutdown, still got %d", code)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Liveness is unaffected while the server waits
+	if code, _, err := get("/healthz"); err != nil || code != http.StatusOK {
+		t.Errorf("Expected /healthz to stay 200, got %d, %v", code, err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+	if _, _, err := get("/readyz"); err == nil {
+		t.Error("Expected the listener to be closed after the delay")
+	}
+}
+
+// Test a YAML list response is one document holding a sequence of codes
+func TestServeYAMLSequence(t *testing.T) {
+	rec := serveRequest(t, "GET", "/status?class=40&format=yaml")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(rec.Body.String()))
+	var codes []StatusCode
+	if err := dec.Decode(&codes); err != nil {
+		t.Fatalf("Expected a YAML sequence: %v\n%s", err, rec.Body.String())
+	}
+	if len(codes) != 10 || codes[0].Code != 400 || codes[9].Code != 409 {
+		t.Errorf("Unexpected codes: %+v", codes)
+	}
+	var extra any
+	if err := dec.Decode(&extra); err != io.EOF {
+		t.Errorf("Expected a single YAML document, found more:\n%s", rec.Body.String())
+	}
+}
+
+// Test only GET is accepted
+func TestServeMethodNotAllowed(t *testing.T) {
+	rec := serveRequest(t, "POST", "/status/200")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
+
+// Test GET / renders the lookup page with all codes
+func TestServeIndex(t *testing.T) {
+	rec := serveRequest(t, "GET", "/")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Unexpected content type: %s", ct)
+	}
+
+	body := rec.Body.String()
+	expected := []string{
+		`<input type="search" id="q" name="q" value=""`,
+		`<tr><td class="code">200</td><td>Success</td><td>OK</td>`,
+		`<tr><td class="code">511</td>`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(body, exp) {
+			t.Errorf("Expected page to contain: %s", exp)
+		}
+	}
+}
+
+// Test GET /?q= filters the server-rendered table
+func TestServeIndexQuery(t *testing.T) {
+	testCases := []struct {
+		target  string
+		present []string
+		absent  []string
+	}{
+		{"/?q=teapot", []string{`<td class="code">418</td>`, `value="teapot"`}, []string{`<td class="code">200</td>`}},
+		{"/?q=50", []string{`<td class="code">502</td>`, `<td class="code">508</td>`}, []string{`<td class="code">510</td>`}},
+		{"/?q=nomatch", []string{"No HTTP status codes found matching your criteria"}, []string{`<td class="code">`}},
+	}
+
+	for _, tc := range testCases {
+		body := serveRequest(t, "GET", tc.target).Body.String()
+		for _, exp := range tc.present {
+			if !strings.Contains(body, exp) {
+				t.Errorf("%s: expected page to contain: %s", tc.target, exp)
+			}
+		}
+		for _, exp := range tc.absent {
+			if strings.Contains(body, exp) {
+				t.Errorf("%s: expected page not to contain: %s", tc.target, exp)
+			}
+		}
+	}
+}
+
+// Test the query is escaped when echoed into the page
+func TestServeIndexEscaping(t *testing.T) {
+	body := serveRequest(t, "GET", "/?q=%22%3E%3Cscript%3E").Body.String()
+	if strings.Contains(body, `"><script>`) {
+		t.Error("Query was not HTML-escaped")
+	}
+	if !strings.Contains(body, `value="&#34;&gt;&lt;script&gt;"`) {
+		t.Error("Expected escaped query in search box")
+	}
+}
+
+// Test content negotiation via the Accept header and format parameter
+func TestServeContentNegotiation(t *testing.T) {
+	testCases := []struct {
+		target      string
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"/status/404", "", "application/json", `"code":404`},
+		{"/status/404", "*/*", "application/json", `"code":404`},
+		{"/status/404", "application/json", "application/json", `"code":404`},
+		{"/status/404", "text/csv", "text/csv", "404,Client Error,Not Found"},
+		{"/status/404", "application/xml", "application/xml", "<code>404</code>"},
+		{"/status/404", "application/yaml", "application/yaml", "code: 404"},
+		{"/status/404", "text/markdown", "text/markdown", "| 404 | Client Error | Not Found |"},
+		{"/status/404", "text/html, text/csv;q=0.5, application/xml;q=0.9", "application/xml", "<code>404</code>"},
+		{"/status/404", "text/*", "text/csv", "Code,Type,Short,Long"},
+		{"/status?class=41", "text/markdown", "text/markdown", "| 418 | Client Error | I'm a teapot |"},
+		{"/status/404?format=csv", "application/xml", "text/csv", "404,Client Error,Not Found"},
+		{"/status?search=teapot&format=YAML", "", "application/yaml", "code: 418"},
+		{"/status/404?pretty=1", "", "application/json", "[\n  {\n    \"code\": 404"},
+		{"/status/404?format=xml&pretty=true", "", "application/xml", "\n  <http_status>\n    <code>404</code>"},
+		{"/status/404?format=csv&pretty=1", "", "text/csv", "404,Client Error,Not Found"},
+		{"/status/404?pretty=0", "", "application/json", `[{"code":404`},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest("GET", tc.target, nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+		rec := httptest.NewRecorder()
+		newAPIServer(statusCodes).routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s (%s): expected 200, got %d", tc.target, tc.accept, rec.Code)
+			continue
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tc.contentType {
+			t.Errorf("%s (%s): expected content type %s, got %s", tc.target, tc.accept, tc.contentType, ct)
+		}
+		if !strings.Contains(rec.Body.String(), tc.contains) {
+			t.Errorf("%s (%s): expected body to contain %q, got:\n%s", tc.target, tc.accept, tc.contains, rec.Body.String())
+		}
+		if vary := rec.Header().Get("Vary"); vary != "Accept" {
+			t.Errorf("%s (%s): expected Vary: Accept, got %q", tc.target, tc.accept, vary)
+		}
+	}
+}
+
+// Test unsupported formats return 406 with the supported list
+func TestServeNotAcceptable(t *testing.T) {
+	req := httptest.NewRequest("GET", "/status/404", nil)
+	req.Header.Set("Accept", "application/pdf, application/json;q=0")
+	rec := httptest.NewRecorder()
+	newAPIServer(statusCodes).routes().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotAcceptable {
+		t.Fatalf("Expected 406, got %d", rec.Code)
+	}
+	var body apiError
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON error body: %v", err)
+	}
+	if len(body.Supported) != len(responseFormats) || body.Supported[0] != "application/json" {
+		t.Errorf("Unexpected supported list: %v", body.Supported)
+	}
+}
+
+// Test invalid ?format= and ?pretty= values are rejected with 400
+func TestServeInvalidQueryFormat(t *testing.T) {
+	rec := serveRequest(t, "GET", "/status/404?format=pdf")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for unknown format, got %d", rec.Code)
+	}
+	var body apiError
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON error body: %v", err)
+	}
+	if strings.Join(body.Supported, ",") != strings.Join(responseFormatNames(), ",") {
+		t.Errorf("Expected supported formats %v, got %v", responseFormatNames(), body.Supported)
+	}
+	if !strings.Contains(body.Error, "'pdf'") {
+		t.Errorf("Expected the bad format in the error, got %q", body.Error)
+	}
+
+	rec = serveRequest(t, "GET", "/status?pretty=maybe")
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "invalid pretty value") {
+		t.Errorf("Expected 400 for invalid pretty value, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// Test the server default format applies only when the client has no preference
+func TestServeDefaultFormat(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	api.defaultFormat, _ = findResponseFormat("yaml")
+
+	testCases := []struct {
+		target      string
+		accept      string
+		contentType string
+	}{
+		{"/status/404", "", "application/yaml"},
+		{"/status/404", "*/*", "application/yaml"},
+		{"/status/404", "application/*", "application/yaml"},
+		{"/status/404", "text/*", "text/csv"},
+		{"/status/404", "application/json", "application/json"},
+		{"/status/404?format=xml", "", "application/xml"},
+	}
+	for _, tc := range testCases {
+		rec := serveWithHeaders(api, tc.target, map[string]string{"Accept": tc.accept})
+		if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || ct != tc.contentType {
+			t.Errorf("%s (%s): expected 200 %s, got %d %s", tc.target, tc.accept, tc.contentType, rec.Code, ct)
+		}
+	}
+}
+
+// Test listen accepts host:port and bare port addresses
+func TestListenTCP(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:0", "0"} {
+		ln, err := listen(addr, 0600)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", addr, err)
+		}
+		if _, ok := ln.Addr().(*net.TCPAddr); !ok {
+			t.Errorf("%s: expected TCP listener, got %T", addr, ln.Addr())
+		}
+		ln.Close()
+	}
+}
+
+// Test serving over a unix socket, including permissions and cleanup
+func TestServeUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "httpstatus.sock")
+	ln, err := listen("unix://"+path, 0600)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Socket not created: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected socket mode 0600, got %o", info.Mode().Perm())
+	}
+
+	srv := &http.Server{Handler: newAPIServer(statusCodes).routes()}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntilDone(ctx, srv, ln, time.Second)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://unix/status/418")
+	if err != nil {
+		t.Fatalf("Request over unix socket failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "I'm a teapot") {
+		t.Errorf("Unexpected response: %s", body)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected shutdown error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Socket file was not removed on shutdown")
+	}
+}
+
+// Test listen refuses to replace a regular file with a socket
+func TestListenUnixSocketExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-a-socket")
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := listen("unix://"+path, 0600); err == nil {
+		t.Error("Expected error when path is a regular file")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Regular file should not have been removed")
+	}
+}
+
+// Test shutdown lets in-flight requests finish
+func TestServeUntilDoneDrains(t *testing.T) {
+	started := make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})}
+	ln, err := listen("127.0.0.1:0", 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntilDone(ctx, srv, ln, time.Second)
+	}()
+
+	respCh := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			respCh <- 0
+			return
+		}
+		resp.Body.Close()
+		respCh <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	if code := <-respCh; code != http.StatusOK {
+		t.Errorf("In-flight request did not complete, got status %d", code)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected shutdown error: %v", err)
+	}
+}
+
+// Test shutdown gives up once the drain timeout passes
+func TestServeUntilDoneTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})}
+	ln, err := listen("127.0.0.1:0", 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntilDone(ctx, srv, ln, 50*time.Millisecond)
+	}()
+	go http.Get("http://" + ln.Addr().String())
+
+	<-started
+	start := time.Now()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected drain timeout error")
+		}
+		if time.Since(start) > time.Second {
+			t.Errorf("Shutdown took %s, expected about 50ms", time.Since(start))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shutdown did not complete within the deadline")
+	}
+}
+
+// writeKeyPair writes a certificate and its private key as PEM files
+func writeKeyPair(t *testing.T, dir, name string, cert tls.Certificate) (string, string) {
+	t.Helper()
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// Test serving HTTPS with a self-signed certificate over HTTP/2
+func TestServeTLSSelfSigned(t *testing.T) {
+	var logBuf bytes.Buffer
+	log.SetOutput(&logBuf)
+	defer func() {
+		log.SetOutput(os.Stderr)
+	}()
+
+	tlsConfig, err := serverTLSConfig("", "", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(logBuf.String(), "SHA-256 fingerprint") {
+		t.Errorf("Expected fingerprint to be logged, got: %s", logBuf.String())
+	}
+
+	ln, err := listen("127.0.0.1:0", 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: newAPIServer(statusCodes).routes(), TLSConfig: tlsConfig}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntilDone(ctx, srv, ln, time.Second)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get("https://" + ln.Addr().String() + "/status/200")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Errorf("Expected HTTP/2, got %s", resp.Proto)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected shutdown error: %v", err)
+	}
+}
+
+// Test certificate fingerprint format
+func TestCertFingerprint(t *testing.T) {
+	cert, err := generateSelfSignedCert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp := certFingerprint(cert)
+	if len(fp) != 32*3-1 || strings.Count(fp, ":") != 31 {
+		t.Errorf("Unexpected fingerprint format: %s", fp)
+	}
+}
+
+// Test TLS flag validation and mismatched key pairs
+func TestServerTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certA, err := generateSelfSignedCert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certB, err := generateSelfSignedCert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFileA, _ := writeKeyPair(t, dir, "a", certA)
+	_, keyFileB := writeKeyPair(t, dir, "b", certB)
+
+	testCases := []struct {
+		cert, key  string
+		selfSigned bool
+		errMsg     string
+	}{
+		{certFileA, "", false, "--tls-cert and --tls-key must be given together"},
+		{"", keyFileB, false, "--tls-cert and --tls-key must be given together"},
+		{certFileA, keyFileB, true, "--tls-self-signed cannot be combined"},
+		{certFileA, keyFileB, false, "invalid TLS certificate/key pair"},
+		{filepath.Join(dir, "missing.crt"), keyFileB, false, "invalid TLS certificate/key pair"},
+	}
+
+	for _, tc := range testCases {
+		_, err := serverTLSConfig(tc.cert, tc.key, tc.selfSigned)
+		if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+			t.Errorf("Expected error containing %q, got %v", tc.errMsg, err)
+		}
+	}
+
+	// No TLS flags means plain HTTP
+	if cfg, err := serverTLSConfig("", "", false); cfg != nil || err != nil {
+		t.Errorf("Expected nil config without TLS flags, got %v, %v", cfg, err)
+	}
+}
+
+// Test reloading a certificate from disk
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certA, _ := generateSelfSignedCert()
+	certB, _ := generateSelfSignedCert()
+	certFile, keyFile := writeKeyPair(t, dir, "server", certA)
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, _ := r.getCertificate(nil)
+	if !bytes.Equal(got.Certificate[0], certA.Certificate[0]) {
+		t.Error("Expected initial certificate")
+	}
+
+	writeKeyPair(t, dir, "server", certB)
+	if err := r.reload(); err != nil {
+		t.Fatalf("Unexpected reload error: %v", err)
+	}
+	got, _ = r.getCertificate(nil)
+	if !bytes.Equal(got.Certificate[0], certB.Certificate[0]) {
+		t.Error("Expected reloaded certificate")
+	}
+
+	// A broken pair on disk keeps the previous certificate
+	os.WriteFile(keyFile, []byte("garbage"), 0600)
+	if err := r.reload(); err == nil {
+		t.Error("Expected reload error for invalid key")
+	}
+	got, _ = r.getCertificate(nil)
+	if !bytes.Equal(got.Certificate[0], certB.Certificate[0]) {
+		t.Error("Expected previous certificate to be kept")
+	}
+}
+
+// Test CORS headers for allowed, disallowed and wildcard origins
+func TestServeCORS(t *testing.T) {
+	testCases := []struct {
+		name        string
+		origins     []string
+		credentials bool
+		origin      string
+		allowOrigin string
+		allowCreds  string
+	}{
+		{"allowed", []string{"https://dash.example"}, false, "https://dash.example", "https://dash.example", ""},
+		{"disallowed", []string{"https://dash.example"}, false, "https://evil.example", "", ""},
+		{"wildcard", []string{"*"}, false, "https://any.example", "*", ""},
+		{"wildcard with credentials", []string{"*"}, true, "https://any.example", "https://any.example", "true"},
+		{"allowed with credentials", []string{"https://dash.example"}, true, "https://dash.example", "https://dash.example", "true"},
+		{"not configured", nil, false, "https://dash.example", "", ""},
+		{"no origin header", []string{"*"}, false, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		api := newAPIServer(statusCodes)
+		api.corsOrigins = tc.origins
+		api.corsCredentials = tc.credentials
+
+		req := httptest.NewRequest("GET", "/status/200", nil)
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		rec := httptest.NewRecorder()
+		api.routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", tc.name, rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tc.allowOrigin {
+			t.Errorf("%s: expected Allow-Origin %q, got %q", tc.name, tc.allowOrigin, got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tc.allowCreds {
+			t.Errorf("%s: expected Allow-Credentials %q, got %q", tc.name, tc.allowCreds, got)
+		}
+	}
+}
+
+// Test a rate-limited response keeps its CORS headers, so a browser
+// client can read the 429
+func TestServeCORSRateLimited(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	api.corsOrigins = []string{"https://dash.example"}
+	api.limiter = newRateLimiter(1, time.Minute, nil)
+	handler := api.routes()
+
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/types", nil)
+		req.Header.Set("Origin", "https://dash.example")
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+	}
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://dash.example" {
+		t.Errorf("Expected Allow-Origin on the 429, got %q", got)
+	}
+}
+
+// Test CORS preflight requests are answered with 204
+func TestServeCORSPreflight(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	api.corsOrigins = []string{"https://dash.example"}
+
+	for _, target := range []string{"/status", "/status/404", "/types"} {
+		req := httptest.NewRequest("OPTIONS", target, nil)
+		req.Header.Set("Origin", "https://dash.example")
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		req.Header.Set("Access-Control-Request-Headers", "Accept")
+		rec := httptest.NewRecorder()
+		api.routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("%s: expected 204, got %d", target, rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, "GET") {
+			t.Errorf("%s: expected GET in Allow-Methods, got %q", target, got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Headers"); got != "Accept" {
+			t.Errorf("%s: expected Allow-Headers Accept, got %q", target, got)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("%s: expected empty preflight body", target)
+		}
+	}
+
+	// Disallowed preflight gets no CORS headers
+	req := httptest.NewRequest("OPTIONS", "/status", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rec := httptest.NewRecorder()
+	api.routes().ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" || rec.Header().Get("Access-Control-Allow-Methods") != "" {
+		t.Errorf("Expected no CORS headers for disallowed origin, got %v", rec.Header())
+	}
+}
+
+// Test the HTML page is not covered by CORS
+func TestServeCORSIndexExcluded(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	api.corsOrigins = []string{"*"}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Origin", "https://any.example")
+	rec := httptest.NewRecorder()
+	api.routes().ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no CORS headers on HTML page, got %q", got)
+	}
+}
+
+// serveWithHeaders runs a request with extra headers against an API server
+func serveWithHeaders(api *apiServer, target string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	api.routes().ServeHTTP(rec, req)
+	return rec
+}
+
+// Test the 200 -> 304 conditional request flow
+func TestServeETag(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	api.cacheMaxAge = 5 * time.Minute
+
+	for _, target := range []string{"/status/404", "/status?class=4", "/types"} {
+		first := serveWithHeaders(api, target, nil)
+		etag := first.Header().Get("ETag")
+		if first.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s: expected 200 with ETag, got %d %q", target, first.Code, etag)
+		}
+		if !strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, "W/") {
+			t.Errorf("%s: expected a strong quoted ETag, got %s", target, etag)
+		}
+		if cc := first.Header().Get("Cache-Control"); cc != "public, max-age=300" {
+			t.Errorf("%s: unexpected Cache-Control: %s", target, cc)
+		}
+
+		for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+			second := serveWithHeaders(api, target, map[string]string{"If-None-Match": inm})
+			if second.Code != http.StatusNotModified {
+				t.Errorf("%s: expected 304 for If-None-Match %s, got %d", target, inm, second.Code)
+			}
+			if second.Body.Len() != 0 {
+				t.Errorf("%s: expected empty 304 body", target)
+			}
+			if second.Header().Get("ETag") != etag {
+				t.Errorf("%s: 304 should repeat the ETag", target)
+			}
+		}
+
+		stale := serveWithHeaders(api, target, map[string]string{"If-None-Match": `"stale"`})
+		if stale.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 for stale ETag, got %d", target, stale.Code)
+		}
+	}
+}
+
+// Test ETags differ between filters, representations and datasets
+func TestServeETagVariants(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	etag := func(api *apiServer, target string, headers map[string]string) string {
+		return serveWithHeaders(api, target, headers).Header().Get("ETag")
+	}
+
+	seen := make(map[string]string)
+	variants := []struct {
+		target string
+		accept string
+	}{
+		{"/status", ""},
+		{"/status?class=4", ""},
+		{"/status?class=5", ""},
+		{"/status?class=4&search=timeout", ""},
+		{"/status?class=4", "text/csv"},
+		{"/status/404", ""},
+		{"/status/404?pretty=1", ""},
+		{"/status/404?format=xml", ""},
+		{"/status/404?format=xml&pretty=1", ""},
+		{"/status/405", ""},
+	}
+	for _, v := range variants {
+		e := etag(api, v.target, map[string]string{"Accept": v.accept})
+		key := v.target + " " + v.accept
+		for otherKey, other := range seen {
+			if other == e {
+				t.Errorf("%s shares ETag with %s", key, otherKey)
+			}
+		}
+		seen[key] = e
+	}
+
+	// Parameter order does not change the ETag
+	if etag(api, "/status?class=4&search=timeout", nil) != etag(api, "/status?search=timeout&class=4", nil) {
+		t.Error("Equivalent queries should share an ETag")
+	}
+
+	// A different dataset changes every ETag
+	custom := append([]StatusCode{}, statusCodes...)
+	custom[0].Short = strPtr("Carry On")
+	if etag(api, "/status/404", nil) == etag(newAPIServer(custom), "/status/404", nil) {
+		t.Error("ETag should change when the dataset changes")
+	}
+}
+
+// Test error responses are not cached
+func TestServeErrorNotCached(t *testing.T) {
+	rec := serveRequest(t, "GET", "/status/999")
+	if rec.Header().Get("ETag") != "" || rec.Header().Get("Cache-Control") != "" {
+		t.Errorf("Error responses should not carry caching headers: %v", rec.Header())
+	}
+}
+
+// Test a second signal during the shutdown delay shuts down at once
+func TestDrainContextSkip(t *testing.T) {
+	api := newAPIServer(statusCodes)
+	ctx, cancel := context.WithCancel(context.Background())
+	skip := make(chan os.Signal, 1)
+	drainCtx := api.drainContext(ctx, time.Hour, skip)
+
+	cancel()
+	select {
+	case <-drainCtx.Done():
+		t.Fatal("Expected the delay to hold off shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	skip <- os.Interrupt
+	select {
+	case <-drainCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected a second signal to skip the delay")
+	}
+}
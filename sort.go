@@ -0,0 +1,99 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sortKeys lists the fields --sort can order results by
+var sortKeys = []string{"code", "type", "short"}
+
+// validSortKey reports whether s is a sort key
+func validSortKey(s string) bool {
+	for _, k := range sortKeys {
+		if s == k {
+			return true
+		}
+	}
+	return false
+}
+
+// sortResults orders results by key, numerically for code and ignoring
+// case for type and short; ties fall back to the code so the order is
+// always the same. With no key the lookup order is kept, or reversed.
+func sortResults(results []StatusCode, key string, reverse bool) ([]StatusCode, error) {
+	sorted := append([]StatusCode{}, results...)
+	if key == "" {
+		if reverse {
+			for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+				sorted[i], sorted[j] = sorted[j], sorted[i]
+			}
+		}
+		return sorted, nil
+	}
+	if !validSortKey(key) {
+		return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid sort key: '%s' - must be one of %s", key, strings.Join(sortKeys, ", ")), Input: key}
+	}
+	field := func(sc StatusCode) string {
+		switch key {
+		case "type":
+			return strings.ToLower(sc.Type)
+		case "short":
+			if sc.Short != nil {
+				return strings.ToLower(*sc.Short)
+			}
+		}
+		return ""
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if reverse {
+			a, b = b, a
+		}
+		if fa, fb := field(a), field(b); fa != fb {
+			return fa < fb
+		}
+		return a.Code < b.Code
+	})
+	return sorted, nil
+}
+
+// pageResults skips the first offset results and keeps at most limit of
+// the rest; a limit of zero or less keeps them all
+func pageResults(results []StatusCode, offset, limit int) ([]StatusCode, error) {
+	if offset < 0 {
+		return nil, &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("invalid offset: '%d' - must not be negative", offset), Input: strconv.Itoa(offset)}
+	}
+	if offset >= len(results) && offset > 0 {
+		return nil, &cliError{Kind: errNotFound, Message: fmt.Sprintf("no HTTP status codes left after skipping %d of %d", offset, len(results)), Input: strconv.Itoa(offset)}
+	}
+	results = results[offset:]
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+	return results, nil
+}
@@ -0,0 +1,99 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Test sorting a mixed -c 5,2 --search teapot lookup
+func TestSortResults(t *testing.T) {
+	results, err := lookup(lookupQuery{codes: "5,2", search: "teapot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key     string
+		reverse bool
+		want    []int
+	}{
+		{"code", false, []int{200, 201, 202, 203, 204, 205, 206, 207, 208, 226, 418, 500, 501, 502, 503, 504, 505, 506, 507, 508, 510, 511}},
+		{"code", true, []int{511, 510, 508, 507, 506, 505, 504, 503, 502, 501, 500, 418, 226, 208, 207, 206, 205, 204, 203, 202, 201, 200}},
+		{"type", false, []int{418, 500, 501, 502, 503, 504, 505, 506, 507, 508, 510, 511, 200, 201, 202, 203, 204, 205, 206, 207, 208, 226}},
+		{"short", false, []int{202, 208, 502, 201, 504, 505, 418, 226, 507, 500, 508, 207, 511, 204, 203, 510, 501, 200, 206, 205, 503, 506}},
+		{"", true, []int{418, 226, 208, 207, 206, 205, 204, 203, 202, 201, 200, 511, 510, 508, 507, 506, 505, 504, 503, 502, 501, 500}},
+	}
+	for _, tt := range tests {
+		sorted, err := sortResults(results, tt.key, tt.reverse)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.key, err)
+		}
+		if got := codesOf(sorted); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s reverse=%v: expected %v, got %v", tt.key, tt.reverse, tt.want, got)
+		}
+	}
+
+	if _, err := sortResults(results, "long", false); err == nil {
+		t.Error("Expected an error for an unknown sort key")
+	}
+}
+
+// Test --limit and --offset paging after sorting
+func TestPageResults(t *testing.T) {
+	results, err := lookup(lookupQuery{codes: "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted, err := sortResults(results, "code", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		offset, limit int
+		want          []int
+	}{
+		{0, 3, []int{499, 451, 450}},
+		{3, 3, []int{449, 444, 431}},
+		{32, 5, []int{401, 400}},
+		{33, 0, []int{400}},
+		{0, -1, codesOf(sorted)},
+	}
+	for _, tt := range tests {
+		page, err := pageResults(sorted, tt.offset, tt.limit)
+		if err != nil {
+			t.Fatalf("offset %d limit %d: %v", tt.offset, tt.limit, err)
+		}
+		if got := codesOf(page); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("offset %d limit %d: expected %v, got %v", tt.offset, tt.limit, tt.want, got)
+		}
+	}
+
+	if _, err := pageResults(sorted, 34, 10); err == nil {
+		t.Error("Expected an error for an offset past the last code")
+	}
+	if _, err := pageResults(sorted, -1, 10); err == nil {
+		t.Error("Expected an error for a negative offset")
+	}
+}
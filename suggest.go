@@ -0,0 +1,256 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// suggestAlternative is a code worth considering instead of a suggestion
+type suggestAlternative struct {
+	Code     int    `json:"code"`
+	Short    string `json:"short"`
+	Tradeoff string `json:"tradeoff"`
+}
+
+// suggestScenario is a curated situation and the code that fits it. Each
+// keyword is one or more word prefixes that must all appear in the
+// description; keywords with more words weigh more.
+type suggestScenario struct {
+	code         int
+	keywords     []string
+	reason       string
+	alternatives []suggestAlternative
+}
+
+// suggestion is a ranked result for a scenario description
+type suggestion struct {
+	Code         int                  `json:"code"`
+	Short        string               `json:"short"`
+	Score        int                  `json:"score"`
+	Reason       string               `json:"reason"`
+	Matched      []string             `json:"matched"`
+	Alternatives []suggestAlternative `json:"alternatives"`
+}
+
+// suggestScenarios is the rule table used by the suggest subcommand
+var suggestScenarios = []suggestScenario{
+	{422, []string{"business valid", "business rule", "fail valid", "valid error", "semantic", "well-formed", "field", "unprocessable"},
+		"The request is syntactically correct but its content breaks a validation or business rule, which is what 422 describes.",
+		[]suggestAlternative{{Code: 400, Tradeoff: "400 is understood by every client and is fine when you don't need to tell malformed requests apart from rejected ones."}}},
+	{400, []string{"malformed", "invalid json", "parse", "syntax", "missing param", "bad input", "bad request", "garbage"},
+		"The request itself could not be understood, so the client has to fix it before retrying.",
+		[]suggestAlternative{{Code: 422, Tradeoff: "Use 422 when the request parses fine and only its content is rejected."}}},
+	{401, []string{"not logged", "no token", "missing token", "expired token", "unauthenticated", "not authenticated", "login", "credential", "auth missing", "missing auth"},
+		"The client has not proven who it is; 401 asks it to authenticate and must include a WWW-Authenticate header.",
+		[]suggestAlternative{{Code: 403, Tradeoff: "Use 403 when the client is authenticated but still isn't allowed; re-authenticating won't help."}}},
+	{403, []string{"permission", "not allowed", "forbidden", "role", "insufficient", "admin only", "access denied", "not permitted", "authorization"},
+		"The client is known but lacks the rights for this action, and authenticating again won't change that.",
+		[]suggestAlternative{
+			{Code: 401, Tradeoff: "Use 401 if the client hasn't authenticated at all."},
+			{Code: 404, Tradeoff: "404 hides that the resource exists from clients who shouldn't know about it."},
+		}},
+	{404, []string{"not found", "doesn't exist", "does not exist", "no such", "unknown id", "missing resource", "nonexistent"},
+		"Nothing exists at this URI, or you'd rather not reveal that it does.",
+		[]suggestAlternative{{Code: 410, Tradeoff: "Use 410 when the resource existed and is gone for good, so clients can drop their links."}}},
+	{410, []string{"deleted", "removed permanent", "permanently removed", "gone", "retired", "sunset", "decommission"},
+		"The resource used to exist and has been removed permanently with no replacement.",
+		[]suggestAlternative{{Code: 404, Tradeoff: "404 is the safer choice when you can't promise the removal is permanent."}}},
+	{409, []string{"conflict", "duplicate", "already exist", "concurrent", "version mismatch", "state"},
+		"The request clashes with the current state of the resource, and the client must resolve the conflict first.",
+		[]suggestAlternative{{Code: 412, Tradeoff: "Use 412 when the conflict is detected through an If-Match or If-Unmodified-Since precondition."}}},
+	{412, []string{"if-match", "etag", "precondition", "stale", "optimistic lock", "lost update"},
+		"A conditional request header didn't match the current resource, so applying the change could lose an update.",
+		[]suggestAlternative{{Code: 409, Tradeoff: "409 fits conflicts found by the application rather than by request preconditions."}}},
+	{428, []string{"require if-match", "require etag", "require condition", "must be conditional", "without if-match"},
+		"The server wants clients to send conditional requests to avoid lost updates.",
+		[]suggestAlternative{{Code: 412, Tradeoff: "412 is for a precondition that was sent but failed."}}},
+	{429, []string{"rate limit", "too many", "throttl", "quota", "per minute", "per second", "burst"},
+		"One client is sending more requests than it's allowed; include Retry-After so it knows when to try again.",
+		[]suggestAlternative{{Code: 503, Tradeoff: "Use 503 when the whole service is overloaded rather than one client being over its limit."}}},
+	{202, []string{"async", "queue", "background", "later", "job", "accepted for processing", "eventual", "webhook"},
+		"The work has been accepted but won't finish before the response; point the client at a status resource.",
+		[]suggestAlternative{{Code: 201, Tradeoff: "Use 201 if the resource already exists by the time you respond."}}},
+	{201, []string{"created", "create", "new resource", "insert", "sign up", "register"},
+		"A new resource was created; return its URI in the Location header.",
+		[]suggestAlternative{{Code: 200, Tradeoff: "200 is fine when nothing new is addressable, e.g. an action that only returns a result."}}},
+	{204, []string{"no content", "nothing to return", "empty body", "no body", "deleted success", "successful delete"},
+		"The request succeeded and there is deliberately nothing to send back.",
+		[]suggestAlternative{{Code: 200, Tradeoff: "Use 200 with a body if clients benefit from seeing the updated resource."}}},
+	{501, []string{"not implemented", "not yet implement", "unimplemented", "coming soon", "unsupported feature", "todo"},
+		"The server doesn't support the functionality needed for this request at all.",
+		[]suggestAlternative{{Code: 405, Tradeoff: "Use 405 when the method is understood but not allowed on this particular resource."}}},
+	{405, []string{"method not allowed", "wrong method", "read-only", "read only", "post not allowed", "delete not allowed"},
+		"The resource exists but doesn't accept this HTTP method; list the allowed ones in the Allow header.",
+		[]suggestAlternative{{Code: 501, Tradeoff: "501 means the server doesn't recognise the method anywhere."}}},
+	{451, []string{"legal", "court", "government", "censor", "dmca", "sanction", "jurisdiction", "law"},
+		"Access is blocked because of a legal demand; 451 makes the reason transparent to clients and researchers.",
+		[]suggestAlternative{{Code: 403, Tradeoff: "403 avoids disclosing that the block is legal in nature, if you're not allowed to say."}}},
+	{413, []string{"too large", "too big", "upload size", "file size", "body size", "payload size"},
+		"The request body is larger than the server is willing to process.",
+		[]suggestAlternative{{Code: 400, Tradeoff: "400 is less precise, and clients can't tell that sending less data would help."}}},
+	{414, []string{"url too long", "uri too long", "query string too long", "long url"},
+		"The request target is longer than the server will interpret; move large parameters into the body.",
+		nil},
+	{415, []string{"content type", "content-type", "media type", "unsupported format", "wrong format", "xml instead"},
+		"The request body is in a format the endpoint doesn't accept.",
+		[]suggestAlternative{{Code: 406, Tradeoff: "406 is the opposite case: the server can't produce a response format the client accepts."}}},
+	{406, []string{"accept header", "cannot produce", "response format", "negotiation"},
+		"The server can't produce a representation matching the client's Accept headers.",
+		[]suggestAlternative{{Code: 200, Tradeoff: "Many APIs ignore Accept and return their default format with 200 instead."}}},
+	{304, []string{"not modified", "unchanged", "cache", "if-none-match", "if-modified-since"},
+		"The client's cached copy is still current, so no body needs to be sent.",
+		nil},
+	{301, []string{"moved permanent", "new url", "new domain", "renamed", "canonical url"},
+		"The resource has a new permanent URI and clients should update their links.",
+		[]suggestAlternative{{Code: 308, Tradeoff: "Use 308 when the method and body must be preserved; 301 lets clients turn POST into GET."}}},
+	{307, []string{"temporar redirect", "moved temporar", "temporarily elsewhere"},
+		"The resource is temporarily at another URI and the client must repeat the same request there.",
+		[]suggestAlternative{{Code: 302, Tradeoff: "302 is more widely known but lets clients change POST into GET."}}},
+	{303, []string{"after post", "redirect after", "post-redirect-get", "see result"},
+		"After handling the request, the client should fetch the result from another URI with GET.",
+		[]suggestAlternative{{Code: 302, Tradeoff: "302 behaves the same in most browsers but doesn't state the intent."}}},
+	{503, []string{"maintenance", "overload", "unavailable", "temporarily down", "capacity", "shed load", "starting up"},
+		"The service can't handle requests right now but expects to recover; send Retry-After if you know when.",
+		[]suggestAlternative{{Code: 429, Tradeoff: "Use 429 when a single client is over its limit rather than the service as a whole."}}},
+	{500, []string{"bug", "crash", "exception", "unexpected", "panic", "unhandled"},
+		"Something went wrong on the server that the client couldn't have prevented.",
+		[]suggestAlternative{{Code: 503, Tradeoff: "503 tells clients the failure is temporary and worth retrying."}}},
+	{502, []string{"upstream", "bad gateway", "invalid response", "backend error", "proxy"},
+		"A gateway or proxy got an invalid response from the server behind it.",
+		[]suggestAlternative{{Code: 504, Tradeoff: "Use 504 when the upstream didn't answer in time rather than answering badly."}}},
+	{504, []string{"gateway timeout", "upstream timeout", "timed out", "timeout", "took too long"},
+		"A gateway or proxy gave up waiting for the server behind it.",
+		[]suggestAlternative{{Code: 408, Tradeoff: "408 is for a client that was too slow to send its request."}}},
+}
+
+// runSuggest implements "httpstatus suggest [flags] <description>"
+func runSuggest(args []string, w io.Writer) error {
+	fs := newFlagSet("suggest")
+	limit := fs.Int("limit", 3, "Maximum number of suggestions")
+	jsonOut := fs.Bool("json", false, "Output suggestions as JSON")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return fmt.Errorf("invalid limit: %d - must be positive", *limit)
+	}
+	description := strings.Join(positional, " ")
+	if strings.TrimSpace(description) == "" {
+		return fmt.Errorf("suggest requires a description, e.g. httpstatus suggest \"user is not logged in\"")
+	}
+
+	suggestions := suggest(description)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no suggestion for: '%s' - try describing what the client did and why it failed", description)
+	}
+	if len(suggestions) > *limit {
+		suggestions = suggestions[:*limit]
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(suggestions)
+	}
+	printSuggestions(w, suggestions)
+	return nil
+}
+
+// suggest ranks the scenarios matching a description, best first
+func suggest(description string) []suggestion {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\''
+	})
+
+	var results []suggestion
+	for _, sc := range suggestScenarios {
+		s := suggestion{Code: sc.code, Reason: sc.reason, Matched: []string{}, Alternatives: []suggestAlternative{}}
+		for _, keyword := range sc.keywords {
+			if terms := strings.Fields(keyword); matchesTerms(words, terms) {
+				s.Score += len(terms)
+				s.Matched = append(s.Matched, keyword)
+			}
+		}
+		if s.Score == 0 {
+			continue
+		}
+		s.Short = shortFor(sc.code)
+		for _, alt := range sc.alternatives {
+			alt.Short = shortFor(alt.Code)
+			s.Alternatives = append(s.Alternatives, alt)
+		}
+		results = append(results, s)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// matchesTerms reports whether every term is a prefix of some word
+func matchesTerms(words, terms []string) bool {
+	for _, term := range terms {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// shortFor returns the reason phrase for a code, or "" if unknown
+func shortFor(code int) string {
+	if sc, found := findStatusCode(code); found && sc.Short != nil {
+		return *sc.Short
+	}
+	return ""
+}
+
+// printSuggestions prints ranked suggestions with their reasoning
+func printSuggestions(w io.Writer, suggestions []suggestion) {
+	for i, s := range suggestions {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d. %d %s\n", i+1, s.Code, s.Short)
+		fmt.Fprintf(w, "   Why: %s\n", s.Reason)
+		for _, alt := range s.Alternatives {
+			fmt.Fprintf(w, "   Alternative: %d %s - %s\n", alt.Code, alt.Short, alt.Tradeoff)
+		}
+		fmt.Fprintf(w, "   Matched: %s\n", strings.Join(s.Matched, ", "))
+	}
+}
@@ -0,0 +1,118 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Test scenario descriptions map to the expected top suggestion
+func TestSuggest(t *testing.T) {
+	testCases := []struct {
+		description string
+		expected    int
+	}{
+		{"user submitted a well-formed request but a field fails business validation", 422},
+		{"the request body is malformed JSON", 400},
+		{"the user is not logged in", 401},
+		{"user is logged in but lacks permission to delete", 403},
+		{"client is sending too many requests and hit the rate limit", 429},
+		{"the job was queued for background processing", 202},
+		{"this endpoint is not yet implemented", 501},
+		{"content blocked by a court order", 451},
+		{"order with this id does not exist", 404},
+	}
+
+	for _, tc := range testCases {
+		results := suggest(tc.description)
+		if len(results) == 0 {
+			t.Errorf("%q: no suggestions", tc.description)
+			continue
+		}
+		if results[0].Code != tc.expected {
+			t.Errorf("%q: expected %d, got %d (%v)", tc.description, tc.expected, results[0].Code, results[0].Matched)
+		}
+	}
+}
+
+// Test the scenario table only refers to known codes
+func TestSuggestScenarios(t *testing.T) {
+	for _, sc := range suggestScenarios {
+		if _, found := findStatusCode(sc.code); !found {
+			t.Errorf("Scenario for unknown code %d", sc.code)
+		}
+		if sc.reason == "" || len(sc.keywords) == 0 {
+			t.Errorf("Incomplete scenario for %d", sc.code)
+		}
+		for _, alt := range sc.alternatives {
+			if _, found := findStatusCode(alt.Code); !found || alt.Tradeoff == "" {
+				t.Errorf("Bad alternative %d for %d", alt.Code, sc.code)
+			}
+		}
+	}
+}
+
+// Test text output includes the reasoning and alternatives
+func TestRunSuggestText(t *testing.T) {
+	var buf bytes.Buffer
+	err := runSuggest([]string{"a", "field", "fails", "business", "validation", "--limit", "1"}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"1. 422 Unprocessable Entity\n", "   Why: ", "   Alternative: 400 Bad Request - "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "2. ") {
+		t.Errorf("Expected a single suggestion:\n%s", out)
+	}
+}
+
+// Test JSON output
+func TestRunSuggestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runSuggest([]string{"--json", "rate limit exceeded"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var results []suggestion
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Code != 429 || results[0].Short != "Too Many Requests" || results[0].Alternatives[0].Code != 503 {
+		t.Errorf("Unexpected result: %+v", results[0])
+	}
+}
+
+// Test invalid arguments and unmatched descriptions are errors
+func TestRunSuggestErrors(t *testing.T) {
+	for _, args := range [][]string{{}, {"hello world"}, {"--limit", "0", "rate limit"}} {
+		var buf bytes.Buffer
+		if err := runSuggest(args, &buf); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
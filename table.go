@@ -0,0 +1,432 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// tableBorder holds the characters used to draw a bordered table
+type tableBorder struct {
+	horizontal, vertical               string
+	topLeft, topMid, topRight          string
+	midLeft, midMid, midRight          string
+	bottomLeft, bottomMid, bottomRight string
+}
+
+var (
+	asciiBorder = tableBorder{
+		horizontal: "-", vertical: "|",
+		topLeft: "+", topMid: "+", topRight: "+",
+		midLeft: "+", midMid: "+", midRight: "+",
+		bottomLeft: "+", bottomMid: "+", bottomRight: "+",
+	}
+	unicodeBorder = tableBorder{
+		horizontal: "─", vertical: "│",
+		topLeft: "┌", topMid: "┬", topRight: "┐",
+		midLeft: "├", midMid: "┼", midRight: "┤",
+		bottomLeft: "└", bottomMid: "┴", bottomRight: "┘",
+	}
+)
+
+// tableStyles lists the styles accepted by --table-style
+var tableStyles = []string{"plain", "ascii", "unicode", "compact", "github"}
+
+// validTableStyle reports whether a --table-style value is supported
+func validTableStyle(style string) bool {
+	for _, s := range tableStyles {
+		if s == style {
+			return true
+		}
+	}
+	return false
+}
+
+// textTable is a table of cells ready to be rendered
+type textTable struct {
+	headers    []string
+	rows       [][]string
+	rightAlign []bool
+	widths     []int
+
+	// colors holds an SGR sequence per row, or is nil for no colour
+	colors []string
+}
+
+// tableOptions controls how a fields table is rendered
+type tableOptions struct {
+	style string // a --table-style
+	width int    // columns to fit the table to, unlimited when zero
+	wrap  bool   // wrap rather than truncate cells that don't fit
+	color bool   // colour rows by status class
+
+	// icons adds a leading column of class icons from this set when set
+	icons map[int]string
+}
+
+// newFieldsTable builds a table with a column per field
+func newFieldsTable(codes []StatusCode, fields []metadataField) *textTable {
+	var headers []string
+	var rightAlign []bool
+	for _, f := range fields {
+		headers = append(headers, strings.ToUpper(f.label))
+		rightAlign = append(rightAlign, f.name == "code")
+	}
+	var rows [][]string
+	for _, sc := range codes {
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i], _ = f.value(sc)
+		}
+		rows = append(rows, row)
+	}
+	return newTextTable(headers, rows, rightAlign)
+}
+
+// withIcons returns the table with a leading, unlabelled column holding
+// the class icon of each row's code
+func (t *textTable) withIcons(codes []StatusCode, icons map[int]string) *textTable {
+	headers := append([]string{""}, t.headers...)
+	rightAlign := append([]bool{false}, t.rightAlign...)
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		rows[r] = append([]string{classIcon(icons, codes[r].Code)}, row...)
+	}
+	return newTextTable(headers, rows, rightAlign)
+}
+
+// colorRows colours each row by the class of the code it shows
+func (t *textTable) colorRows(codes []StatusCode) *textTable {
+	t.colors = make([]string, len(codes))
+	for i, sc := range codes {
+		t.colors[i] = classColor(sc.Code)
+	}
+	return t
+}
+
+// paintRow colours s for row r, when the table has colours
+func (t *textTable) paintRow(r int, s string) string {
+	if r < 0 || r >= len(t.colors) || t.colors[r] == "" {
+		return s
+	}
+	return paint(t.colors[r], s)
+}
+
+// newTextTable builds a table from its cells, sizing every column
+func newTextTable(headers []string, rows [][]string, rightAlign []bool) *textTable {
+	t := &textTable{headers: headers, rows: rows, rightAlign: rightAlign}
+	t.widths = make([]int, len(t.headers))
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		for i, cell := range row {
+			if n := displayWidth(cell); n > t.widths[i] {
+				t.widths[i] = n
+			}
+		}
+	}
+	return t
+}
+
+// markdownCells returns a copy of the table with every cell escaped for a
+// Markdown table, sized for the escaped text
+func (t *textTable) markdownCells() *textTable {
+	headers := make([]string, len(t.headers))
+	for i, h := range t.headers {
+		headers[i] = escapeMarkdownCell(h)
+	}
+	rows := make([][]string, len(t.rows))
+	for r, row := range t.rows {
+		rows[r] = make([]string, len(row))
+		for i, cell := range row {
+			rows[r][i] = escapeMarkdownCell(cell)
+		}
+	}
+	return newTextTable(headers, rows, t.rightAlign)
+}
+
+// escapeMarkdownCell makes text safe inside a Markdown table cell: pipes
+// are escaped, line breaks become <br> and leading or trailing spaces,
+// which renderers trim, become &nbsp;
+func escapeMarkdownCell(s string) string {
+	s = strings.NewReplacer("\r\n", "<br>", "\n", "<br>", "\r", "<br>", "|", `\|`).Replace(s)
+
+	trimmed := strings.TrimLeft(s, " \t")
+	lead := strings.Repeat("&nbsp;", len(s)-len(trimmed))
+	s = strings.TrimRight(trimmed, " \t")
+	trail := strings.Repeat("&nbsp;", len(trimmed)-len(s))
+	return lead + s + trail
+}
+
+// pad fills a cell to its column width
+func (t *textTable) pad(i int, cell string, align bool) string {
+	fill := strings.Repeat(" ", t.widths[i]-displayWidth(cell))
+	if align && t.rightAlign[i] {
+		return fill + cell
+	}
+	return cell + fill
+}
+
+// writeAligned writes rows as space-separated columns; the last column is
+// not padded, matching text/tabwriter
+func (t *textTable) writeAligned(w io.Writer, rule bool) {
+	line := func(r int, cells []string) {
+		var b strings.Builder
+		for i, cell := range cells {
+			if i == len(cells)-1 {
+				b.WriteString(cell)
+			} else {
+				b.WriteString(t.pad(i, cell, false) + "  ")
+			}
+		}
+		fmt.Fprintln(w, t.paintRow(r, b.String()))
+	}
+
+	line(-1, t.headers)
+	if rule {
+		dashes := make([]string, len(t.headers))
+		for i := range dashes {
+			dashes[i] = strings.Repeat("-", t.widths[i])
+		}
+		line(-1, dashes)
+	}
+	for r, row := range t.rows {
+		line(r, row)
+	}
+}
+
+// writeBordered draws the table inside a border
+func (t *textTable) writeBordered(w io.Writer, b tableBorder) {
+	rule := func(left, mid, right string) {
+		parts := make([]string, len(t.widths))
+		for i, width := range t.widths {
+			parts[i] = strings.Repeat(b.horizontal, width+2)
+		}
+		fmt.Fprintln(w, left+strings.Join(parts, mid)+right)
+	}
+	line := func(r int, cells []string, align bool) {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			parts[i] = " " + t.paintRow(r, t.pad(i, cell, align)) + " "
+		}
+		fmt.Fprintln(w, b.vertical+strings.Join(parts, b.vertical)+b.vertical)
+	}
+
+	rule(b.topLeft, b.topMid, b.topRight)
+	line(-1, t.headers, false)
+	rule(b.midLeft, b.midMid, b.midRight)
+	for r, row := range t.rows {
+		line(r, row, true)
+	}
+	rule(b.bottomLeft, b.bottomMid, b.bottomRight)
+}
+
+// writeGitHub writes a Markdown pipe table with padded columns
+func (t *textTable) writeGitHub(w io.Writer) {
+	line := func(cells []string) {
+		fmt.Fprintln(w, "| "+strings.Join(cells, " | ")+" |")
+	}
+
+	cells := make([]string, len(t.headers))
+	for i, h := range t.headers {
+		cells[i] = t.pad(i, h, false)
+	}
+	line(cells)
+
+	for i, width := range t.widths {
+		// Markdown needs at least three dashes; the colon marks right alignment
+		n := width
+		if n < 3 {
+			n = 3
+		}
+		if t.rightAlign[i] {
+			cells[i] = strings.Repeat("-", n-1) + ":"
+		} else {
+			cells[i] = strings.Repeat("-", n)
+		}
+	}
+	line(cells)
+
+	for _, row := range t.rows {
+		for i, cell := range row {
+			cells[i] = t.pad(i, cell, true)
+		}
+		line(cells)
+	}
+}
+
+// printTableStyle outputs a text table in the given --table-style
+func printTableStyle(w io.Writer, codes []StatusCode, style string) {
+	printTableFields(w, codes, style, baseFields)
+}
+
+// printTableFields renders a table with a column per field
+func printTableFields(w io.Writer, codes []StatusCode, style string, fields []metadataField) {
+	printTableOptions(w, codes, fields, tableOptions{style: style})
+}
+
+// printTableOptions renders a fields table fitted and coloured as opts say
+func printTableOptions(w io.Writer, codes []StatusCode, fields []metadataField, opts tableOptions) {
+	t := newFieldsTable(codes, fields)
+	if opts.icons != nil {
+		t = t.withIcons(codes, opts.icons)
+	}
+	if opts.color && opts.style != "github" {
+		t.colorRows(codes)
+	}
+	t.fit(opts.width, opts.style, opts.wrap).write(w, opts.style)
+}
+
+// terminalWidth returns the --width override, or the width of f when it is
+// a terminal; 0 means no limit
+func terminalWidth(override int, f *os.File) int {
+	if override > 0 {
+		return override
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	if w, _, err := term.GetSize(int(f.Fd())); err == nil {
+		return w
+	}
+	return 0
+}
+
+// fit narrows the widest column so lines are at most width columns wide in
+// the given style, truncating its cells with an ellipsis or, with wrap,
+// continuing them on extra lines. The header row is never broken, and
+// github tables are left alone since Markdown cells cannot span lines
+func (t *textTable) fit(width int, style string, wrap bool) *textTable {
+	if width <= 0 || style == "github" || len(t.widths) == 0 {
+		return t
+	}
+
+	// Column separators: two spaces when aligned, " | " when bordered
+	overhead := 2 * (len(t.widths) - 1)
+	if style == "ascii" || style == "unicode" {
+		overhead = 3*len(t.widths) + 1
+	}
+	total, widest := overhead, 0
+	for i, w := range t.widths {
+		total += w
+		if w >= t.widths[widest] {
+			widest = i
+		}
+	}
+	if total <= width {
+		return t
+	}
+
+	avail := t.widths[widest] - (total - width)
+	if min := displayWidth(t.headers[widest]); avail < min {
+		avail = min
+	}
+	if avail < 1 {
+		avail = 1
+	}
+
+	var rows [][]string
+	var colors []string
+	for r, row := range t.rows {
+		lines := []string{truncateCell(row[widest], avail)}
+		if wrap {
+			lines = wrapCell(row[widest], avail)
+		}
+		for n, line := range lines {
+			cells := make([]string, len(row))
+			if n == 0 {
+				copy(cells, row)
+			}
+			cells[widest] = line
+			rows = append(rows, cells)
+			if t.colors != nil {
+				colors = append(colors, t.colors[r])
+			}
+		}
+	}
+	fitted := newTextTable(t.headers, rows, t.rightAlign)
+	fitted.colors = colors
+	return fitted
+}
+
+// truncateCell shortens s to width columns, ending it with an ellipsis
+func truncateCell(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	head, _ := cutWidth(s, width-1)
+	if width <= 1 {
+		head = ""
+	}
+	return head + "…"
+}
+
+// wrapCell breaks s into lines of at most width columns at spaces,
+// splitting words only when they are wider than a whole line
+func wrapCell(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for displayWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			var head string
+			head, word = cutWidth(word, width)
+			lines = append(lines, head)
+		}
+		switch {
+		case word == "":
+		case line == "":
+			line = word
+		case displayWidth(line)+1+displayWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// write renders the table in the given --table-style
+func (t *textTable) write(w io.Writer, style string) {
+	switch style {
+	case "plain":
+		t.writeAligned(w, true)
+	case "ascii":
+		t.writeBordered(w, asciiBorder)
+	case "unicode":
+		t.writeBordered(w, unicodeBorder)
+	case "github":
+		t.markdownCells().writeGitHub(w)
+	default:
+		t.writeAligned(w, false)
+	}
+}
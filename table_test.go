@@ -0,0 +1,207 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+var updateGolden = flag.Bool("update", false, "Rewrite golden files in testdata")
+
+// goldenCodes is the fixed code set rendered by the golden table tests
+func goldenCodes(t *testing.T) []StatusCode {
+	t.Helper()
+	results, err := processInputs("100,404,418,503", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prepareOutputs(results, false, true)
+}
+
+// Test every table style against its golden file
+func TestTableStylesGolden(t *testing.T) {
+	codes := goldenCodes(t)
+	for _, style := range tableStyles {
+		var buf bytes.Buffer
+		printTableStyle(&buf, codes, style)
+
+		checkGolden(t, filepath.Join("testdata", "table", style+".golden"), buf.Bytes())
+	}
+}
+
+// checkGolden compares output with a golden file, or rewrites the file
+// when the tests are run with -update
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Output differs from %s:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// Test the compact style matches the previous tabwriter output exactly
+func TestTableCompactMatchesTabwriter(t *testing.T) {
+	for _, codes := range [][]StatusCode{goldenCodes(t), prepareOutputs(statusCodes, false, false)} {
+		var want bytes.Buffer
+		tw := tabwriter.NewWriter(&want, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CODE\tTYPE\tSHORT\tLONG")
+		for _, sc := range codes {
+			short, long := "", ""
+			if sc.Short != nil {
+				short = *sc.Short
+			}
+			if sc.Long != nil {
+				long = *sc.Long
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", sc.Code, sc.Type, short, long)
+		}
+		tw.Flush()
+
+		var got bytes.Buffer
+		printTable(&got, codes)
+		if got.String() != want.String() {
+			t.Errorf("Compact table differs from tabwriter:\n%s\nwant:\n%s", got.String(), want.String())
+		}
+	}
+}
+
+// Test style names are validated
+func TestValidTableStyle(t *testing.T) {
+	for _, style := range tableStyles {
+		if !validTableStyle(style) {
+			t.Errorf("Expected %q to be valid", style)
+		}
+	}
+	for _, style := range []string{"", "fancy", "ASCII"} {
+		if validTableStyle(style) {
+			t.Errorf("Expected %q to be invalid", style)
+		}
+	}
+}
+
+// Test the github style escapes cells like --markdown
+func TestGitHubStyleEscaping(t *testing.T) {
+	table := newTextTable([]string{"A", "B"}, [][]string{{"x|y", "one\ntwo"}}, []bool{false, false})
+	var buf bytes.Buffer
+	table.write(&buf, "github")
+	want := "| A    | B          |\n| ---- | ---------- |\n| x\\|y | one<br>two |\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+// Test --width truncates or wraps the widest column in every style
+func TestTableFitWidth(t *testing.T) {
+	codes := goldenCodes(t)
+	for _, style := range []string{"compact", "plain", "ascii", "unicode"} {
+		for _, wrap := range []bool{false, true} {
+			var buf bytes.Buffer
+			printTableOptions(&buf, codes, baseFields, tableOptions{style: style, width: 60, wrap: wrap})
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			for _, line := range lines {
+				if n := utf8.RuneCountInString(line); n > 60 {
+					t.Errorf("%s (wrap %v): line is %d wide: %q", style, wrap, n, line)
+				}
+			}
+			if strings.Contains(buf.String(), "…") == wrap {
+				t.Errorf("%s (wrap %v): unexpected ellipsis use:\n%s", style, wrap, buf.String())
+			}
+			if wrap && !strings.Contains(buf.String(), "(RFC") {
+				t.Errorf("%s: wrapped text lost:\n%s", style, buf.String())
+			}
+		}
+	}
+}
+
+// Test a width of zero leaves the table untouched
+func TestTableFitUnlimited(t *testing.T) {
+	codes := goldenCodes(t)
+	var want, got bytes.Buffer
+	printTableStyle(&want, codes, "compact")
+	printTableOptions(&got, codes, baseFields, tableOptions{style: "compact", wrap: true})
+	if got.String() != want.String() {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want.String(), got.String())
+	}
+}
+
+// Test the header row stays on one line however narrow the table
+func TestTableFitKeepsHeader(t *testing.T) {
+	table := newTextTable([]string{"CODE", "DESCRIPTION"}, [][]string{{"1", "a long description"}}, []bool{true, false})
+	var buf bytes.Buffer
+	table.fit(8, "compact", true).write(&buf, "compact")
+	want := "CODE  DESCRIPTION\n1     a long\n      description\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+}
+
+// Test wrapping and truncation never split a multi-byte rune
+func TestTableFitRunes(t *testing.T) {
+	if got := truncateCell("héllo wörld", 6); got != "héllo…" {
+		t.Errorf("Expected 'héllo…', got '%s'", got)
+	}
+	got := wrapCell("ünïcödé wörds", 4)
+	want := []string{"ünïc", "ödé", "wörd", "s"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	for _, line := range got {
+		if !utf8.ValidString(line) {
+			t.Errorf("Invalid UTF-8 in %q", line)
+		}
+	}
+}
+
+// Test borders line up around wide runes
+func TestTableWideRunes(t *testing.T) {
+	table := newTextTable([]string{"CODE", "SHORT"}, [][]string{{"404", "見つかりません"}, {"418", "teapot"}}, []bool{true, false})
+	var buf bytes.Buffer
+	table.write(&buf, "unicode")
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, line := range lines[1:] {
+		if displayWidth(line) != displayWidth(lines[0]) {
+			t.Errorf("Misaligned table:\n%s", buf.String())
+			break
+		}
+	}
+}
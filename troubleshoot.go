@@ -0,0 +1,209 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// troubleshootCheck is one item of a troubleshooting checklist; the ID is
+// stable so entries can be referenced individually
+type troubleshootCheck struct {
+	ID     string `json:"id"`
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// troubleshootChecklists holds the checklists for common operational codes,
+// ordered from the most to the least likely cause
+var troubleshootChecklists = map[int][]troubleshootCheck{
+	408: {
+		{"slow-client", "Is the client sending the request slowly?", "Mobile or congested clients may not finish sending headers or body before the server's read timeout."},
+		{"read-timeout", "Is the server's request read timeout too short?", "Compare client_body_timeout / client_header_timeout, ReadTimeout or the equivalent with the largest expected upload time."},
+		{"idle-keepalive", "Are idle keep-alive connections being reused after the server closed them?", "Some servers send 408 when closing idle connections; make sure clients retry idempotent requests on a fresh connection."},
+		{"proxy-buffering", "Is a proxy or load balancer holding the request body?", "Request buffering in front of the server can delay the body until the backend times out."},
+	},
+	413: {
+		{"body-limit", "Which layer enforces the size limit?", "Check client_max_body_size, LimitRequestBody, load balancer and framework limits - the smallest one wins."},
+		{"upload-size", "How large is the request actually?", "Compare the Content-Length of the failing request with the configured limits."},
+		{"compression", "Is the body compressed or encoded?", "Base64 or multipart encoding can inflate uploads by a third or more."},
+		{"chunked-uploads", "Should large uploads be chunked or sent directly to storage?", "Resumable or pre-signed uploads avoid pushing large bodies through the API tier."},
+	},
+	429: {
+		{"which-limit", "Which limiter returned the 429?", "Check response headers and logs to tell the application, gateway, CDN and upstream API limits apart."},
+		{"retry-after", "Is the client honouring Retry-After?", "Clients should wait for Retry-After or back off exponentially with jitter instead of retrying immediately."},
+		{"limit-key", "Is the limit keyed on the right identity?", "Clients behind a shared NAT or proxy may share one IP bucket; check X-Forwarded-For handling and per-key limits."},
+		{"traffic-spike", "Did traffic genuinely increase?", "Look for a deploy, batch job, retry storm or abusive client driving the request rate."},
+		{"limit-config", "Are the limits sized for current traffic?", "Compare the configured rate and burst with normal peak traffic."},
+	},
+	499: {
+		{"client-timeout", "Is the client timeout shorter than the server's response time?", "The client gave up first; compare client and load balancer timeouts with request latency."},
+		{"slow-upstream", "Why was the request slow?", "Check upstream latency, slow queries and lock contention for the affected requests."},
+		{"user-cancel", "Are users navigating away or cancelling requests?", "Browsers abort requests on navigation; a low steady rate of 499s can be normal."},
+		{"health-check", "Are health checks timing out?", "Load balancer health checks with short timeouts show up as 499 on slow instances."},
+	},
+	500: {
+		{"app-logs", "What does the application log say?", "Find the stack trace or error for the failing request, ideally by request ID."},
+		{"recent-deploy", "Was anything deployed or changed recently?", "Correlate the start of the errors with deploys, config changes and feature flags."},
+		{"dependency-failure", "Is a dependency failing?", "Database, cache or downstream API errors are often surfaced as 500 by unhandled exceptions."},
+		{"bad-input", "Does specific input trigger it?", "Look for a common path, parameter or payload among the failing requests."},
+		{"resources", "Is the process out of resources?", "Check memory, file descriptors, disk space and connection pool exhaustion."},
+	},
+	502: {
+		{"upstream-down", "Is the upstream running?", "Check that the backend processes or pods are up and listening on the port the proxy targets."},
+		{"upstream-crash", "Is the upstream crashing or restarting?", "Look for OOM kills, panics and restarts around the time of the errors."},
+		{"timeout-mismatch", "Do proxy and upstream timeouts line up?", "If the upstream closes connections before the proxy's timeout, the proxy sees a reset and returns 502."},
+		{"keepalive-mismatch", "Is the upstream's keep-alive timeout shorter than the proxy's?", "The proxy may reuse a connection the upstream just closed; the upstream idle timeout should exceed the proxy's."},
+		{"dns", "Does the proxy resolve the upstream correctly?", "Stale DNS caches in the proxy can point at old or removed addresses after a redeploy."},
+		{"tls-protocol", "Does the protocol match what the upstream expects?", "HTTP versus HTTPS, TLS versions, SNI and HTTP/2 settings between proxy and upstream must agree."},
+		{"invalid-response", "Is the upstream sending a malformed response?", "Oversized headers or invalid status lines are rejected by the proxy; check proxy buffer sizes."},
+	},
+	503: {
+		{"no-healthy-backends", "Are there any healthy backends?", "Check the load balancer target health and why instances are failing health checks."},
+		{"overload", "Is the service overloaded?", "Look at CPU, queue lengths and concurrency limits; shedding load returns 503."},
+		{"maintenance", "Is the service in maintenance or being deployed?", "Maintenance modes and rolling deploys with too few healthy instances return 503."},
+		{"circuit-breaker", "Has a circuit breaker opened?", "Service meshes and clients return 503 while a breaker is open after upstream failures."},
+		{"dependency-down", "Is a critical dependency unavailable?", "Readiness checks that include dependencies take every instance out of rotation when one fails."},
+		{"retry-after", "Is Retry-After set for clients?", "Tell clients when to retry to avoid a retry storm as the service recovers."},
+	},
+	504: {
+		{"slow-upstream", "Why is the upstream slow?", "Check upstream latency, slow queries, lock contention and calls to slow dependencies."},
+		{"timeout-mismatch", "Is the gateway timeout shorter than the upstream's work?", "Compare proxy_read_timeout, load balancer idle timeouts and the longest legitimate request."},
+		{"network", "Can the gateway reach the upstream?", "Firewalls, security groups and routing changes can make connections hang until the timeout."},
+		{"dns", "Does the gateway resolve the upstream correctly?", "Resolving to an unreachable address makes connection attempts time out."},
+		{"saturation", "Is the upstream saturated?", "Requests waiting for a worker, thread or connection pool count towards the gateway timeout."},
+	},
+}
+
+// troubleshootReport is the troubleshooting output for one code
+type troubleshootReport struct {
+	Code      int                 `json:"code"`
+	Short     string              `json:"short"`
+	Checklist []troubleshootCheck `json:"checklist"`
+	Notice    string              `json:"notice,omitempty"`
+	Long      string              `json:"long,omitempty"`
+}
+
+// runTroubleshoot implements "httpstatus troubleshoot [flags] <code>..."
+func runTroubleshoot(args []string, w io.Writer) error {
+	fs := newFlagSet("troubleshoot")
+	markdown := fs.Bool("markdown", false, "Output the checklist as markdown")
+	jsonOut := fs.Bool("json", false, "Output the checklist as JSON")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if *markdown && *jsonOut {
+		return fmt.Errorf("--markdown and --json cannot be used together")
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("troubleshoot requires a status code, e.g. httpstatus troubleshoot 502")
+	}
+
+	var reports []troubleshootReport
+	for _, arg := range positional {
+		code, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid status code: '%s' - must be numeric", arg)
+		}
+		report, err := newTroubleshootReport(code)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+
+	switch {
+	case *jsonOut:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	case *markdown:
+		printTroubleshootMarkdown(w, reports)
+	default:
+		printTroubleshootText(w, reports)
+	}
+	return nil
+}
+
+// newTroubleshootReport builds the report for a code, falling back to the
+// long description when there is no checklist
+func newTroubleshootReport(code int) (troubleshootReport, error) {
+	sc, found := findStatusCode(code)
+	if !found {
+		return troubleshootReport{}, notFoundError(strconv.Itoa(code))
+	}
+
+	report := troubleshootReport{Code: code, Checklist: troubleshootChecklists[code]}
+	if sc.Short != nil {
+		report.Short = *sc.Short
+	}
+	if report.Checklist == nil {
+		report.Checklist = []troubleshootCheck{}
+		report.Notice = fmt.Sprintf("no troubleshooting checklist for %d, showing its description instead", code)
+		if sc.Long != nil {
+			report.Long = *sc.Long
+		}
+	}
+	return report, nil
+}
+
+// printTroubleshootText prints numbered checklists for the terminal
+func printTroubleshootText(w io.Writer, reports []troubleshootReport) {
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d %s\n", r.Code, r.Short)
+		if r.Notice != "" {
+			fmt.Fprintf(w, "Note: %s\n", r.Notice)
+			fmt.Fprintf(w, "  %s\n", r.Long)
+			continue
+		}
+		for n, c := range r.Checklist {
+			fmt.Fprintf(w, "%2d. %s\n", n+1, c.Check)
+			fmt.Fprintf(w, "    %s\n", c.Detail)
+		}
+	}
+}
+
+// printTroubleshootMarkdown prints checklists as markdown task lists for
+// pasting into incident documents
+func printTroubleshootMarkdown(w io.Writer, reports []troubleshootReport) {
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %d %s\n\n", r.Code, r.Short)
+		if r.Notice != "" {
+			fmt.Fprintf(w, "> **Note:** %s\n\n%s\n", r.Notice, r.Long)
+			continue
+		}
+		for _, c := range r.Checklist {
+			fmt.Fprintf(w, "- [ ] **%s** %s\n", c.Check, c.Detail)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Test the common operational codes all have checklists with unique IDs
+func TestTroubleshootChecklists(t *testing.T) {
+	for _, code := range []int{408, 413, 429, 499, 500, 502, 503, 504} {
+		checks := troubleshootChecklists[code]
+		if len(checks) == 0 {
+			t.Errorf("No checklist for %d", code)
+		}
+		seen := map[string]bool{}
+		for _, c := range checks {
+			if c.ID == "" || c.Check == "" || c.Detail == "" {
+				t.Errorf("Incomplete checklist entry for %d: %+v", code, c)
+			}
+			if seen[c.ID] {
+				t.Errorf("Duplicate checklist id %q for %d", c.ID, code)
+			}
+			seen[c.ID] = true
+		}
+	}
+	for code := range troubleshootChecklists {
+		if _, found := findStatusCode(code); !found {
+			t.Errorf("Checklist for unknown code %d", code)
+		}
+	}
+}
+
+// Test text output numbers the checklist in order
+func TestTroubleshootText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runTroubleshoot([]string{"502"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "502 Bad Gateway\n 1. Is the upstream running?\n") {
+		t.Errorf("Unexpected text output:\n%s", out)
+	}
+	if strings.Index(out, "upstream's keep-alive") < strings.Index(out, "timeouts line up") {
+		t.Errorf("Checklist out of order:\n%s", out)
+	}
+}
+
+// Test markdown output is a task list
+func TestTroubleshootMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runTroubleshoot([]string{"--markdown", "429"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "## 429 Too Many Requests" {
+		t.Errorf("Unexpected heading: %q", lines[0])
+	}
+	if len(lines) != 2+len(troubleshootChecklists[429]) {
+		t.Fatalf("Unexpected number of lines:\n%s", buf.String())
+	}
+	for _, line := range lines[2:] {
+		if !strings.HasPrefix(line, "- [ ] **") {
+			t.Errorf("Expected a task list item, got %q", line)
+		}
+	}
+}
+
+// Test JSON output and the fallback for codes without a checklist
+func TestTroubleshootJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runTroubleshoot([]string{"504", "404", "--json"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var reports []troubleshootReport
+	if err := json.Unmarshal(buf.Bytes(), &reports); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(reports))
+	}
+	if reports[0].Checklist[0].ID != "slow-upstream" || reports[0].Notice != "" {
+		t.Errorf("Unexpected report for 504: %+v", reports[0])
+	}
+	if len(reports[1].Checklist) != 0 || reports[1].Notice == "" || reports[1].Long != "Requested resource could not be found" {
+		t.Errorf("Expected a fallback report for 404: %+v", reports[1])
+	}
+
+	buf.Reset()
+	if err := runTroubleshoot([]string{"404"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Note: no troubleshooting checklist for 404") {
+		t.Errorf("Expected fallback notice:\n%s", buf.String())
+	}
+}
+
+// Test invalid arguments are rejected
+func TestTroubleshootErrors(t *testing.T) {
+	testCases := [][]string{
+		{},
+		{"abc"},
+		{"999"},
+		{"502", "--json", "--markdown"},
+	}
+	for _, args := range testCases {
+		var buf bytes.Buffer
+		if err := runTroubleshoot(args, &buf); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// builtinCodes keeps the embedded dataset while statusCodes may be
+// overlaid with the user's custom codes
+var builtinCodes = statusCodes
+
+// finding is one problem found in a data file
+type finding struct {
+	line, column int
+	severity     string // "error" or "warning"
+	message      string
+}
+
+// yamlErrorLine extracts the line number from a YAML syntax error
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// dataFields are the keys a data file entry may have
+var dataFields = map[string]bool{"code": true, "type": true, "short": true, "long": true}
+
+// validateDataFile checks a YAML or JSON data file against the schema and
+// the dataset rules, returning the findings in file order and the codes
+// of the entries that have no errors
+func validateDataFile(data []byte) ([]finding, []StatusCode) {
+	var findings []finding
+	report := func(n *yaml.Node, severity, format string, args ...interface{}) {
+		findings = append(findings, finding{n.Line, n.Column, severity, fmt.Sprintf(format, args...)})
+	}
+
+	var entries []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line := 0
+			if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+			return append(findings, finding{line, 0, "error", err.Error()}), nil
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		switch root := doc.Content[0]; root.Kind {
+		case yaml.SequenceNode:
+			entries = append(entries, root.Content...)
+		case yaml.MappingNode:
+			entries = append(entries, root)
+		default:
+			report(root, "error", "expected a list of status codes")
+		}
+	}
+
+	var codes []StatusCode
+	firstLine := make(map[int]int)
+	for _, entry := range entries {
+		if entry.Kind != yaml.MappingNode {
+			report(entry, "error", "expected a status code with code and type fields")
+			continue
+		}
+
+		valid := true
+		fields := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(entry.Content); i += 2 {
+			key, value := entry.Content[i], entry.Content[i+1]
+			if !dataFields[key.Value] {
+				report(key, "warning", "unknown field '%s' is ignored", key.Value)
+				continue
+			}
+			if value.Kind != yaml.ScalarNode {
+				report(value, "error", "%s must be a single value", key.Value)
+				valid = false
+				continue
+			}
+			fields[key.Value] = value
+		}
+
+		codeNode, typeNode := fields["code"], fields["type"]
+		if codeNode == nil {
+			report(entry, "error", "missing required field: code")
+			continue
+		}
+		code, err := strconv.Atoi(codeNode.Value)
+		switch {
+		case err != nil || codeNode.Tag != "!!int":
+			report(codeNode, "error", "code must be a number, got '%s'", codeNode.Value)
+			continue
+		case code < 100 || code > 599:
+			report(codeNode, "error", "code %d is out of range - must be 100-599", code)
+			valid = false
+		case firstLine[code] != 0:
+			report(codeNode, "error", "code %d is already defined on line %d", code, firstLine[code])
+			valid = false
+		default:
+			firstLine[code] = codeNode.Line
+		}
+		if typeNode == nil || typeNode.Value == "" {
+			report(entry, "error", "code %d has no type", code)
+			valid = false
+		}
+		for _, sc := range builtinCodes {
+			if sc.Code == code && valid {
+				report(codeNode, "warning", "code %d overrides the built-in %s", code, describe(sc))
+			}
+		}
+
+		if valid {
+			var sc StatusCode
+			if err := entry.Decode(&sc); err != nil {
+				report(entry, "error", "%v", err)
+				continue
+			}
+			codes = append(codes, sc)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].line != findings[j].line {
+			return findings[i].line < findings[j].line
+		}
+		return findings[i].column < findings[j].column
+	})
+	return findings, codes
+}
+
+// countFindings returns the number of errors and warnings
+func countFindings(findings []finding) (errs, warnings int) {
+	for _, f := range findings {
+		if f.severity == "error" {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	return errs, warnings
+}
+
+// plural formats a count with a singular or plural noun
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// printFindings writes one path:line:column line per finding; syntax
+// errors have no column
+func printFindings(w io.Writer, path string, findings []finding) {
+	for _, f := range findings {
+		position := fmt.Sprintf("%s:%d:%d", path, f.line, f.column)
+		if f.column == 0 {
+			position = fmt.Sprintf("%s:%d", path, f.line)
+		}
+		fmt.Fprintf(w, "%s: %s: %s\n", position, f.severity, f.message)
+	}
+}
+
+// runCustomValidate checks a data file, failing on errors, or on warnings
+// too with --strict
+func runCustomValidate(args []string, w io.Writer) error {
+	fs := newFlagSet("custom validate")
+	strict := fs.Bool("strict", false, "Treat warnings as errors")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("custom validate takes at most one file, got %d", len(positional))
+	}
+	var path string
+	if len(positional) == 1 {
+		path = positional[0]
+	} else if path, err = customDataPath(); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	findings, _ := validateDataFile(data)
+	printFindings(w, path, findings)
+
+	errs, warnings := countFindings(findings)
+	if errs == 0 && warnings == 0 {
+		fmt.Fprintf(w, "%s: no problems found\n", path)
+		return nil
+	}
+	fmt.Fprintf(w, "%s, %s\n", plural(errs, "error"), plural(warnings, "warning"))
+	if errs > 0 || *strict {
+		return &cliError{Kind: errInvalidInput, Message: fmt.Sprintf("%s is not valid", path), Input: path}
+	}
+	return nil
+}
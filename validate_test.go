@@ -0,0 +1,115 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test each schema and semantic rule is reported at its line and column
+func TestValidateDataFile(t *testing.T) {
+	data := `- code: 404
+  type: Client Error
+  short: Missing
+- code: 209
+  type: Success
+  colour: blue
+- code: 700
+  type: Odd
+- code: abc
+  type: Odd
+- code: 209
+  type: Success
+- type: Nothing
+- code: 210
+  short: [a, b]
+`
+	findings, codes := validateDataFile([]byte(data))
+
+	var buf bytes.Buffer
+	printFindings(&buf, "custom.yaml", findings)
+	expected := `custom.yaml:1:9: warning: code 404 overrides the built-in Not Found (Client Error)
+custom.yaml:6:3: warning: unknown field 'colour' is ignored
+custom.yaml:7:9: error: code 700 is out of range - must be 100-599
+custom.yaml:9:9: error: code must be a number, got 'abc'
+custom.yaml:11:9: error: code 209 is already defined on line 4
+custom.yaml:13:3: error: missing required field: code
+custom.yaml:14:3: error: code 210 has no type
+custom.yaml:15:10: error: short must be a single value
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+	if errs, warnings := countFindings(findings); errs != 6 || warnings != 2 {
+		t.Errorf("Expected 6 errors and 2 warnings, got %d and %d", errs, warnings)
+	}
+	if len(codes) != 2 || codes[0].Code != 404 || codes[1].Code != 209 {
+		t.Errorf("Expected the two valid entries, got %+v", codes)
+	}
+}
+
+// Test JSON files and YAML syntax errors
+func TestValidateDataFileSyntax(t *testing.T) {
+	findings, codes := validateDataFile([]byte(`[{"code": 299, "type": "Success"}]`))
+	if len(findings) != 0 || len(codes) != 1 {
+		t.Errorf("Expected a valid JSON file, got %v", findings)
+	}
+
+	findings, _ = validateDataFile([]byte("- code: 404\n  type: [x\n"))
+	if len(findings) != 1 || findings[0].severity != "error" || findings[0].line == 0 {
+		t.Errorf("Expected one syntax error with a line, got %+v", findings)
+	}
+
+	findings, _ = validateDataFile([]byte("just a string\n"))
+	if len(findings) != 1 || !strings.Contains(findings[0].message, "expected a list") {
+		t.Errorf("Expected a schema error, got %+v", findings)
+	}
+}
+
+// Test warnings only fail validation with --strict
+func TestRunCustomValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	if err := os.WriteFile(path, []byte("- code: 404\n  type: Client Error\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := runCustom([]string{"validate", path}, nil, &buf); err != nil {
+		t.Errorf("Expected warnings to pass, got %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "0 errors, 1 warning\n") {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+	if err := runCustom([]string{"validate", "--strict", path}, nil, &buf); err == nil {
+		t.Error("Expected --strict to fail on warnings")
+	}
+
+	os.WriteFile(path, []byte("- code: 299\n  type: Success\n"), 0644)
+	buf.Reset()
+	if err := runCustom([]string{"validate", path}, nil, &buf); err != nil || !strings.Contains(buf.String(), "no problems found") {
+		t.Errorf("Expected a clean file, got %v:\n%s", err, buf.String())
+	}
+}
@@ -0,0 +1,107 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import "unicode"
+
+// wideRanges are the East Asian Wide and Fullwidth blocks, plus the emoji
+// blocks, that terminals draw two columns wide
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo initials
+	{0x231A, 0x231B},   // watch, hourglass
+	{0x23E9, 0x23EC},   // media controls
+	{0x2614, 0x2615},   // umbrella, hot beverage
+	{0x26A1, 0x26A1},   // high voltage
+	{0x26D4, 0x26D4},   // no entry
+	{0x2705, 0x2705},   // check mark button
+	{0x274C, 0x274C},   // cross mark
+	{0x2753, 0x2755},   // question and exclamation marks
+	{0x2757, 0x2757},   // heavy exclamation mark
+	{0x2E80, 0x303E},   // CJK radicals, symbols and punctuation
+	{0x3041, 0x33FF},   // kana, compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // fullwidth forms
+	{0xFFE0, 0xFFE6},   // fullwidth signs
+	{0x1F300, 0x1F64F}, // pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // transport and map symbols
+	{0x1F900, 0x1F9FF}, // supplemental pictographs
+	{0x20000, 0x3FFFD}, // CJK extensions B onwards
+}
+
+// runeWidth returns the number of terminal columns r takes up
+func runeWidth(r rune) int {
+	switch {
+	case r == 0x200B || r == 0x200D || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cc):
+		return 0
+	}
+	for _, w := range wideRanges {
+		if r >= w.lo && r <= w.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// variationEmoji is the variation selector asking for emoji presentation,
+// which widens the symbol before it to two columns
+const variationEmoji = 0xFE0F
+
+// runeWidthAfter is runeWidth for r following prev, counting the column
+// an emoji variation selector adds to a narrow symbol
+func runeWidthAfter(prev, r rune) int {
+	if r == variationEmoji && runeWidth(prev) == 1 {
+		return 1
+	}
+	return runeWidth(r)
+}
+
+// displayWidth returns the number of terminal columns s takes up
+func displayWidth(s string) int {
+	n := 0
+	var prev rune
+	for _, r := range s {
+		n += runeWidthAfter(prev, r)
+		prev = r
+	}
+	return n
+}
+
+// cutWidth splits s after as many runes as fit in width columns, always
+// taking at least one rune so the caller makes progress
+func cutWidth(s string, width int) (head, rest string) {
+	n := 0
+	var prev rune
+	for i, r := range s {
+		w := runeWidthAfter(prev, r)
+		if n+w > width && i > 0 {
+			return s[:i], s[i:]
+		}
+		n += w
+		prev = r
+	}
+	return s, ""
+}
@@ -0,0 +1,62 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import "testing"
+
+// Test display widths of narrow, wide and zero-width runes
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{
+		"Not Found": 9,
+		"héllo":     5,
+		"見つかりません":   14,
+		"🍵 teapot":  9,
+		"e\u0301":   1,
+		"":          0,
+	}
+	for s, want := range tests {
+		if got := displayWidth(s); got != want {
+			t.Errorf("displayWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+// Test cutting never splits a rune or overshoots the width
+func TestCutWidth(t *testing.T) {
+	tests := []struct {
+		s          string
+		width      int
+		head, rest string
+	}{
+		{"abcdef", 3, "abc", "def"},
+		{"見つかり", 3, "見", "つかり"},
+		{"見つかり", 4, "見つ", "かり"},
+		{"見", 1, "見", ""},
+		{"ab", 5, "ab", ""},
+	}
+	for _, tt := range tests {
+		head, rest := cutWidth(tt.s, tt.width)
+		if head != tt.head || rest != tt.rest {
+			t.Errorf("cutWidth(%q, %d) = %q, %q, want %q, %q", tt.s, tt.width, head, rest, tt.head, tt.rest)
+		}
+	}
+}
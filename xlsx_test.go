@@ -0,0 +1,89 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Test the workbook has every part and a sheet with a frozen header
+func TestPrintXLSX(t *testing.T) {
+	long := `Fish & "chips" <served>`
+	codes := []StatusCode{
+		{Code: 200, Type: "Success", Short: strPtr("OK"), Long: &long},
+		{Code: 418, Type: "Client Error", Short: strPtr("I'm a teapot")},
+	}
+
+	var buf bytes.Buffer
+	if err := printXLSX(&buf, codes, baseFields); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`,
+		`<col min="1" max="1" width="6" customWidth="1"/>`,
+		`<col min="4" max="4" width="25" customWidth="1"/>`,
+		`<c r="A1" s="1" t="inlineStr"><is><t>Code</t></is></c>`,
+		`<c r="A2"><v>200</v></c>`,
+		`<t xml:space="preserve">Fish &amp; &#34;chips&#34; &lt;served&gt;</t>`,
+		`<row r="3">`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Expected %q in the sheet:\n%s", want, sheet)
+		}
+	}
+}
+
+// Test column letters past Z
+func TestXLSXColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 3: "D", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
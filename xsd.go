@@ -0,0 +1,149 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// xsdType maps a Go type to its XML Schema built-in type
+func xsdType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "xs:int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "xs:unsignedInt"
+	case reflect.Float32, reflect.Float64:
+		return "xs:double"
+	case reflect.Bool:
+		return "xs:boolean"
+	default:
+		return "xs:string"
+	}
+}
+
+// xsdWriter writes indented schema lines
+type xsdWriter struct {
+	b     strings.Builder
+	depth int
+}
+
+func (x *xsdWriter) line(format string, args ...interface{}) {
+	x.b.WriteString(strings.Repeat("  ", x.depth))
+	fmt.Fprintf(&x.b, format, args...)
+	x.b.WriteString("\n")
+}
+
+// complexType describes a struct from its xml tags; pointer, omitempty and
+// slice fields are optional and ",attr" fields become attributes
+func (x *xsdWriter) complexType(t reflect.Type) {
+	x.line("<xs:complexType>")
+	x.depth++
+
+	var attrs []string
+	x.line("<xs:sequence>")
+	x.depth++
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Name == "XMLName" {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("xml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		ft := f.Type
+		optional := strings.Contains(opts, "omitempty")
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+			optional = true
+		}
+
+		if strings.Contains(opts, "attr") {
+			use := "required"
+			if optional {
+				use = "optional"
+			}
+			attrs = append(attrs, fmt.Sprintf(`<xs:attribute name="%s" type="%s" use="%s"/>`, name, xsdType(ft), use))
+			continue
+		}
+
+		occurs := ""
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 {
+			ft = ft.Elem()
+			occurs = ` minOccurs="0" maxOccurs="unbounded"`
+		} else if optional {
+			occurs = ` minOccurs="0"`
+		}
+
+		if ft.Kind() == reflect.Struct {
+			x.line(`<xs:element name="%s"%s>`, name, occurs)
+			x.depth++
+			x.complexType(ft)
+			x.depth--
+			x.line("</xs:element>")
+		} else {
+			x.line(`<xs:element name="%s" type="%s"%s/>`, name, xsdType(ft), occurs)
+		}
+	}
+	x.depth--
+	x.line("</xs:sequence>")
+
+	for _, attr := range attrs {
+		x.line("%s", attr)
+	}
+	x.depth--
+	x.line("</xs:complexType>")
+}
+
+// xsdDocument builds an XML Schema for a root struct, named by its XMLName tag
+func xsdDocument(root reflect.Type) string {
+	name := root.Name()
+	if f, ok := root.FieldByName("XMLName"); ok {
+		name, _, _ = strings.Cut(f.Tag.Get("xml"), ",")
+	}
+
+	x := &xsdWriter{}
+	x.b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	x.line(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">`)
+	x.depth++
+	x.line(`<xs:element name="%s">`, name)
+	x.depth++
+	x.complexType(root)
+	x.depth--
+	x.line("</xs:element>")
+	x.depth--
+	x.line("</xs:schema>")
+	return x.b.String()
+}
+
+// printXSD outputs the XML Schema for the XML output
+func printXSD(w io.Writer) {
+	fmt.Fprint(w, xsdDocument(reflect.TypeOf(HTTPStatusCollection{})))
+}
@@ -0,0 +1,170 @@
+/*
+httpstatus - A CLI tool for looking up HTTP status codes in multiple formats.
+Copyright (C) 2025  Adam Maltby
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+For questions, issues, or contributions, please visit:
+https://github.com/yodanator/httpstatus
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// schemaElement is the subset of an XSD element declaration the tests check
+type schemaElement struct {
+	Name      string          `xml:"name,attr"`
+	Type      string          `xml:"type,attr"`
+	MinOccurs string          `xml:"minOccurs,attr"`
+	MaxOccurs string          `xml:"maxOccurs,attr"`
+	Children  []schemaElement `xml:"complexType>sequence>element"`
+	Attrs     []struct {
+		Name string `xml:"name,attr"`
+		Use  string `xml:"use,attr"`
+	} `xml:"complexType>attribute"`
+}
+
+// xmlNode is a generic XML element
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []xmlNode  `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+// validateNode checks an element against its declaration
+func validateNode(t *testing.T, decl schemaElement, node xmlNode) {
+	t.Helper()
+	if node.XMLName.Local != decl.Name {
+		t.Errorf("Expected element %s, got %s", decl.Name, node.XMLName.Local)
+		return
+	}
+
+	if len(decl.Children) == 0 {
+		if decl.Type == "xs:int" {
+			if _, err := strconv.Atoi(node.Text); err != nil {
+				t.Errorf("%s: expected an integer, got %q", decl.Name, node.Text)
+			}
+		}
+		return
+	}
+
+	for _, attr := range decl.Attrs {
+		found := false
+		for _, a := range node.Attrs {
+			found = found || a.Name.Local == attr.Name
+		}
+		if attr.Use == "required" && !found {
+			t.Errorf("%s: missing required attribute %s", decl.Name, attr.Name)
+		}
+	}
+
+	// Children must follow the sequence, each within its occurrence bounds
+	i := 0
+	for _, child := range decl.Children {
+		count := 0
+		for i < len(node.Children) && node.Children[i].XMLName.Local == child.Name {
+			validateNode(t, child, node.Children[i])
+			count++
+			i++
+		}
+		minOccurs, maxOccurs := 1, 1
+		if child.MinOccurs != "" {
+			minOccurs, _ = strconv.Atoi(child.MinOccurs)
+		}
+		if child.MaxOccurs == "unbounded" {
+			maxOccurs = -1
+		}
+		if count < minOccurs || (maxOccurs >= 0 && count > maxOccurs) {
+			t.Errorf("%s: %s occurs %d times", decl.Name, child.Name, count)
+		}
+	}
+	if i < len(node.Children) {
+		t.Errorf("%s: unexpected element %s", decl.Name, node.Children[i].XMLName.Local)
+	}
+}
+
+// Test XML output validates against the generated schema
+func TestXSDValidatesXMLOutput(t *testing.T) {
+	var schema struct {
+		Elements []schemaElement `xml:"element"`
+	}
+	var xsd bytes.Buffer
+	printXSD(&xsd)
+	if err := xml.Unmarshal(xsd.Bytes(), &schema); err != nil {
+		t.Fatalf("Schema is not valid XML: %v", err)
+	}
+	if len(schema.Elements) != 1 {
+		t.Fatalf("Expected one root element, got %d", len(schema.Elements))
+	}
+
+	all := prepareOutputs(statusCodes, false, true)
+	outputs := [][]StatusCode{
+		all,
+		prepareOutputs(statusCodes, false, false),
+		applyFramework(all, "rails"),
+		nil,
+	}
+	for _, codes := range outputs {
+		for _, pretty := range []bool{false, true} {
+			var buf bytes.Buffer
+			printXML(&buf, codes, pretty)
+			var root xmlNode
+			if err := xml.Unmarshal(buf.Bytes(), &root); err != nil {
+				t.Fatal(err)
+			}
+			validateNode(t, schema.Elements[0], root)
+		}
+	}
+}
+
+// Test the schema follows the struct fields and handles attributes
+func TestXSDDocument(t *testing.T) {
+	type item struct {
+		ID    int     `xml:"id,attr"`
+		Label *string `xml:"label,attr"`
+		Name  string  `xml:"name"`
+		Skip  string  `xml:"-"`
+		Flag  bool    `xml:"flag,omitempty"`
+	}
+	type root struct {
+		XMLName xml.Name `xml:"items"`
+		Items   []item   `xml:"item"`
+	}
+
+	doc := xsdDocument(reflect.TypeOf(root{}))
+	for _, want := range []string{
+		`<xs:element name="items">`,
+		`<xs:element name="item" minOccurs="0" maxOccurs="unbounded">`,
+		`<xs:element name="name" type="xs:string"/>`,
+		`<xs:element name="flag" type="xs:boolean" minOccurs="0"/>`,
+		`<xs:attribute name="id" type="xs:int" use="required"/>`,
+		`<xs:attribute name="label" type="xs:string" use="optional"/>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected %s in schema:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "Skip") || strings.Contains(doc, `"XMLName"`) {
+		t.Errorf("Unexpected field in schema:\n%s", doc)
+	}
+}